@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"time"
+
+	"github.com/Andamio-Platform/andamio-indexer/internal/slottime"
 )
 
 const (
@@ -16,10 +18,12 @@ var (
 )
 
 type Config struct {
-	Network  Network  `json:"network"`
-	Indexer  Indexer  `json:"indexer"`
-	Database Database `json:"database"`
-	Andamio  Andamio  `json:"andamio"`
+	Network   Network   `json:"network"`
+	Indexer   Indexer   `json:"indexer"`
+	Database  Database  `json:"database"`
+	Auth      Auth      `json:"auth"`
+	RateLimit RateLimit `json:"rateLimit"`
+	Andamio   Andamio   `json:"andamio"`
 }
 
 type Network struct {
@@ -29,6 +33,53 @@ type Network struct {
 	LocalKupoEndpoint          string `json:"localKupoEndpoint"`
 	BlinklabKupoEndpoint       string `json:"blinklabKupoEndpoint"`
 	CFCardanoNodeEndpoint      string `json:"CFCardanoNodeEndpoint"`
+	// ChainTime overrides the slot to time conversion parameters of the network.
+	ChainTime *ChainTime `json:"chainTime,omitempty"`
+}
+
+// ChainTime holds chain time parameters overriding those of the public network (mainnet,
+// preprod or preview) the magic belongs to. Unset fields keep the public network value;
+// all of them must be set for other networks.
+type ChainTime struct {
+	// SystemStart is the start time of the first Byron slot, in RFC 3339.
+	SystemStart       *time.Time `json:"systemStart,omitempty"`
+	ByronSlotLengthMs *uint64    `json:"byronSlotLengthMs,omitempty"`
+	ByronEpochLength  *uint64    `json:"byronEpochLength,omitempty"`
+	// ShelleyStartSlot and ShelleyStartEpoch are 0 for networks starting in Shelley.
+	ShelleyStartSlot  *uint64 `json:"shelleyStartSlot,omitempty"`
+	ShelleyStartEpoch *uint64 `json:"shelleyStartEpoch,omitempty"`
+	SlotLengthMs      *uint64 `json:"slotLengthMs,omitempty"`
+	EpochLength       *uint64 `json:"epochLength,omitempty"`
+}
+
+// ChainTimeParams returns the slot to time conversion parameters of the network, with the
+// chainTime overrides applied. It returns false when they are incomplete.
+func (n *Network) ChainTimeParams() (slottime.Params, bool) {
+	params, _ := slottime.ForNetwork(n.Magic)
+	if o := n.ChainTime; o != nil {
+		if o.SystemStart != nil {
+			params.SystemStart = *o.SystemStart
+		}
+		if o.ByronSlotLengthMs != nil {
+			params.ByronSlotLength = time.Duration(*o.ByronSlotLengthMs) * time.Millisecond
+		}
+		if o.ByronEpochLength != nil {
+			params.ByronEpochLength = *o.ByronEpochLength
+		}
+		if o.ShelleyStartSlot != nil {
+			params.ShelleyStartSlot = *o.ShelleyStartSlot
+		}
+		if o.ShelleyStartEpoch != nil {
+			params.ShelleyStartEpoch = *o.ShelleyStartEpoch
+		}
+		if o.SlotLengthMs != nil {
+			params.SlotLength = time.Duration(*o.SlotLengthMs) * time.Millisecond
+		}
+		if o.EpochLength != nil {
+			params.EpochLength = *o.EpochLength
+		}
+	}
+	return params, params.Valid()
 }
 
 // "intercerptHash": "cd510710d2d680240540595aea3306750ad275e38ab4511eb10d2b5e02cc0186",
@@ -49,6 +100,39 @@ type Database struct {
 	DatabaseDIR string `json:"databaseDir"`
 }
 
+type Auth struct {
+	// AllowAnonymousRead lets requests without an API key call read-only endpoints.
+	// Admin endpoints always require an admin key.
+	AllowAnonymousRead bool `json:"allowAnonymousRead"`
+}
+
+type RateLimit struct {
+	// DailyRowQuota caps the number of result rows a client can fetch per UTC day.
+	// API keys with their own quota override it. 0 disables the quota.
+	DailyRowQuota int64 `json:"dailyRowQuota"`
+	// Default applies to every route group without an entry in Groups.
+	Default RateLimitRule `json:"default"`
+	// Groups holds per route group rules, keyed by group name (e.g. "addresses", "assets").
+	Groups map[string]RateLimitRule `json:"groups"`
+}
+
+type RateLimitRule struct {
+	// RequestsPerSecond is the token bucket refill rate. 0 disables rate limiting.
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	// Burst is the token bucket size.
+	Burst int `json:"burst"`
+	// MaxLimit is the largest page size a request may ask for. 0 leaves it unbounded.
+	MaxLimit int `json:"maxLimit"`
+}
+
+// Rule returns the rate limit rule for the given route group.
+func (r *RateLimit) Rule(group string) RateLimitRule {
+	if rule, ok := r.Groups[group]; ok {
+		return rule
+	}
+	return r.Default
+}
+
 type Andamio struct {
 	GlobalAdmin           string                 `json:"globalAdmin"`
 	GlobalStateRefMS      MintingContractConfig  `json:"globalStateRefMS"`
@@ -89,6 +173,11 @@ func Load(configFile string) error {
 		if err != nil {
 			return fmt.Errorf("error parsing config file: %v", err)
 		}
+		if GlobalConfig.Network.ChainTime != nil {
+			if _, ok := GlobalConfig.Network.ChainTimeParams(); !ok {
+				return fmt.Errorf("incomplete chainTime parameters for network %d", GlobalConfig.Network.Magic)
+			}
+		}
 	}
 
 	return nil
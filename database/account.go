@@ -0,0 +1,61 @@
+package database
+
+import (
+	"encoding/hex"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+)
+
+// GetTxsByStakeCredential retrieves transactions touching any address delegated to the given stake credential, with pagination support
+func (d *Database) GetTxsByStakeCredential(stakeCredential []byte, limit, offset int, txn *Txn) ([]Transaction, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	modelsTxs, err := d.metadata.GetTxsByStakeCredential(txn.Metadata(), stakeCredential, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return d.loadTransactions(modelsTxs, txn), nil
+}
+
+// GetUnspentOutputsByStakeCredential retrieves the unspent outputs delegated to the given stake credential, with pagination support
+func (d *Database) GetUnspentOutputsByStakeCredential(stakeCredential []byte, limit, offset int, txn *Txn) ([]models.TransactionOutput, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	return d.metadata.GetUnspentOutputsByStakeCredential(txn.Metadata(), stakeCredential, limit, offset)
+}
+
+// GetWithdrawalsByStakeCredential retrieves the reward withdrawals made from the given stake credential, with pagination support
+func (d *Database) GetWithdrawalsByStakeCredential(stakeCredential []byte, limit, offset int, txn *Txn) ([]models.Withdrawal, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	return d.metadata.GetWithdrawalsByStakeCredential(txn.Metadata(), stakeCredential, limit, offset)
+}
+
+// loadTransactions converts transaction models to database transactions and loads their CBOR from the blob store
+func (d *Database) loadTransactions(modelsTxs []models.Transaction, txn *Txn) []Transaction {
+	dbTxs := make([]Transaction, len(modelsTxs))
+	for i, modelTx := range modelsTxs {
+		dbTxs[i] = newTransaction(modelTx)
+
+		// Load CBOR for each transaction
+		cborKey := TxBlobKey(modelTx.TransactionHash)
+		cborItem, err := txn.Blob().Get(cborKey)
+		if err != nil {
+			d.Logger().Warn("failed to load transaction CBOR for transaction", "txHash", hex.EncodeToString(modelTx.TransactionHash), "error", err)
+			continue
+		}
+		cborBytes, err := cborItem.ValueCopy(nil)
+		if err != nil {
+			d.Logger().Warn("failed to copy transaction CBOR value", "txHash", hex.EncodeToString(modelTx.TransactionHash), "error", err)
+			continue
+		}
+		dbTxs[i].TransactionCBOR = cborBytes
+	}
+	return dbTxs
+}
@@ -12,3 +12,16 @@ func (d *Database) GetAllAddresses() ([]string, error) {
 
 	return addrList, nil
 }
+
+// GetAddressStartSlots returns every watched address mapped to the slot indexing starts at
+func (d *Database) GetAddressStartSlots() (map[string]uint64, error) {
+	txn := d.Transaction(false)
+	defer txn.Discard()
+
+	startSlots, err := d.metadata.GetAddressStartSlots(txn.Metadata())
+	if err != nil {
+		return nil, err
+	}
+
+	return startSlots, nil
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"bytes"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+)
+
+// AssetHolding is a quantity of an asset held at an address
+type AssetHolding struct {
+	Address  string
+	Quantity uint64
+}
+
+// AssetTransfer is how a transaction moved an asset: the addresses whose UTxOs holding it
+// the transaction consumed, and the addresses it produced UTxOs holding it to
+type AssetTransfer struct {
+	TransactionHash []byte
+	BlockNumber     uint64
+	SlotNumber      uint64
+	IsValid         bool
+	From            []AssetHolding
+	To              []AssetHolding
+	// Quantity is the quantity the transaction moved, the larger of the quantities it
+	// consumed and produced so that mints and burns count
+	Quantity uint64
+	// Minted is the net quantity the transaction minted, negative when it burned
+	Minted int64
+}
+
+// GetAssetHistory retrieves the transfers of an asset, oldest first with pagination.
+// Transactions that failed phase-2 validation only move the asset through their
+// collateral and collateral return, and mint nothing.
+func (d *Database) GetAssetHistory(assetFingerprint []byte, limit, offset int, txn *Txn) ([]AssetTransfer, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	modelsTxs, err := d.metadata.GetAssetHistoryTxs(txn.Metadata(), assetFingerprint, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	transfers := []AssetTransfer{}
+	for _, tx := range modelsTxs {
+		transfer := AssetTransfer{
+			TransactionHash: tx.TransactionHash,
+			BlockNumber:     tx.BlockNumber,
+			SlotNumber:      tx.SlotNumber,
+			IsValid:         tx.IsValid,
+			From:            []AssetHolding{},
+			To:              []AssetHolding{},
+		}
+		var consumed, produced []models.TransactionOutput
+		if tx.IsValid {
+			for _, input := range tx.Inputs {
+				consumed = append(consumed, models.TransactionOutput{Address: input.Address, Asset: input.Asset})
+			}
+			produced = tx.Outputs
+			for _, mint := range tx.Mints {
+				if bytes.Equal(mint.Fingerprint, assetFingerprint) {
+					transfer.Minted += mint.Quantity
+				}
+			}
+		} else {
+			for _, collateral := range tx.Collateral {
+				output, err := d.metadata.GetTxOutputByUTxO(txn.Metadata(), collateral.UTxOID, collateral.UTxOIDIndex)
+				if err != nil {
+					return nil, err
+				}
+				if output != nil {
+					consumed = append(consumed, *output)
+				}
+			}
+			if tx.CollateralReturn != nil {
+				produced = []models.TransactionOutput{*tx.CollateralReturn}
+			}
+		}
+		var consumedQuantity, producedQuantity uint64
+		transfer.From, consumedQuantity = assetHoldings(consumed, assetFingerprint)
+		transfer.To, producedQuantity = assetHoldings(produced, assetFingerprint)
+		transfer.Quantity = max(consumedQuantity, producedQuantity)
+		transfers = append(transfers, transfer)
+	}
+	return transfers, nil
+}
+
+// assetHoldings sums the quantity of an asset held in UTxOs per address, in order of
+// first appearance, and overall
+func assetHoldings(utxos []models.TransactionOutput, assetFingerprint []byte) ([]AssetHolding, uint64) {
+	holdings := []AssetHolding{}
+	holdingIndex := make(map[string]int)
+	var total uint64
+	for _, utxo := range utxos {
+		for _, asset := range utxo.Asset {
+			if !bytes.Equal(asset.Fingerprint, assetFingerprint) {
+				continue
+			}
+			address := string(utxo.Address)
+			i, ok := holdingIndex[address]
+			if !ok {
+				i = len(holdings)
+				holdingIndex[address] = i
+				holdings = append(holdings, AssetHolding{Address: address})
+			}
+			holdings[i].Quantity += asset.Amount
+			total += asset.Amount
+		}
+	}
+	return holdings, total
+}
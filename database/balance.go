@@ -0,0 +1,96 @@
+package database
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/slottime"
+)
+
+// Balance history bucket sizes
+const (
+	BalanceBucketEpoch = "epoch"
+	BalanceBucketDay   = "day"
+)
+
+// AddressBalance is what an address held in UTxOs at the end of a slot
+type AddressBalance struct {
+	Address   string
+	Lovelace  uint64
+	UTxOCount int64
+	Assets    []models.AssetBalance
+}
+
+// BalancePoint is the lovelace an address received and sent within a bucket of the balance
+// history, and what it held at the end of the bucket
+type BalancePoint struct {
+	// Bucket is the epoch number or the UTC date of the bucket
+	Bucket    string
+	StartSlot uint64
+	StartTime time.Time
+	Received  uint64
+	Sent      uint64
+	Lovelace  uint64
+}
+
+// GetAddressBalance retrieves the lovelace and native assets an address held in UTxOs at
+// the end of a slot
+func (d *Database) GetAddressBalance(address string, slot uint64, txn *Txn) (*AddressBalance, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	balance := &AddressBalance{Address: address}
+	var err error
+	balance.Lovelace, balance.UTxOCount, err = d.metadata.GetAddressLovelaceAtSlot(txn.Metadata(), []byte(address), slot)
+	if err != nil {
+		return nil, err
+	}
+	balance.Assets, err = d.metadata.GetAddressAssetsAtSlot(txn.Metadata(), []byte(address), slot)
+	if err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// GetAddressBalanceHistory retrieves the lovelace balance history of an address, one point
+// per epoch or UTC day in which its balance changed, from the per-slot movements of its UTxOs
+func (d *Database) GetAddressBalanceHistory(address string, bucket string, params slottime.Params, txn *Txn) ([]BalancePoint, error) {
+	if bucket != BalanceBucketEpoch && bucket != BalanceBucketDay {
+		return nil, errors.New("bucket must be epoch or day")
+	}
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	deltas, err := d.metadata.GetAddressBalanceDeltas(txn.Metadata(), []byte(address))
+	if err != nil {
+		return nil, err
+	}
+	points := []BalancePoint{}
+	var lovelace uint64
+	for _, delta := range deltas {
+		var point BalancePoint
+		if bucket == BalanceBucketEpoch {
+			epoch := params.SlotToEpoch(delta.SlotNumber)
+			point.Bucket = strconv.FormatUint(epoch, 10)
+			point.StartSlot = params.EpochStartSlot(epoch)
+			point.StartTime = params.SlotToTime(point.StartSlot)
+		} else {
+			point.StartTime = params.SlotToTime(delta.SlotNumber).UTC().Truncate(24 * time.Hour)
+			point.Bucket = point.StartTime.Format(time.DateOnly)
+			point.StartSlot = params.TimeToSlot(point.StartTime)
+		}
+		if len(points) == 0 || points[len(points)-1].Bucket != point.Bucket {
+			points = append(points, point)
+		}
+		current := &points[len(points)-1]
+		current.Received += delta.Received
+		current.Sent += delta.Sent
+		lovelace = lovelace + delta.Received - delta.Sent
+		current.Lovelace = lovelace
+	}
+	return points, nil
+}
@@ -0,0 +1,14 @@
+package database
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+)
+
+// GetCertificates retrieves decoded certificates, optionally by stake credential, pool, DRep and type, with pagination support
+func (d *Database) GetCertificates(stakeCredential []byte, poolKeyHash []byte, drepCredential []byte, certTypes []string, limit, offset int, txn *Txn) ([]models.Certificate, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	return d.metadata.GetCertificates(txn.Metadata(), stakeCredential, poolKeyHash, drepCredential, certTypes, limit, offset)
+}
@@ -0,0 +1,27 @@
+package database
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+)
+
+// GetTxsByPaymentCredential retrieves transactions touching any address with the given payment key hash or script hash, with pagination support
+func (d *Database) GetTxsByPaymentCredential(paymentCredential []byte, limit, offset int, txn *Txn) ([]Transaction, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	modelsTxs, err := d.metadata.GetTxsByPaymentCredential(txn.Metadata(), paymentCredential, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return d.loadTransactions(modelsTxs, txn), nil
+}
+
+// GetUnspentOutputsByPaymentCredential retrieves the unspent outputs locked by the given payment credential, with pagination support
+func (d *Database) GetUnspentOutputsByPaymentCredential(paymentCredential []byte, limit, offset int, txn *Txn) ([]models.TransactionOutput, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	return d.metadata.GetUnspentOutputsByPaymentCredential(txn.Metadata(), paymentCredential, limit, offset)
+}
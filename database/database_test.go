@@ -15,10 +15,15 @@
 package database_test
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
 	"gorm.io/gorm"
 )
 
@@ -60,3 +65,1895 @@ func TestInMemorySqliteMultipleTransaction(t *testing.T) {
 		t.Fatalf("unexpected error: %s", err)
 	}
 }
+
+// newTestDatabase returns a database of its own for a test, closed when the test ends
+func newTestDatabase(t *testing.T) *database.Database {
+	db, err := database.New(nil, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() {
+		db.Close() //nolint:errcheck
+	})
+	return db
+}
+
+// newTestStore returns a metadata store of its own for a test, closed when the test ends
+func newTestStore(t *testing.T) metadata.MetadataStore {
+	store, err := metadata.New("sqlite", t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() {
+		store.Close() //nolint:errcheck
+	})
+	return store
+}
+
+// setTestTxs indexes the transactions of a test fixture in order
+func setTestTxs(t *testing.T, store metadata.MetadataStore, txs ...*models.Transaction) {
+	for _, tx := range txs {
+		if err := store.SetTx(nil, tx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
+
+// TestAddAddressAfterRemove tests that a removed (soft-deleted) watchlist entry does not
+// collide with the unique index when the address is added again
+func TestAddAddressAfterRemove(t *testing.T) {
+	store := newTestStore(t)
+	const testAddr = "addr_test1qz"
+	entry := &models.Address{Address: testAddr, Tags: []models.AddressTag{{Tag: "cohort-1"}}}
+	if err := store.AddAddress(nil, entry); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := store.AddAddress(nil, &models.Address{Address: testAddr}); !errors.Is(err, models.ErrAddressAlreadyWatched) {
+		t.Fatalf("expected ErrAddressAlreadyWatched, got: %v", err)
+	}
+	if err := store.RemoveAddress(nil, testAddr); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	readded := &models.Address{Address: testAddr, Label: "again", Tags: []models.AddressTag{{Tag: "cohort-2"}}}
+	if err := store.AddAddress(nil, readded); err != nil {
+		t.Fatalf("unexpected error re-adding removed address: %s", err)
+	}
+	got, err := store.GetAddressEntry(nil, testAddr)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got == nil || got.Label != "again" || len(got.Tags) != 1 || got.Tags[0].Tag != "cohort-2" {
+		t.Fatalf("unexpected watchlist entry after re-add: %+v", got)
+	}
+	oldGroup, err := store.GetAddressesByTag(nil, "cohort-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(oldGroup) != 0 {
+		t.Fatalf("expected old group to be empty, got: %v", oldGroup)
+	}
+	if _, err := store.RemoveAddresses(nil, []string{testAddr}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var tagCount int64
+	if err := store.DB().Model(&models.AddressTag{}).Count(&tagCount).Error; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tagCount != 0 {
+		t.Fatalf("expected the tags of removed addresses to be deleted, found %d", tagCount)
+	}
+}
+
+func TestPolicyWatchlist(t *testing.T) {
+	db := newTestDatabase(t)
+	store := db.Metadata()
+	policyIDs := []string{
+		"c37b1b5dc0669f1d3c61a6fddb2e8fde96be87b881c60bce8e8d542f",
+		"9493315cd92eb5d8c4304e67b7e16ae36d61d34502694657811a2c8e",
+	}
+	for _, policyID := range policyIDs {
+		if err := store.AddPolicy(nil, &models.Policy{PolicyID: policyID, Label: "label-" + policyID[:4]}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if err := store.AddPolicy(nil, &models.Policy{PolicyID: policyIDs[0]}); !errors.Is(err, models.ErrPolicyAlreadyWatched) {
+		t.Fatalf("expected ErrPolicyAlreadyWatched, got: %v", err)
+	}
+	policies, err := store.GetPolicies(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(policies) != 2 || policies[0].PolicyID != policyIDs[0] || policies[0].Label != "label-c37b" || policies[1].PolicyID != policyIDs[1] {
+		t.Fatalf("unexpected policies: %+v", policies)
+	}
+
+	if err := store.RemovePolicy(nil, policyIDs[0]); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := store.RemovePolicy(nil, policyIDs[0]); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected ErrRecordNotFound, got: %v", err)
+	}
+	watched, err := db.GetWatchedPolicyIDs()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(watched) != 1 || watched[0] != policyIDs[1] {
+		t.Fatalf("unexpected watched policy IDs: %v", watched)
+	}
+	// A removed policy ID can be added again
+	if err := store.AddPolicy(nil, &models.Policy{PolicyID: policyIDs[0]}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestFingerprintWatchlist(t *testing.T) {
+	db := newTestDatabase(t)
+	store := db.Metadata()
+	fingerprints := []string{
+		"asset1rjklcrnsdzqp65wjgrg55sy9723kw09mlgvlc3",
+		"asset1c43p68zwjezc7f6w4w9qkhkwv9ppwz0f7c3amw",
+	}
+	for _, fingerprint := range fingerprints {
+		if err := store.AddFingerprint(nil, &models.Fingerprint{Fingerprint: fingerprint, Label: "label-" + fingerprint[6:10]}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if err := store.AddFingerprint(nil, &models.Fingerprint{Fingerprint: fingerprints[0]}); !errors.Is(err, models.ErrFingerprintAlreadyWatched) {
+		t.Fatalf("expected ErrFingerprintAlreadyWatched, got: %v", err)
+	}
+	entries, err := store.GetFingerprints(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(entries) != 2 || entries[0].Fingerprint != fingerprints[0] || entries[0].Label != "label-rjkl" || entries[1].Fingerprint != fingerprints[1] {
+		t.Fatalf("unexpected fingerprints: %+v", entries)
+	}
+
+	if err := store.RemoveFingerprint(nil, fingerprints[0]); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := store.RemoveFingerprint(nil, fingerprints[0]); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected ErrRecordNotFound, got: %v", err)
+	}
+	watched, err := db.GetWatchedFingerprints()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(watched) != 1 || watched[0] != fingerprints[1] {
+		t.Fatalf("unexpected watched fingerprints: %v", watched)
+	}
+	// A removed fingerprint can be added again
+	if err := store.AddFingerprint(nil, &models.Fingerprint{Fingerprint: fingerprints[0]}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestCredentialQueries(t *testing.T) {
+	store := newTestStore(t)
+	stakeCred := []byte("stake-credential-0123456789ab")
+	scriptHash := []byte("script-hash-0123456789abcdef")
+	firstHash := []byte("stake-test-tx-1")
+	secondHash := []byte("stake-test-tx-2")
+	first := &models.Transaction{
+		TransactionHash: firstHash,
+		SlotNumber:      10,
+		IsValid:         true,
+		Outputs: []models.TransactionOutput{
+			{TransactionHash: firstHash, UTxOID: firstHash, UTxOIDIndex: 0, StakeCredential: stakeCred, Amount: 1},
+			{TransactionHash: firstHash, UTxOID: firstHash, UTxOIDIndex: 1, PaymentCredential: scriptHash, StakeCredential: stakeCred, Amount: 2},
+		},
+		StakeWithdrawals: []models.Withdrawal{
+			{TransactionHash: firstHash, SlotNumber: 10, StakeAddress: "stake_test1u", StakeCredential: stakeCred, Amount: 5},
+		},
+	}
+	second := &models.Transaction{
+		TransactionHash: secondHash,
+		SlotNumber:      20,
+		IsValid:         true,
+		Inputs: []models.TransactionInput{
+			{TransactionHash: secondHash, UTxOID: firstHash, UTxOIDIndex: 0, StakeCredential: stakeCred, Amount: 1},
+		},
+	}
+	setTestTxs(t, store, first, second)
+
+	txs, err := store.GetTxsByStakeCredential(nil, stakeCred, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(txs) != 2 || string(txs[0].TransactionHash) != string(secondHash) {
+		t.Fatalf("expected both transactions newest first, got %d", len(txs))
+	}
+	utxos, err := store.GetUnspentOutputsByStakeCredential(nil, stakeCred, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// The slot of the producing transaction is loaded along with the output
+	if len(utxos) != 1 || utxos[0].UTxOIDIndex != 1 || utxos[0].SlotNumber != 10 {
+		t.Fatalf("expected only the unspent output, got: %+v", utxos)
+	}
+	withdrawals, err := store.GetWithdrawalsByStakeCredential(nil, stakeCred, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(withdrawals) != 1 || withdrawals[0].Amount != 5 {
+		t.Fatalf("unexpected withdrawals: %+v", withdrawals)
+	}
+
+	scriptTxs, err := store.GetTxsByPaymentCredential(nil, scriptHash, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(scriptTxs) != 1 || string(scriptTxs[0].TransactionHash) != string(firstHash) {
+		t.Fatalf("expected only the transaction paying to the script, got %d", len(scriptTxs))
+	}
+	scriptUtxos, err := store.GetUnspentOutputsByPaymentCredential(nil, scriptHash, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(scriptUtxos) != 1 {
+		t.Fatalf("expected one script UTxO, got: %+v", scriptUtxos)
+	}
+}
+
+func TestMintHistoryAndSupply(t *testing.T) {
+	store := newTestStore(t)
+	policyId := []byte("29d222ce763455e3d7a09a665ce554f00ac89d2e99a1a83d267170c6")
+	mintHash := []byte("mint-test-tx-1")
+	burnHash := []byte("mint-test-tx-2")
+	redeemerIndex := uint(0)
+	mintTx := &models.Transaction{
+		TransactionHash: mintHash,
+		SlotNumber:      100,
+		IsValid:         true,
+		Mints: []models.Mint{
+			{TransactionHash: mintHash, SlotNumber: 100, PolicyId: policyId, NameHex: []byte("6161"), Name: []byte("aa"), Quantity: 10, RedeemerIndex: &redeemerIndex},
+			{TransactionHash: mintHash, SlotNumber: 100, PolicyId: policyId, NameHex: []byte("6262"), Name: []byte("bb"), Quantity: 1, RedeemerIndex: &redeemerIndex},
+		},
+		Witness: models.Witness{
+			TransactionHash: mintHash,
+			Redeemers:       []models.Redeemer{{TransactionHash: mintHash, Tag: 1, Index: 0, Cbor: []byte{0x00}}},
+		},
+	}
+	burnTx := &models.Transaction{
+		TransactionHash: burnHash,
+		SlotNumber:      200,
+		IsValid:         true,
+		Mints: []models.Mint{
+			{TransactionHash: burnHash, SlotNumber: 200, PolicyId: policyId, NameHex: []byte("6161"), Name: []byte("aa"), Quantity: -4},
+		},
+	}
+	// A transaction failing phase-2 validation mints nothing
+	invalidHash := []byte("mint-test-tx-3")
+	invalidTx := &models.Transaction{
+		TransactionHash: invalidHash,
+		SlotNumber:      300,
+		IsValid:         false,
+		Mints: []models.Mint{
+			{TransactionHash: invalidHash, SlotNumber: 300, PolicyId: policyId, NameHex: []byte("6161"), Name: []byte("aa"), Quantity: 100, RedeemerIndex: &redeemerIndex},
+		},
+	}
+	setTestTxs(t, store, mintTx, burnTx, invalidTx)
+
+	mints, err := store.GetMintsByPolicyId(nil, policyId, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(mints) != 3 || mints[0].Quantity != -4 || mints[0].Redeemer != nil {
+		t.Fatalf("expected the burn first without a redeemer, got: %+v", mints)
+	}
+	if mints[1].Redeemer == nil || mints[1].Redeemer.Tag != 1 {
+		t.Fatalf("expected the mint redeemer to be attached, got: %+v", mints[1])
+	}
+
+	supplies, err := store.GetAssetSupplyByPolicyId(nil, policyId)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(supplies) != 2 {
+		t.Fatalf("expected supply for two asset names, got: %+v", supplies)
+	}
+	if supplies[0].Supply != 6 || supplies[0].Minted != 10 || supplies[0].Burned != 4 {
+		t.Fatalf("unexpected supply: %+v", supplies[0])
+	}
+}
+
+func TestPhase2InvalidUTxOSemantics(t *testing.T) {
+	store := newTestStore(t)
+	scriptHash := []byte("phase2-script-0123456789abcd")
+	fundingHash := []byte("phase2-test-tx-1")
+	failedHash := []byte("phase2-test-tx-2")
+	funding := &models.Transaction{
+		TransactionHash: fundingHash,
+		IsValid:         true,
+		Outputs: []models.TransactionOutput{
+			{UTxOID: fundingHash, UTxOIDIndex: 0, PaymentCredential: scriptHash, Amount: 10},
+			{UTxOID: fundingHash, UTxOIDIndex: 1, PaymentCredential: scriptHash, Amount: 5},
+		},
+	}
+	// The failed transaction tries to spend output 0 and pledges output 1 as collateral
+	failed := &models.Transaction{
+		TransactionHash: failedHash,
+		IsValid:         false,
+		Inputs: []models.TransactionInput{
+			{UTxOID: fundingHash, UTxOIDIndex: 0, PaymentCredential: scriptHash, Amount: 10},
+		},
+		Outputs: []models.TransactionOutput{
+			{UTxOID: failedHash, UTxOIDIndex: 0, PaymentCredential: scriptHash, Amount: 10},
+		},
+		Collateral: []models.CollateralInput{
+			{UTxOID: fundingHash, UTxOIDIndex: 1},
+		},
+		CollateralReturn: &models.TransactionOutput{UTxOID: failedHash, UTxOIDIndex: 1, PaymentCredential: scriptHash, Amount: 3},
+	}
+	setTestTxs(t, store, funding, failed)
+
+	utxos, err := store.GetUnspentOutputsByPaymentCredential(nil, scriptHash, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := map[string]bool{}
+	for _, utxo := range utxos {
+		got[fmt.Sprintf("%s#%d", utxo.UTxOID, utxo.UTxOIDIndex)] = true
+	}
+	expected := []string{"phase2-test-tx-1#0", "phase2-test-tx-2#1"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected UTxOs %v, got %v", expected, got)
+	}
+	for _, utxo := range expected {
+		if !got[utxo] {
+			t.Fatalf("expected UTxOs %v, got %v", expected, got)
+		}
+	}
+
+	tx, err := store.GetTxByTxHash(nil, failedHash)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tx.IsValid || len(tx.Outputs) != 1 || tx.CollateralReturn == nil || len(tx.Collateral) != 1 {
+		t.Fatalf("unexpected failed transaction: %+v", tx)
+	}
+}
+
+func TestAssetUTxOPhase2Semantics(t *testing.T) {
+	store := newTestStore(t)
+	fingerprint := []byte("asset1phase2utxos")
+	asset := func() []models.Asset {
+		return []models.Asset{{PolicyId: []byte("phase2-asset-policy"), Fingerprint: fingerprint, Amount: 1}}
+	}
+	fundingHash := []byte("asset-phase2-test-tx-1")
+	spendHash := []byte("asset-phase2-test-tx-2")
+	failedHash := []byte("asset-phase2-test-tx-3")
+	funding := &models.Transaction{
+		TransactionHash: fundingHash,
+		SlotNumber:      1,
+		IsValid:         true,
+		Outputs: []models.TransactionOutput{
+			{UTxOID: fundingHash, UTxOIDIndex: 0, Amount: 10, Asset: asset()},
+			{UTxOID: fundingHash, UTxOIDIndex: 1, Amount: 10, Asset: asset()},
+		},
+	}
+	spend := &models.Transaction{
+		TransactionHash: spendHash,
+		SlotNumber:      2,
+		IsValid:         true,
+		Inputs: []models.TransactionInput{
+			{UTxOID: fundingHash, UTxOIDIndex: 0, Amount: 10, Asset: asset()},
+		},
+		Outputs: []models.TransactionOutput{
+			{UTxOID: spendHash, UTxOIDIndex: 0, Amount: 10, Asset: asset()},
+		},
+	}
+	// The failed transaction tries to spend funding output 1 into an output of its own
+	failed := &models.Transaction{
+		TransactionHash: failedHash,
+		SlotNumber:      3,
+		IsValid:         false,
+		Inputs: []models.TransactionInput{
+			{UTxOID: fundingHash, UTxOIDIndex: 1, Amount: 10, Asset: asset()},
+		},
+		Outputs: []models.TransactionOutput{
+			{UTxOID: failedHash, UTxOIDIndex: 0, Amount: 10, Asset: asset()},
+		},
+	}
+	setTestTxs(t, store, funding, spend, failed)
+
+	outputs, err := store.GetTransactionOutputsByAssetFingerprint(nil, fingerprint, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got := map[string]bool{}
+	for _, output := range outputs {
+		got[fmt.Sprintf("%s#%d", output.UTxOID, output.UTxOIDIndex)] = true
+	}
+	expected := []string{"asset-phase2-test-tx-1#1", "asset-phase2-test-tx-2#0"}
+	if len(got) != len(expected) || !got[expected[0]] || !got[expected[1]] {
+		t.Fatalf("expected unspent outputs %v, got %v", expected, got)
+	}
+	utxos, err := store.GetUTxOsByAssetFingerprint(nil, fingerprint, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(utxos) != len(expected) {
+		t.Fatalf("expected %d UTxOs, got %+v", len(expected), utxos)
+	}
+	inputs, err := store.GetTransactionInputsByAssetFingerprint(nil, fingerprint, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(inputs) != 1 || !bytes.Equal(inputs[0].TransactionHash, spendHash) {
+		t.Fatalf("expected only the input of the valid transaction, got %+v", inputs)
+	}
+	txs, err := store.GetTxsByAssetFingerprint(nil, fingerprint, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(txs) != 2 || !bytes.Equal(txs[0].TransactionHash, spendHash) || !bytes.Equal(txs[1].TransactionHash, fundingHash) {
+		t.Fatalf("expected the transactions producing the asset, got %d", len(txs))
+	}
+}
+
+func TestTxOutputSlots(t *testing.T) {
+	store := newTestStore(t)
+	txHash := []byte("output-slot-test-tx")
+	tx := &models.Transaction{
+		TransactionHash:  txHash,
+		SlotNumber:       42,
+		IsValid:          true,
+		Outputs:          []models.TransactionOutput{{UTxOID: txHash, UTxOIDIndex: 0, Amount: 10}},
+		CollateralReturn: &models.TransactionOutput{UTxOID: txHash, UTxOIDIndex: 1, Amount: 3},
+	}
+	setTestTxs(t, store, tx)
+
+	got, err := store.GetTxByTxHash(nil, txHash)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got.Outputs) != 1 || got.Outputs[0].SlotNumber != 42 || got.CollateralReturn == nil || got.CollateralReturn.SlotNumber != 42 {
+		t.Fatalf("expected the outputs to carry the slot of their transaction: %+v", got)
+	}
+	txs, err := store.GetTxs(nil, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(txs) != 1 || len(txs[0].Outputs) != 1 || txs[0].Outputs[0].SlotNumber != 42 {
+		t.Fatalf("expected the outputs to carry the slot of their transaction: %+v", txs)
+	}
+}
+
+func TestSignerQueries(t *testing.T) {
+	store := newTestStore(t)
+	adminKeyHash := []byte("signer-admin-0123456789abcde")
+	ownerKeyHash := []byte("signer-owner-0123456789abcde")
+	paymentHash := []byte("signer-test-tx-1")
+	mintHash := []byte("signer-test-tx-2")
+	payment := &models.Transaction{
+		TransactionHash: paymentHash,
+		SlotNumber:      1,
+		IsValid:         true,
+		Witness: models.Witness{
+			VkeyWitnesses: []models.VkeyWitness{
+				{KeyHash: adminKeyHash, Vkey: []byte("admin-vkey"), Signature: []byte("sig-1")},
+			},
+		},
+	}
+	// The multisig mint is signed by both keys and carries its native script
+	mint := &models.Transaction{
+		TransactionHash: mintHash,
+		SlotNumber:      2,
+		IsValid:         true,
+		Witness: models.Witness{
+			VkeyWitnesses: []models.VkeyWitness{
+				{KeyHash: adminKeyHash, Vkey: []byte("admin-vkey"), Signature: []byte("sig-2")},
+				{KeyHash: ownerKeyHash, Vkey: []byte("owner-vkey"), Signature: []byte("sig-3")},
+			},
+			NativeScripts: []models.NativeScript{
+				{ScriptHash: []byte("signer-script-0123456789abcd"), Cbor: []byte{0x82, 0x01, 0x80}},
+			},
+		},
+	}
+	setTestTxs(t, store, payment, mint)
+
+	txs, err := store.GetTxsBySigner(nil, adminKeyHash, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(txs) != 2 || !bytes.Equal(txs[0].TransactionHash, mintHash) || !bytes.Equal(txs[1].TransactionHash, paymentHash) {
+		t.Fatalf("unexpected transactions for admin signer: %+v", txs)
+	}
+
+	txs, err = store.GetTxsBySigner(nil, ownerKeyHash, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(txs) != 1 || !bytes.Equal(txs[0].TransactionHash, mintHash) {
+		t.Fatalf("unexpected transactions for owner signer: %+v", txs)
+	}
+	if len(txs[0].Witness.VkeyWitnesses) != 2 || len(txs[0].Witness.NativeScripts) != 1 {
+		t.Fatalf("unexpected witness: %+v", txs[0].Witness)
+	}
+}
+
+func TestScriptRegistry(t *testing.T) {
+	store := newTestStore(t)
+	scriptHash := []byte("registry-script-0123456789ab")
+	deployHash := []byte("registry-test-tx-1")
+	spendHash := []byte("registry-test-tx-2")
+	// The deploying transaction publishes the script in a reference output
+	deploy := &models.Transaction{
+		TransactionHash: deployHash,
+		SlotNumber:      1,
+		IsValid:         true,
+		Outputs: []models.TransactionOutput{
+			{UTxOID: deployHash, UTxOIDIndex: 0, Amount: 20, ReferenceScriptHash: scriptHash},
+		},
+		Scripts: []models.Script{
+			{ScriptHash: scriptHash, Language: models.ScriptLanguagePlutusV3, Cbor: []byte{0x01}, Size: 1, FirstSeenTxHash: deployHash, FirstSeenSlot: 1},
+		},
+	}
+	// The spending transaction runs the script through the reference input and sees it again
+	spend := &models.Transaction{
+		TransactionHash: spendHash,
+		SlotNumber:      2,
+		IsValid:         true,
+		Scripts: []models.Script{
+			{ScriptHash: scriptHash, Language: models.ScriptLanguagePlutusV3, Cbor: []byte{0x01}, Size: 1, FirstSeenTxHash: spendHash, FirstSeenSlot: 2},
+		},
+		ExecutedScripts: []models.TransactionScript{
+			{ScriptHash: scriptHash},
+		},
+	}
+	setTestTxs(t, store, deploy, spend)
+
+	script, err := store.GetScriptByHash(nil, scriptHash)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if script == nil || !bytes.Equal(script.FirstSeenTxHash, deployHash) || script.Language != models.ScriptLanguagePlutusV3 {
+		t.Fatalf("unexpected script: %+v", script)
+	}
+
+	refs, err := store.GetReferenceUTxOsByScriptHash(nil, scriptHash, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(refs) != 1 || !bytes.Equal(refs[0].UTxOID, deployHash) {
+		t.Fatalf("unexpected reference UTxOs: %+v", refs)
+	}
+
+	txs, err := store.GetTxsByScriptHash(nil, scriptHash, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(txs) != 1 || !bytes.Equal(txs[0].TransactionHash, spendHash) || len(txs[0].ExecutedScripts) != 1 {
+		t.Fatalf("unexpected transactions for script: %+v", txs)
+	}
+}
+
+func TestRedeemerQueries(t *testing.T) {
+	store := newTestStore(t)
+	scriptHash := []byte("redeemer-script-0123456789ab")
+	txHash := []byte("redeemer-test-tx-1")
+	utxoIndex := uint32(3)
+	tx := &models.Transaction{
+		TransactionHash: txHash,
+		IsValid:         true,
+		Witness: models.Witness{
+			TransactionHash: txHash,
+			Redeemers: []models.Redeemer{
+				{Index: 0, Tag: 0, Purpose: models.RedeemerPurposeSpend, ScriptHash: scriptHash, UTxOID: []byte("redeemer-spent-tx"), UTxOIDIndex: &utxoIndex, ExUnitsMem: 1000, ExUnitsSteps: 2000},
+				{Index: 0, Tag: 1, Purpose: models.RedeemerPurposeMint, ScriptHash: []byte("redeemer-policy-0123456789ab")},
+			},
+		},
+	}
+	setTestTxs(t, store, tx)
+
+	redeemers, err := store.GetRedeemers(nil, scriptHash, "", 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(redeemers) != 1 || redeemers[0].Purpose != models.RedeemerPurposeSpend || redeemers[0].ExUnitsSteps != 2000 ||
+		redeemers[0].UTxOIDIndex == nil || *redeemers[0].UTxOIDIndex != utxoIndex {
+		t.Fatalf("unexpected redeemers for script: %+v", redeemers)
+	}
+
+	redeemers, err = store.GetRedeemers(nil, scriptHash, models.RedeemerPurposeMint, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(redeemers) != 0 {
+		t.Fatalf("expected no mint redeemers for the spending script, got %+v", redeemers)
+	}
+}
+
+func TestGovernanceQueries(t *testing.T) {
+	store := newTestStore(t)
+	txHash := []byte("governance-test-tx-1")
+	rewardCredential := []byte("governance-reward-0123456789")
+	voterHash := []byte("governance-drep-012345678901")
+	actionTxHash := []byte("governance-action-tx")
+	tx := &models.Transaction{
+		TransactionHash:  txHash,
+		IsValid:          true,
+		TreasuryDonation: 5,
+		Proposals: []models.GovernanceProposal{
+			{TransactionHash: txHash, ProposalIndex: 0, Deposit: 100000, RewardCredential: rewardCredential, ActionType: 6},
+		},
+		Votes: []models.GovernanceVote{
+			{TransactionHash: txHash, VoterType: 3, VoterHash: voterHash, GovActionTxHash: actionTxHash, GovActionIndex: 1, Vote: 1},
+			{TransactionHash: txHash, VoterType: 3, VoterHash: voterHash, GovActionTxHash: actionTxHash, GovActionIndex: 2, Vote: 0},
+		},
+	}
+	setTestTxs(t, store, tx)
+
+	proposals, err := store.GetGovernanceProposals(nil, rewardCredential, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(proposals) != 1 || proposals[0].Deposit != 100000 || !bytes.Equal(proposals[0].TransactionHash, txHash) {
+		t.Fatalf("unexpected proposals for credential: %+v", proposals)
+	}
+
+	votes, err := store.GetGovernanceVotes(nil, voterHash, nil, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(votes) != 2 {
+		t.Fatalf("expected 2 votes for voter, got %d", len(votes))
+	}
+
+	votes, err = store.GetGovernanceVotes(nil, nil, actionTxHash, 2, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(votes) != 1 || votes[0].Vote != 0 {
+		t.Fatalf("unexpected votes for governance action: %+v", votes)
+	}
+}
+
+func TestCertificateQueries(t *testing.T) {
+	store := newTestStore(t)
+	stakeCredential := []byte("certificate-stake-0123456789")
+	poolKeyHash := []byte("certificate-pool-01234567890")
+	txHash := []byte("certificate-test-tx-1")
+	tx := &models.Transaction{
+		TransactionHash: txHash,
+		IsValid:         true,
+		DecodedCertificates: []models.Certificate{
+			{TransactionHash: txHash, CertIndex: 0, Type: models.CertificateTypeRegistration, StakeCredential: stakeCredential, Amount: 2000000},
+			{TransactionHash: txHash, CertIndex: 1, Type: models.CertificateTypeStakeDelegation, StakeCredential: stakeCredential, PoolKeyHash: poolKeyHash},
+		},
+	}
+	setTestTxs(t, store, tx)
+
+	certificates, err := store.GetCertificates(nil, stakeCredential, nil, nil, models.CertificateDelegationTypes, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(certificates) != 1 || !bytes.Equal(certificates[0].PoolKeyHash, poolKeyHash) {
+		t.Fatalf("unexpected delegations for stake credential: %+v", certificates)
+	}
+
+	certificates, err = store.GetCertificates(nil, stakeCredential, nil, nil, nil, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(certificates) != 2 {
+		t.Fatalf("expected 2 certificates for stake credential, got %d", len(certificates))
+	}
+}
+
+func TestMetadataLabelQueries(t *testing.T) {
+	store := newTestStore(t)
+	txHash := []byte("metadata-label-test-tx-1")
+	tx := &models.Transaction{
+		TransactionHash: txHash,
+		IsValid:         true,
+		MetadataLabels: []models.MetadataLabel{
+			{TransactionHash: txHash, Label: 674, Cbor: []byte{0xa1, 0x63, 'm', 's', 'g', 0x81, 0x62, 'h', 'i'}},
+		},
+	}
+	setTestTxs(t, store, tx)
+
+	txs, err := store.GetTxsByMetadataLabel(nil, 674, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(txs) != 1 || !bytes.Equal(txs[0].TransactionHash, txHash) {
+		t.Fatalf("unexpected transactions for label 674: %+v", txs)
+	}
+	txs, err = store.GetTxsByMetadataLabel(nil, 721, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(txs) != 0 {
+		t.Fatalf("expected no transactions for label 721, got %d", len(txs))
+	}
+}
+
+func TestTokenMetadata(t *testing.T) {
+	store := newTestStore(t)
+	policyId := []byte("token-metadata-test-policy")
+	referenceNameHex := []byte("000643b04e6674")
+	userNameHex := []byte("000de1404e6674")
+	outputIndex := uint32(0)
+	setReferenceDatum := func(txHash []byte, slot uint64, metadataJson string) {
+		tx := &models.Transaction{
+			TransactionHash: txHash,
+			SlotNumber:      slot,
+			IsValid:         true,
+			Mints: []models.Mint{
+				{TransactionHash: txHash, PolicyId: policyId, NameHex: userNameHex, Fingerprint: []byte("asset1tokenmetadatatest"), Quantity: 1},
+			},
+			TokenMetadata: []models.TokenMetadata{
+				{
+					PolicyId:        policyId,
+					NameHex:         referenceNameHex,
+					Standard:        models.TokenMetadataStandardCIP68,
+					Version:         1,
+					Metadata:        metadataJson,
+					TransactionHash: txHash,
+					SlotNumber:      slot,
+					OutputIndex:     &outputIndex,
+				},
+			},
+		}
+		if err := store.SetTx(nil, tx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	setReferenceDatum([]byte("token-metadata-test-tx-1"), 100, `{"name":"Nft"}`)
+	setReferenceDatum([]byte("token-metadata-test-tx-2"), 200, `{"name":"Nft v2"}`)
+
+	gotPolicyId, gotNameHex, err := store.GetAssetByFingerprint(nil, []byte("asset1tokenmetadatatest"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(gotPolicyId, policyId) || !bytes.Equal(gotNameHex, userNameHex) {
+		t.Fatalf("unexpected asset %s.%s", gotPolicyId, gotNameHex)
+	}
+
+	tokenMetadata, err := store.GetTokenMetadata(nil, policyId, referenceNameHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tokenMetadata == nil || tokenMetadata.Metadata != `{"name":"Nft v2"}` || tokenMetadata.SlotNumber != 200 {
+		t.Fatalf("expected the latest reference datum metadata, got %+v", tokenMetadata)
+	}
+	tokenMetadata, err = store.GetTokenMetadata(nil, policyId, userNameHex)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tokenMetadata != nil {
+		t.Fatalf("expected no metadata keyed by the user token, got %+v", tokenMetadata)
+	}
+}
+
+func TestDatumDeduplication(t *testing.T) {
+	store := newTestStore(t)
+	inlineHash := bytes.Repeat([]byte{0xd1}, 32)
+	inlineCbor := []byte{0xd8, 0x79, 0x80}
+	hashOnlyHash := bytes.Repeat([]byte{0xd2}, 32)
+	hashOnlyCbor := []byte{0x01}
+	inlineDatum := func(utxoId []byte, index uint32) *models.UTxODatum {
+		return &models.UTxODatum{
+			UTxOID:      utxoId,
+			UTxOIDIndex: index,
+			DatumHash:   inlineHash,
+			Inline:      true,
+			Datum:       &models.Datum{DatumHash: inlineHash, DatumCbor: inlineCbor},
+		}
+	}
+
+	// Two outputs carry the same inline datum and a third only a datum hash
+	txHash1 := []byte("datum-test-tx-1")
+	tx1 := &models.Transaction{
+		TransactionHash: txHash1,
+		IsValid:         true,
+		Outputs: []models.TransactionOutput{
+			{UTxOID: txHash1, UTxOIDIndex: 0, Datum: inlineDatum(txHash1, 0)},
+			{UTxOID: txHash1, UTxOIDIndex: 1, Datum: inlineDatum(txHash1, 1)},
+			{UTxOID: txHash1, UTxOIDIndex: 2, Datum: &models.UTxODatum{UTxOID: txHash1, UTxOIDIndex: 2, DatumHash: hashOnlyHash}},
+		},
+		Datums: []models.Datum{
+			{DatumHash: inlineHash, DatumCbor: inlineCbor},
+			{DatumHash: inlineHash, DatumCbor: inlineCbor},
+		},
+	}
+	setTestTxs(t, store, tx1)
+	for _, index := range []uint32{0, 1} {
+		utxoDatum, err := store.GetDatum(nil, txHash1, index)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if utxoDatum == nil || !utxoDatum.Inline || utxoDatum.Datum == nil || !bytes.Equal(utxoDatum.Datum.DatumCbor, inlineCbor) {
+			t.Fatalf("unexpected datum for output %d: %+v", index, utxoDatum)
+		}
+	}
+	utxoDatum, err := store.GetDatum(nil, txHash1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if utxoDatum == nil || utxoDatum.Inline || utxoDatum.Datum != nil {
+		t.Fatalf("expected an unresolved hash-only datum, got %+v", utxoDatum)
+	}
+
+	// Spending the hash-only output supplies its datum in the witness set
+	txHash2 := []byte("datum-test-tx-2")
+	tx2 := &models.Transaction{
+		TransactionHash: txHash2,
+		IsValid:         true,
+		Inputs: []models.TransactionInput{
+			{UTxOID: txHash1, UTxOIDIndex: 2, Datum: &models.UTxODatum{UTxOID: txHash1, UTxOIDIndex: 2, DatumHash: hashOnlyHash}},
+		},
+		Datums: []models.Datum{{DatumHash: hashOnlyHash, DatumCbor: hashOnlyCbor}},
+	}
+	setTestTxs(t, store, tx2)
+	utxoDatum, err = store.GetDatum(nil, txHash1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if utxoDatum == nil || utxoDatum.Datum == nil || !bytes.Equal(utxoDatum.Datum.DatumCbor, hashOnlyCbor) {
+		t.Fatalf("expected the hash-only datum to be resolved, got %+v", utxoDatum)
+	}
+	storedTx, err := store.GetTxByTxHash(nil, txHash1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(storedTx.Outputs) != 3 || storedTx.Outputs[0].Datum == nil || !storedTx.Outputs[0].Datum.Inline || storedTx.Outputs[2].Datum.Datum == nil {
+		t.Fatalf("unexpected output datums: %+v", storedTx.Outputs)
+	}
+	datum, err := store.GetDatumByHash(nil, inlineHash)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if datum == nil || !bytes.Equal(datum.DatumCbor, inlineCbor) {
+		t.Fatalf("unexpected datum by hash: %+v", datum)
+	}
+}
+
+// TestLegacyDatumMigration tests that reopening a store links the UTxOs of datums stored
+// with the UTxO of their inline datum
+func TestLegacyDatumMigration(t *testing.T) {
+	dataDir := t.TempDir()
+	store, err := metadata.New("sqlite", dataDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	utxoID := []byte("legacy-datum-test-tx")
+	datumHash := bytes.Repeat([]byte{0xd3}, 32)
+	datumCbor := []byte{0xd8, 0x79, 0x80}
+	if err := store.DB().Exec("ALTER TABLE datum ADD COLUMN utxo_id blob").Error; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := store.DB().Exec("ALTER TABLE datum ADD COLUMN utxo_index integer").Error; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	legacyDatum := map[string]interface{}{"utxo_id": utxoID, "utxo_index": 1, "datum_hash": datumHash, "datum_cbor": datumCbor}
+	if err := store.DB().Table("datum").Create(legacyDatum).Error; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	store, err = metadata.New("sqlite", dataDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer store.Close() //nolint:errcheck
+	utxoDatum, err := store.GetDatum(nil, utxoID, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if utxoDatum == nil || !utxoDatum.Inline || !bytes.Equal(utxoDatum.DatumHash, datumHash) ||
+		utxoDatum.Datum == nil || !bytes.Equal(utxoDatum.Datum.DatumCbor, datumCbor) {
+		t.Fatalf("expected the inline datum link, got %+v", utxoDatum)
+	}
+}
+
+func TestReferenceInputQueries(t *testing.T) {
+	store := newTestStore(t)
+	stateTxHash := []byte("reference-input-test-state-tx")
+	stateDatumHash := bytes.Repeat([]byte{0xe1}, 32)
+	stateTx := &models.Transaction{
+		TransactionHash: stateTxHash,
+		IsValid:         true,
+		Outputs: []models.TransactionOutput{
+			{
+				UTxOID:      stateTxHash,
+				UTxOIDIndex: 0,
+				Address:     []byte("addr_test1referenceinputstate"),
+				Amount:      2000000,
+				Asset:       []models.Asset{{UTxOID: stateTxHash, UTxOIDIndex: 0, PolicyId: []byte("reference-input-policy"), Amount: 1}},
+				Datum:       &models.UTxODatum{UTxOID: stateTxHash, UTxOIDIndex: 0, DatumHash: stateDatumHash, Inline: true},
+			},
+		},
+		Datums: []models.Datum{{DatumHash: stateDatumHash, DatumCbor: []byte{0x02}}},
+	}
+	setTestTxs(t, store, stateTx)
+	readerTxHash := []byte("reference-input-test-reader-tx")
+	readerTx := &models.Transaction{
+		TransactionHash: readerTxHash,
+		IsValid:         true,
+		ReferenceInputs: []models.SimpleUTxO{
+			{
+				TransactionHash: readerTxHash,
+				UTxOID:          stateTxHash,
+				UTxOIDIndex:     0,
+				Resolved:        true,
+				Address:         []byte("addr_test1referenceinputstate"),
+				Amount:          2000000,
+			},
+		},
+	}
+	setTestTxs(t, store, readerTx)
+
+	txs, err := store.GetTxsReferencingUTxO(nil, stateTxHash, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(txs) != 1 || !bytes.Equal(txs[0].TransactionHash, readerTxHash) {
+		t.Fatalf("unexpected transactions referencing the UTxO: %+v", txs)
+	}
+	referenceInputs := txs[0].ReferenceInputs
+	if len(referenceInputs) != 1 || !referenceInputs[0].Resolved || len(referenceInputs[0].Asset) != 1 {
+		t.Fatalf("unexpected reference inputs: %+v", referenceInputs)
+	}
+	if referenceInputs[0].Datum == nil || referenceInputs[0].Datum.Datum == nil || !bytes.Equal(referenceInputs[0].Datum.Datum.DatumCbor, []byte{0x02}) {
+		t.Fatalf("expected the reference input datum, got %+v", referenceInputs[0].Datum)
+	}
+	txs, err = store.GetTxsReferencingUTxO(nil, stateTxHash, 1, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(txs) != 0 {
+		t.Fatalf("expected no transactions referencing output 1, got %d", len(txs))
+	}
+
+	// Reference inputs to outputs that were not indexed keep the assets and datum they were
+	// resolved with
+	unindexedTxHash := []byte("reference-input-test-unindexed-tx")
+	unindexedDatumHash := bytes.Repeat([]byte{0xe2}, 32)
+	kupoReaderTxHash := []byte("reference-input-test-kupo-reader-tx")
+	kupoReaderTx := &models.Transaction{
+		TransactionHash: kupoReaderTxHash,
+		IsValid:         true,
+		ReferenceInputs: []models.SimpleUTxO{
+			{
+				TransactionHash: kupoReaderTxHash,
+				UTxOID:          unindexedTxHash,
+				UTxOIDIndex:     0,
+				Resolved:        true,
+				Address:         []byte("addr_test1referenceinputunindexed"),
+				Amount:          3000000,
+				Asset:           []models.Asset{{UTxOID: unindexedTxHash, UTxOIDIndex: 0, PolicyId: []byte("reference-input-policy"), Amount: 2}},
+				Datum:           &models.UTxODatum{UTxOID: unindexedTxHash, UTxOIDIndex: 0, DatumHash: unindexedDatumHash, Inline: true},
+			},
+		},
+		Datums: []models.Datum{{DatumHash: unindexedDatumHash, DatumCbor: []byte{0x03}}},
+	}
+	setTestTxs(t, store, kupoReaderTx)
+	txs, err = store.GetTxsReferencingUTxO(nil, unindexedTxHash, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(txs) != 1 || len(txs[0].ReferenceInputs) != 1 {
+		t.Fatalf("unexpected transactions referencing the unindexed UTxO: %+v", txs)
+	}
+	referenceInputs = txs[0].ReferenceInputs
+	if len(referenceInputs[0].Asset) != 1 || referenceInputs[0].Asset[0].Amount != 2 {
+		t.Fatalf("expected the reference input asset, got %+v", referenceInputs[0].Asset)
+	}
+	if referenceInputs[0].Datum == nil || referenceInputs[0].Datum.Datum == nil || !bytes.Equal(referenceInputs[0].Datum.Datum.DatumCbor, []byte{0x03}) {
+		t.Fatalf("expected the reference input datum, got %+v", referenceInputs[0].Datum)
+	}
+}
+
+func TestUTxOLineage(t *testing.T) {
+	store := newTestStore(t)
+	// A produces two outputs, B spends A#0, C fails phase-2 validation and loses B#0 as
+	// collateral, and D fails with A#1 as an input, which leaves A#1 unspent
+	aTxHash := []byte("utxo-lineage-test-a-tx")
+	bTxHash := []byte("utxo-lineage-test-b-tx")
+	cTxHash := []byte("utxo-lineage-test-c-tx")
+	dTxHash := []byte("utxo-lineage-test-d-tx")
+	txs := []*models.Transaction{
+		{
+			TransactionHash: aTxHash,
+			IsValid:         true,
+			Outputs: []models.TransactionOutput{
+				{UTxOID: aTxHash, UTxOIDIndex: 0, Amount: 5000000},
+				{UTxOID: aTxHash, UTxOIDIndex: 1, Amount: 1000000},
+			},
+		},
+		{
+			TransactionHash: bTxHash,
+			IsValid:         true,
+			Inputs:          []models.TransactionInput{{TransactionHash: bTxHash, UTxOID: aTxHash, UTxOIDIndex: 0}},
+			Outputs:         []models.TransactionOutput{{UTxOID: bTxHash, UTxOIDIndex: 0, Amount: 4800000}},
+		},
+		{
+			TransactionHash:  cTxHash,
+			IsValid:          false,
+			Inputs:           []models.TransactionInput{{TransactionHash: cTxHash, UTxOID: aTxHash, UTxOIDIndex: 1}},
+			Collateral:       []models.CollateralInput{{TransactionHash: cTxHash, UTxOID: bTxHash, UTxOIDIndex: 0}},
+			CollateralReturn: &models.TransactionOutput{UTxOID: cTxHash, UTxOIDIndex: 1, Amount: 4000000},
+		},
+		{
+			TransactionHash: dTxHash,
+			IsValid:         false,
+			Inputs:          []models.TransactionInput{{TransactionHash: dTxHash, UTxOID: aTxHash, UTxOIDIndex: 1}},
+		},
+	}
+	setTestTxs(t, store, txs...)
+
+	spendingTests := []struct {
+		utxoID   []byte
+		index    uint32
+		expected []byte
+	}{
+		{aTxHash, 0, bTxHash},
+		{bTxHash, 0, cTxHash},
+		{aTxHash, 1, nil},
+		{cTxHash, 1, nil},
+	}
+	for _, test := range spendingTests {
+		spendingTxHash, err := store.GetSpendingTxHash(nil, test.utxoID, test.index)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !bytes.Equal(spendingTxHash, test.expected) {
+			t.Fatalf("%s#%d: expected spending transaction %q, got %q", test.utxoID, test.index, test.expected, spendingTxHash)
+		}
+	}
+
+	tx, err := store.GetTxWithUTxOs(nil, cTxHash)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tx == nil || tx.IsValid || len(tx.Collateral) != 1 || tx.CollateralReturn == nil || tx.CollateralReturn.UTxOIDIndex != 1 {
+		t.Fatalf("unexpected transaction: %+v", tx)
+	}
+	tx, err = store.GetTxWithUTxOs(nil, []byte("utxo-lineage-test-missing-tx"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tx != nil {
+		t.Fatalf("expected no transaction, got %+v", tx)
+	}
+}
+
+func TestValueFlowAddressSummary(t *testing.T) {
+	sender := "addr_test1valueflowsender"
+	receiver := "addr_test1valueflowreceiver"
+	payment := &database.ValueFlow{
+		IsValid:  true,
+		Complete: true,
+		Addresses: []database.AddressValueFlow{
+			{Address: sender, Consumed: 5000000, Produced: 2800000, Lovelace: -2200000},
+			{Address: receiver, Produced: 2000000, Lovelace: 2000000},
+		},
+	}
+	selfPayment := &database.ValueFlow{
+		IsValid:   true,
+		Complete:  true,
+		Addresses: []database.AddressValueFlow{{Address: sender, Consumed: 5000000, Produced: 4800000, Lovelace: -200000}},
+	}
+	scriptPayment := &database.ValueFlow{
+		IsValid:  true,
+		Complete: true,
+		Addresses: []database.AddressValueFlow{
+			{Address: sender, Consumed: 5000000, Produced: 2800000, Lovelace: -2200000},
+			{Address: "addr_test1valueflowscript", Script: true, Produced: 2000000, Lovelace: 2000000},
+		},
+	}
+	tests := []struct {
+		flow      *database.ValueFlow
+		address   string
+		direction string
+		lovelace  int64
+	}{
+		{payment, sender, database.DirectionSent, -2200000},
+		{payment, receiver, database.DirectionReceived, 2000000},
+		{payment, "addr_test1valueflowother", database.DirectionReceived, 0},
+		{selfPayment, sender, database.DirectionSelf, -200000},
+		{scriptPayment, sender, database.DirectionContract, -2200000},
+	}
+	for _, test := range tests {
+		summary := test.flow.AddressSummary(test.address)
+		if summary.Direction != test.direction || summary.Lovelace != test.lovelace {
+			t.Fatalf("%s: expected %s %d, got %s %d", test.address, test.direction, test.lovelace, summary.Direction, summary.Lovelace)
+		}
+	}
+}
+
+// TestGetUTxO tests that outputs of transactions that failed phase-2 validation are not
+// UTxOs, while their collateral returns are
+func TestGetUTxO(t *testing.T) {
+	db := newTestDatabase(t)
+	validTxHash := []byte("get-utxo-test-valid-tx")
+	invalidTxHash := []byte("get-utxo-test-invalid-tx")
+	txs := []*models.Transaction{
+		{
+			TransactionHash: validTxHash,
+			IsValid:         true,
+			Outputs:         []models.TransactionOutput{{UTxOID: validTxHash, UTxOIDIndex: 0, Address: []byte("addr_test1getutxotest"), Amount: 5000000}},
+		},
+		{
+			TransactionHash:  invalidTxHash,
+			IsValid:          false,
+			Collateral:       []models.CollateralInput{{TransactionHash: invalidTxHash, UTxOID: validTxHash, UTxOIDIndex: 0}},
+			Outputs:          []models.TransactionOutput{{UTxOID: invalidTxHash, UTxOIDIndex: 0, Address: []byte("addr_test1getutxotest"), Amount: 2000000}},
+			CollateralReturn: &models.TransactionOutput{UTxOID: invalidTxHash, UTxOIDIndex: 1, Address: []byte("addr_test1getutxotest"), Amount: 4000000},
+		},
+	}
+	setTestTxs(t, db.Metadata(), txs...)
+
+	tests := []struct {
+		utxoID  []byte
+		index   uint32
+		found   bool
+		spentBy []byte
+	}{
+		{validTxHash, 0, true, invalidTxHash},
+		{invalidTxHash, 0, false, nil},
+		{invalidTxHash, 1, true, nil},
+		{validTxHash, 1, false, nil},
+	}
+	for _, test := range tests {
+		utxo, err := db.GetUTxO(test.utxoID, test.index, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if (utxo != nil) != test.found {
+			t.Fatalf("%s#%d: expected found %t, got %+v", test.utxoID, test.index, test.found, utxo)
+		}
+		if utxo == nil {
+			continue
+		}
+		if utxo.CreatedBy == nil || !bytes.Equal(utxo.CreatedBy.TransactionHash, test.utxoID) {
+			t.Fatalf("%s#%d: unexpected creating transaction %+v", test.utxoID, test.index, utxo.CreatedBy)
+		}
+		if (utxo.SpentBy == nil) != (test.spentBy == nil) || (utxo.SpentBy != nil && !bytes.Equal(utxo.SpentBy.TransactionHash, test.spentBy)) {
+			t.Fatalf("%s#%d: expected spent by %q, got %+v", test.utxoID, test.index, test.spentBy, utxo.SpentBy)
+		}
+	}
+}
+
+// TestUTxOLineageWalk tests the spend chain walk in both directions, and that a chain
+// fanning out past MaxLineageSteps transactions is truncated
+func TestUTxOLineageWalk(t *testing.T) {
+	db := newTestDatabase(t)
+	// A produces the UTxO, B spends it into many outputs and each of those is spent by its
+	// own transaction
+	aTxHash := []byte("lineage-walk-test-a-tx")
+	bTxHash := []byte("lineage-walk-test-b-tx")
+	fanOut := database.MaxLineageSteps + 10
+	txs := []*models.Transaction{
+		{
+			TransactionHash: aTxHash,
+			IsValid:         true,
+			Outputs:         []models.TransactionOutput{{UTxOID: aTxHash, UTxOIDIndex: 0, Address: []byte("addr_test1lineagewalk"), Amount: 5000000}},
+		},
+		{
+			TransactionHash: bTxHash,
+			IsValid:         true,
+			Inputs:          []models.TransactionInput{{TransactionHash: bTxHash, UTxOID: aTxHash, UTxOIDIndex: 0}},
+		},
+	}
+	for i := 0; i < fanOut; i++ {
+		txs[1].Outputs = append(txs[1].Outputs, models.TransactionOutput{UTxOID: bTxHash, UTxOIDIndex: uint32(i), Address: []byte("addr_test1lineagewalk"), Amount: 1000000})
+		spendTxHash := []byte(fmt.Sprintf("lineage-walk-test-spend-tx-%d", i))
+		txs = append(txs, &models.Transaction{
+			TransactionHash: spendTxHash,
+			IsValid:         true,
+			Inputs:          []models.TransactionInput{{TransactionHash: spendTxHash, UTxOID: bTxHash, UTxOIDIndex: uint32(i)}},
+		})
+	}
+	setTestTxs(t, db.Metadata(), txs...)
+
+	lineage, err := db.GetUTxOLineage(aTxHash, 0, 1, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if lineage.Truncated || len(lineage.Ancestors) != 1 || !bytes.Equal(lineage.Ancestors[0].TransactionHash, aTxHash) {
+		t.Fatalf("unexpected ancestors: %+v", lineage.Ancestors)
+	}
+	if len(lineage.Descendants) != 1 || !bytes.Equal(lineage.Descendants[0].TransactionHash, bTxHash) || len(lineage.Descendants[0].Produced) != fanOut {
+		t.Fatalf("unexpected descendants: %+v", lineage.Descendants)
+	}
+
+	lineage, err = db.GetUTxOLineage(aTxHash, 0, 2, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !lineage.Truncated || len(lineage.Descendants) != database.MaxLineageSteps {
+		t.Fatalf("expected %d descendants and truncation, got %d (truncated %t)", database.MaxLineageSteps, len(lineage.Descendants), lineage.Truncated)
+	}
+	for i, step := range lineage.Descendants[1:] {
+		if step.Depth != 2 || !bytes.Equal(step.TransactionHash, []byte(fmt.Sprintf("lineage-walk-test-spend-tx-%d", i))) {
+			t.Fatalf("descendant %d: unexpected step %+v", i+1, step)
+		}
+	}
+}
+
+// TestTxValueFlow tests the lovelace and asset deltas of a transaction spending a UTxO that
+// holds an asset
+func TestTxValueFlow(t *testing.T) {
+	db := newTestDatabase(t)
+	sender := []byte("addr_test1txvalueflowsender")
+	receiver := []byte("addr_test1txvalueflowreceiver")
+	policyId := []byte("txvalueflow-policy")
+	nameHex := []byte("746f6b656e")
+	asset := func(utxoID []byte, index uint32, amount uint64) []models.Asset {
+		return []models.Asset{{UTxOID: utxoID, UTxOIDIndex: index, PolicyId: policyId, NameHex: nameHex, Fingerprint: []byte("asset1txvalueflowtest"), Amount: amount}}
+	}
+	fundTxHash := []byte("txvalueflow-test-fund-tx")
+	spendTxHash := []byte("txvalueflow-test-spend-tx")
+	txs := []*models.Transaction{
+		{
+			TransactionHash: fundTxHash,
+			SlotNumber:      100,
+			IsValid:         true,
+			Outputs:         []models.TransactionOutput{{UTxOID: fundTxHash, UTxOIDIndex: 0, Address: sender, Amount: 10000000, Asset: asset(fundTxHash, 0, 7)}},
+		},
+		{
+			TransactionHash: spendTxHash,
+			SlotNumber:      200,
+			IsValid:         true,
+			Fee:             200000,
+			Inputs:          []models.TransactionInput{{TransactionHash: spendTxHash, UTxOID: fundTxHash, UTxOIDIndex: 0, Address: sender, Amount: 10000000, Asset: asset(fundTxHash, 0, 7)}},
+			Outputs: []models.TransactionOutput{
+				{UTxOID: spendTxHash, UTxOIDIndex: 0, Address: receiver, Amount: 2000000, Asset: asset(spendTxHash, 0, 5)},
+				{UTxOID: spendTxHash, UTxOIDIndex: 1, Address: sender, Amount: 7800000, Asset: asset(spendTxHash, 1, 2)},
+			},
+		},
+	}
+	setTestTxs(t, db.Metadata(), txs...)
+
+	flow, err := db.GetTxValueFlow(spendTxHash, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if flow == nil || !flow.Complete || flow.Fee != 200000 || len(flow.Addresses) != 2 {
+		t.Fatalf("unexpected value flow: %+v", flow)
+	}
+	expected := []struct {
+		address  []byte
+		lovelace int64
+		assets   int64
+	}{
+		{sender, -2200000, -5},
+		{receiver, 2000000, 5},
+	}
+	for i, test := range expected {
+		address := flow.Addresses[i]
+		if address.Address != string(test.address) || address.Lovelace != test.lovelace {
+			t.Fatalf("address %d: expected %s %d, got %+v", i, test.address, test.lovelace, address)
+		}
+		if len(address.Assets) != 1 || address.Assets[0].Amount.Int64() != test.assets ||
+			!bytes.Equal(address.Assets[0].PolicyId, policyId) || !bytes.Equal(address.Assets[0].NameHex, nameHex) {
+			t.Fatalf("address %d: expected an asset delta of %d, got %+v", i, test.assets, address.Assets)
+		}
+	}
+}
+
+func TestAssetHistory(t *testing.T) {
+	store := newTestStore(t)
+	fingerprint := []byte("asset1historytestfingerprint")
+	asset := func(utxoID []byte) []models.Asset {
+		return []models.Asset{{UTxOID: utxoID, UTxOIDIndex: 0, PolicyId: []byte("history-policy"), NameHex: []byte("6e6674"), Fingerprint: fingerprint, Amount: 1}}
+	}
+	// A mints the asset, B transfers it, C burns it and D never touches it
+	mintTxHash := []byte("asset-history-test-mint-tx")
+	transferTxHash := []byte("asset-history-test-transfer-tx")
+	burnTxHash := []byte("asset-history-test-burn-tx")
+	otherTxHash := []byte("asset-history-test-other-tx")
+	txs := []*models.Transaction{
+		{
+			TransactionHash: mintTxHash,
+			SlotNumber:      100,
+			IsValid:         true,
+			Outputs:         []models.TransactionOutput{{UTxOID: mintTxHash, UTxOIDIndex: 0, Address: []byte("addr_test1historyminter"), Asset: asset(mintTxHash)}},
+			Mints:           []models.Mint{{TransactionHash: mintTxHash, Fingerprint: fingerprint, Quantity: 1}},
+		},
+		{
+			TransactionHash: transferTxHash,
+			SlotNumber:      200,
+			IsValid:         true,
+			Inputs:          []models.TransactionInput{{TransactionHash: transferTxHash, UTxOID: mintTxHash, UTxOIDIndex: 0, Address: []byte("addr_test1historyminter"), Asset: asset(mintTxHash)}},
+			Outputs:         []models.TransactionOutput{{UTxOID: transferTxHash, UTxOIDIndex: 0, Address: []byte("addr_test1historyholder"), Asset: asset(transferTxHash)}},
+		},
+		{
+			TransactionHash: burnTxHash,
+			SlotNumber:      300,
+			IsValid:         true,
+			Inputs:          []models.TransactionInput{{TransactionHash: burnTxHash, UTxOID: transferTxHash, UTxOIDIndex: 0, Address: []byte("addr_test1historyholder"), Asset: asset(transferTxHash)}},
+			Mints:           []models.Mint{{TransactionHash: burnTxHash, Fingerprint: fingerprint, Quantity: -1}},
+		},
+		{
+			TransactionHash: otherTxHash,
+			SlotNumber:      400,
+			IsValid:         true,
+			Outputs:         []models.TransactionOutput{{UTxOID: otherTxHash, UTxOIDIndex: 0, Address: []byte("addr_test1historyholder")}},
+		},
+	}
+	setTestTxs(t, store, txs...)
+
+	history, err := store.GetAssetHistoryTxs(nil, fingerprint, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected 3 transactions, got %d", len(history))
+	}
+	for i, txHash := range [][]byte{mintTxHash, transferTxHash, burnTxHash} {
+		if !bytes.Equal(history[i].TransactionHash, txHash) {
+			t.Fatalf("transaction %d: expected %s, got %s", i, txHash, history[i].TransactionHash)
+		}
+	}
+	if len(history[1].Inputs) != 1 || len(history[1].Inputs[0].Asset) != 1 {
+		t.Fatalf("expected the transfer input asset, got %+v", history[1].Inputs)
+	}
+	if len(history[2].Mints) != 1 || history[2].Mints[0].Quantity != -1 {
+		t.Fatalf("expected the burn, got %+v", history[2].Mints)
+	}
+	history, err = store.GetAssetHistoryTxs(nil, fingerprint, 1, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(history) != 1 || !bytes.Equal(history[0].TransactionHash, transferTxHash) {
+		t.Fatalf("unexpected paginated history: %+v", history)
+	}
+}
+
+// TestDatabaseAssetHistory tests the transfers of an asset, counting the asset held by the
+// UTxO an input spends once
+func TestDatabaseAssetHistory(t *testing.T) {
+	db := newTestDatabase(t)
+	fingerprint := []byte("asset1dbhistorytestfingerprint")
+	asset := func(utxoID []byte) []models.Asset {
+		return []models.Asset{{UTxOID: utxoID, UTxOIDIndex: 0, PolicyId: []byte("db-history-policy"), NameHex: []byte("6e6674"), Fingerprint: fingerprint, Amount: 1}}
+	}
+	minter := []byte("addr_test1dbhistoryminter")
+	holder := []byte("addr_test1dbhistoryholder")
+	mintTxHash := []byte("db-asset-history-test-mint-tx")
+	transferTxHash := []byte("db-asset-history-test-transfer-tx")
+	txs := []*models.Transaction{
+		{
+			TransactionHash: mintTxHash,
+			SlotNumber:      100,
+			IsValid:         true,
+			Outputs:         []models.TransactionOutput{{UTxOID: mintTxHash, UTxOIDIndex: 0, Address: minter, Asset: asset(mintTxHash)}},
+			Mints:           []models.Mint{{TransactionHash: mintTxHash, Fingerprint: fingerprint, Quantity: 1}},
+		},
+		{
+			TransactionHash: transferTxHash,
+			SlotNumber:      200,
+			IsValid:         true,
+			Inputs:          []models.TransactionInput{{TransactionHash: transferTxHash, UTxOID: mintTxHash, UTxOIDIndex: 0, Address: minter, Asset: asset(mintTxHash)}},
+			Outputs:         []models.TransactionOutput{{UTxOID: transferTxHash, UTxOIDIndex: 0, Address: holder, Asset: asset(transferTxHash)}},
+		},
+	}
+	setTestTxs(t, db.Metadata(), txs...)
+
+	history, err := db.GetAssetHistory(fingerprint, 10, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []database.AssetTransfer{
+		{TransactionHash: mintTxHash, To: []database.AssetHolding{{Address: string(minter), Quantity: 1}}, Quantity: 1, Minted: 1},
+		{TransactionHash: transferTxHash, From: []database.AssetHolding{{Address: string(minter), Quantity: 1}}, To: []database.AssetHolding{{Address: string(holder), Quantity: 1}}, Quantity: 1},
+	}
+	if len(history) != len(expected) {
+		t.Fatalf("expected %d transfers, got %+v", len(expected), history)
+	}
+	for i, transfer := range history {
+		if !bytes.Equal(transfer.TransactionHash, expected[i].TransactionHash) ||
+			transfer.Quantity != expected[i].Quantity ||
+			transfer.Minted != expected[i].Minted ||
+			fmt.Sprint(transfer.From) != fmt.Sprint(expected[i].From) ||
+			fmt.Sprint(transfer.To) != fmt.Sprint(expected[i].To) {
+			t.Fatalf("transfer %d: expected %+v, got %+v", i, expected[i], transfer)
+		}
+	}
+}
+
+func TestPolicyAssetsAndHolders(t *testing.T) {
+	store := newTestStore(t)
+	policyId := []byte("holders-test-policy")
+	tokenFingerprint := []byte("asset1holderstesttoken")
+	otherFingerprint := []byte("asset1holderstestother")
+	asset := func(utxoID []byte, index uint32, nameHex, fingerprint []byte, amount uint64) []models.Asset {
+		return []models.Asset{{UTxOID: utxoID, UTxOIDIndex: index, PolicyId: policyId, NameHex: nameHex, Fingerprint: fingerprint, Amount: amount}}
+	}
+	// A sends the token to X and Y and the other asset to Y, B moves X's tokens to Z and
+	// C fails phase-2 validation, so Y's tokens stay unspent
+	aTxHash := []byte("holders-test-a-tx")
+	bTxHash := []byte("holders-test-b-tx")
+	cTxHash := []byte("holders-test-c-tx")
+	txs := []*models.Transaction{
+		{
+			TransactionHash: aTxHash,
+			IsValid:         true,
+			Outputs: []models.TransactionOutput{
+				{UTxOID: aTxHash, UTxOIDIndex: 0, Address: []byte("addr_test1holdersx"), Asset: asset(aTxHash, 0, []byte("746f6b656e"), tokenFingerprint, 5)},
+				{UTxOID: aTxHash, UTxOIDIndex: 1, Address: []byte("addr_test1holdersy"), Asset: asset(aTxHash, 1, []byte("746f6b656e"), tokenFingerprint, 3)},
+				{UTxOID: aTxHash, UTxOIDIndex: 2, Address: []byte("addr_test1holdersy"), Asset: asset(aTxHash, 2, []byte("6f74686572"), otherFingerprint, 1)},
+			},
+		},
+		{
+			TransactionHash: bTxHash,
+			IsValid:         true,
+			Inputs:          []models.TransactionInput{{TransactionHash: bTxHash, UTxOID: aTxHash, UTxOIDIndex: 0}},
+			Outputs:         []models.TransactionOutput{{UTxOID: bTxHash, UTxOIDIndex: 0, Address: []byte("addr_test1holdersz"), Asset: asset(bTxHash, 0, []byte("746f6b656e"), tokenFingerprint, 5)}},
+		},
+		{
+			TransactionHash: cTxHash,
+			IsValid:         false,
+			Inputs:          []models.TransactionInput{{TransactionHash: cTxHash, UTxOID: aTxHash, UTxOIDIndex: 1}},
+		},
+	}
+	setTestTxs(t, store, txs...)
+
+	holders, err := store.GetAssetHolders(nil, tokenFingerprint, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(holders) != 2 ||
+		string(holders[0].Address) != "addr_test1holdersz" || holders[0].Quantity != 5 ||
+		string(holders[1].Address) != "addr_test1holdersy" || holders[1].Quantity != 3 {
+		t.Fatalf("unexpected holders: %+v", holders)
+	}
+
+	policyAssets, err := store.GetPolicyAssets(nil, policyId, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(policyAssets) != 2 {
+		t.Fatalf("expected 2 assets, got %d", len(policyAssets))
+	}
+	if string(policyAssets[0].NameHex) != "6f74686572" || policyAssets[0].Supply != 1 || policyAssets[0].Holders != 1 {
+		t.Fatalf("unexpected other asset: %+v", policyAssets[0])
+	}
+	if string(policyAssets[1].NameHex) != "746f6b656e" || policyAssets[1].Supply != 8 || policyAssets[1].Holders != 2 {
+		t.Fatalf("unexpected token asset: %+v", policyAssets[1])
+	}
+}
+
+func TestAddressBalanceAtSlot(t *testing.T) {
+	store := newTestStore(t)
+	address := []byte("addr_test1balancetestaddress")
+	// A pays 10 ADA and a token to the address, B spends it and returns 4 ADA of change and
+	// C fails phase-2 validation, losing the change as collateral and returning 3 ADA
+	aTxHash := []byte("balance-test-a-tx")
+	bTxHash := []byte("balance-test-b-tx")
+	cTxHash := []byte("balance-test-c-tx")
+	txs := []*models.Transaction{
+		{
+			TransactionHash: aTxHash,
+			SlotNumber:      100,
+			IsValid:         true,
+			Outputs: []models.TransactionOutput{{
+				UTxOID: aTxHash, UTxOIDIndex: 0, Address: address, Amount: 10000000,
+				Asset: []models.Asset{{UTxOID: aTxHash, UTxOIDIndex: 0, PolicyId: []byte("balance-policy"), NameHex: []byte("746f6b656e"), Fingerprint: []byte("asset1balancetest"), Amount: 7}},
+			}},
+		},
+		{
+			TransactionHash: bTxHash,
+			SlotNumber:      200,
+			IsValid:         true,
+			Inputs: []models.TransactionInput{{
+				TransactionHash: bTxHash, UTxOID: aTxHash, UTxOIDIndex: 0, Address: address, Amount: 10000000,
+				Asset: []models.Asset{{UTxOID: aTxHash, UTxOIDIndex: 0, PolicyId: []byte("balance-policy"), NameHex: []byte("746f6b656e"), Fingerprint: []byte("asset1balancetest"), Amount: 7}},
+			}},
+			Outputs: []models.TransactionOutput{
+				{UTxOID: bTxHash, UTxOIDIndex: 0, Address: address, Amount: 4000000},
+				{UTxOID: bTxHash, UTxOIDIndex: 1, Address: []byte("addr_test1balancetestother"), Amount: 5800000},
+			},
+		},
+		{
+			TransactionHash:  cTxHash,
+			SlotNumber:       300,
+			IsValid:          false,
+			Collateral:       []models.CollateralInput{{TransactionHash: cTxHash, UTxOID: bTxHash, UTxOIDIndex: 0}},
+			CollateralReturn: &models.TransactionOutput{UTxOID: cTxHash, UTxOIDIndex: 1, Address: address, Amount: 3000000},
+		},
+	}
+	setTestTxs(t, store, txs...)
+
+	balanceTests := []struct {
+		slot     uint64
+		lovelace uint64
+		assets   int
+	}{
+		{50, 0, 0},
+		{100, 10000000, 1},
+		{250, 4000000, 0},
+		{300, 3000000, 0},
+	}
+	for _, test := range balanceTests {
+		lovelace, utxoCount, err := store.GetAddressLovelaceAtSlot(nil, address, test.slot)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if lovelace != test.lovelace || (lovelace > 0) != (utxoCount == 1) {
+			t.Fatalf("slot %d: expected %d lovelace, got %d in %d UTxOs", test.slot, test.lovelace, lovelace, utxoCount)
+		}
+		assets, err := store.GetAddressAssetsAtSlot(nil, address, test.slot)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(assets) != test.assets || (test.assets > 0 && assets[0].Quantity != 7) {
+			t.Fatalf("slot %d: unexpected assets %+v", test.slot, assets)
+		}
+	}
+
+	deltas, err := store.GetAddressBalanceDeltas(nil, address)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []models.BalanceDelta{
+		{SlotNumber: 100, Received: 10000000},
+		{SlotNumber: 200, Received: 4000000, Sent: 10000000},
+		{SlotNumber: 300, Received: 3000000, Sent: 4000000},
+	}
+	if len(deltas) != len(expected) {
+		t.Fatalf("expected %d deltas, got %+v", len(expected), deltas)
+	}
+	for i := range expected {
+		if deltas[i] != expected[i] {
+			t.Fatalf("delta %d: expected %+v, got %+v", i, expected[i], deltas[i])
+		}
+	}
+}
+
+// TestDuplicateAssetMigration tests that reopening a store removes the asset rows an input
+// saved again for the UTxO it spends
+func TestDuplicateAssetMigration(t *testing.T) {
+	dataDir := t.TempDir()
+	store, err := metadata.New("sqlite", dataDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	utxoID := []byte("duplicate-asset-test-tx")
+	asset := models.Asset{UTxOID: utxoID, UTxOIDIndex: 0, PolicyId: []byte("duplicate-policy"), NameHex: []byte("746f6b656e"), Fingerprint: []byte("asset1duplicatetest"), Amount: 7}
+	tx := &models.Transaction{
+		TransactionHash: utxoID,
+		SlotNumber:      100,
+		IsValid:         true,
+		Outputs:         []models.TransactionOutput{{UTxOID: utxoID, UTxOIDIndex: 0, Address: []byte("addr_test1duplicatetest"), Amount: 2000000, Asset: []models.Asset{asset}}},
+	}
+	setTestTxs(t, store, tx)
+	if result := store.DB().Create(&asset); result.Error != nil {
+		t.Fatalf("unexpected error: %s", result.Error)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	store, err = metadata.New("sqlite", dataDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer store.Close() //nolint:errcheck
+	var count int64
+	if result := store.DB().Model(&models.Asset{}).Where("utxo_id = ?", utxoID).Count(&count); result.Error != nil {
+		t.Fatalf("unexpected error: %s", result.Error)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 asset row, got %d", count)
+	}
+}
+
+func TestAddressStats(t *testing.T) {
+	store := newTestStore(t)
+	sender := []byte("addr_test1statstestsender")
+	receiver := []byte("addr_test1statstestreceiver")
+	// A pays 10 ADA and a token to the sender, B pays 4 ADA to the receiver with 5.8 ADA of change
+	aTxHash := []byte("stats-test-a-tx")
+	bTxHash := []byte("stats-test-b-tx")
+	aTx := &models.Transaction{
+		TransactionHash: aTxHash,
+		SlotNumber:      100,
+		IsValid:         true,
+		Outputs: []models.TransactionOutput{{
+			UTxOID: aTxHash, UTxOIDIndex: 0, Address: sender, Amount: 10000000,
+			Asset: []models.Asset{{UTxOID: aTxHash, UTxOIDIndex: 0, PolicyId: []byte("stats-policy"), NameHex: []byte("746f6b656e"), Fingerprint: []byte("asset1statstest"), Amount: 1}},
+		}},
+	}
+	bTx := &models.Transaction{
+		TransactionHash: bTxHash,
+		BlockNumber:     2,
+		SlotNumber:      200,
+		Fee:             200000,
+		IsValid:         true,
+		Inputs:          []models.TransactionInput{{TransactionHash: bTxHash, UTxOID: aTxHash, UTxOIDIndex: 0, Address: sender, Amount: 10000000}},
+		Outputs: []models.TransactionOutput{
+			{UTxOID: bTxHash, UTxOIDIndex: 0, Address: receiver, Amount: 4000000},
+			{UTxOID: bTxHash, UTxOIDIndex: 1, Address: sender, Amount: 5800000},
+		},
+	}
+	// Indexing a transaction again does not count it twice
+	setTestTxs(t, store, aTx, bTx, bTx)
+
+	stats, err := store.GetAddressStats(nil, sender)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := models.AddressStats{
+		Address:        sender,
+		FirstSlot:      100,
+		FirstTxHash:    aTxHash,
+		LastSlot:       200,
+		LastTxHash:     bTxHash,
+		TxCount:        2,
+		TotalReceived:  15800000,
+		TotalSent:      10000000,
+		FeesPaid:       200000,
+		DistinctAssets: 1,
+		Counterparties: 1,
+	}
+	if stats == nil {
+		t.Fatalf("expected sender stats")
+	}
+	expected.ID = stats.ID
+	if fmt.Sprintf("%+v", *stats) != fmt.Sprintf("%+v", expected) {
+		t.Fatalf("unexpected sender stats:\n%+v\nexpected:\n%+v", *stats, expected)
+	}
+	stats, err = store.GetAddressStats(nil, receiver)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if stats == nil || stats.TxCount != 1 || stats.TotalReceived != 4000000 || stats.TotalSent != 0 || stats.FeesPaid != 0 || stats.Counterparties != 1 {
+		t.Fatalf("unexpected receiver stats: %+v", stats)
+	}
+	stats, err = store.GetAddressStats(nil, []byte("addr_test1statstestunknown"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if stats != nil {
+		t.Fatalf("expected no stats, got %+v", stats)
+	}
+
+	// Deleting B leaves the stats of A alone
+	if err := store.DeleteTxsByBlockNumber(nil, 2); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	stats, err = store.GetAddressStats(nil, sender)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected = models.AddressStats{
+		Address:        sender,
+		FirstSlot:      100,
+		FirstTxHash:    aTxHash,
+		LastSlot:       100,
+		LastTxHash:     aTxHash,
+		TxCount:        1,
+		TotalReceived:  10000000,
+		DistinctAssets: 1,
+	}
+	if stats == nil {
+		t.Fatalf("expected sender stats")
+	}
+	expected.ID = stats.ID
+	if fmt.Sprintf("%+v", *stats) != fmt.Sprintf("%+v", expected) {
+		t.Fatalf("unexpected sender stats after deleting B:\n%+v\nexpected:\n%+v", *stats, expected)
+	}
+	stats, err = store.GetAddressStats(nil, receiver)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if stats != nil {
+		t.Fatalf("expected no receiver stats after deleting B, got %+v", stats)
+	}
+	if err := store.DeleteTxByHash(nil, aTxHash); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	stats, err = store.GetAddressStats(nil, sender)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if stats != nil {
+		t.Fatalf("expected no sender stats after deleting A, got %+v", stats)
+	}
+	var addressTxs int64
+	if err := store.DB().Model(&models.AddressTransaction{}).Count(&addressTxs).Error; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if addressTxs != 0 {
+		t.Fatalf("expected no address transactions left, got %d", addressTxs)
+	}
+}
+
+// TestAddressStatsBackfill tests that reopening a store counts the transactions indexed
+// before address stats were kept, once
+func TestAddressStatsBackfill(t *testing.T) {
+	dataDir := t.TempDir()
+	store, err := metadata.New("sqlite", dataDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	sender := []byte("addr_test1statsbackfillsender")
+	receiver := []byte("addr_test1statsbackfillreceiver")
+	aTxHash := []byte("stats-backfill-test-a-tx")
+	bTxHash := []byte("stats-backfill-test-b-tx")
+	txs := []*models.Transaction{
+		{
+			TransactionHash: aTxHash,
+			SlotNumber:      100,
+			IsValid:         true,
+			Outputs:         []models.TransactionOutput{{UTxOID: aTxHash, UTxOIDIndex: 0, Address: sender, Amount: 10000000}},
+		},
+		{
+			TransactionHash: bTxHash,
+			SlotNumber:      200,
+			Fee:             200000,
+			IsValid:         true,
+			Inputs:          []models.TransactionInput{{TransactionHash: bTxHash, UTxOID: aTxHash, UTxOIDIndex: 0, Address: sender, Amount: 10000000}},
+			Outputs: []models.TransactionOutput{
+				{UTxOID: bTxHash, UTxOIDIndex: 0, Address: receiver, Amount: 4000000},
+				{UTxOID: bTxHash, UTxOIDIndex: 1, Address: sender, Amount: 5800000},
+			},
+		},
+	}
+	setTestTxs(t, store, txs...)
+	expected := make(map[string]string)
+	for _, address := range [][]byte{sender, receiver} {
+		stats, err := store.GetAddressStats(nil, address)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if stats == nil {
+			t.Fatalf("expected stats of %s", address)
+		}
+		stats.ID = 0
+		expected[string(address)] = fmt.Sprintf("%+v", *stats)
+	}
+	// Drop the stats, as in a store indexed before they were kept
+	for _, model := range []any{&models.AddressStats{}, &models.AddressTransaction{}, &models.AddressAsset{}, &models.AddressCounterparty{}} {
+		if err := store.DB().Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(model).Error; err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Reopening again does not count the transactions twice
+	for i := 0; i < 2; i++ {
+		store, err = metadata.New("sqlite", dataDir, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for _, address := range [][]byte{sender, receiver} {
+			stats, err := store.GetAddressStats(nil, address)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if stats == nil {
+				t.Fatalf("expected backfilled stats of %s", address)
+			}
+			stats.ID = 0
+			if fmt.Sprintf("%+v", *stats) != expected[string(address)] {
+				t.Fatalf("unexpected backfilled stats:\n%+v\nexpected:\n%s", *stats, expected[string(address)])
+			}
+		}
+		if err := store.Close(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// Stats kept when received and sent were net changes are counted again
+	store, err = metadata.New("sqlite", dataDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := store.DB().Exec("ALTER TABLE address_stats ADD COLUMN received integer").Error; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := store.DB().Exec("ALTER TABLE address_stats ADD COLUMN sent integer").Error; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := store.DB().Exec("UPDATE address_stats SET total_received = 1, total_sent = 1").Error; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	store, err = metadata.New("sqlite", dataDir, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer store.Close() //nolint:errcheck
+	for _, address := range [][]byte{sender, receiver} {
+		stats, err := store.GetAddressStats(nil, address)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if stats == nil {
+			t.Fatalf("expected recounted stats of %s", address)
+		}
+		stats.ID = 0
+		if fmt.Sprintf("%+v", *stats) != expected[string(address)] {
+			t.Fatalf("unexpected recounted stats:\n%+v\nexpected:\n%s", *stats, expected[string(address)])
+		}
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	store := newTestStore(t)
+	blockA := &models.Block{BlockHash: []byte("block-test-a"), BlockNumber: 10, SlotNumber: 100, Era: "Conway", TxCount: 3}
+	blockB := &models.Block{BlockHash: []byte("block-test-b"), BlockNumber: 11, SlotNumber: 120, Era: "Conway", TxCount: 1}
+	blockC := &models.Block{BlockHash: []byte("block-test-c"), BlockNumber: 12, SlotNumber: 140, BlockTime: time.Unix(1700000140, 0).UTC(), Era: "Conway", TxCount: 5}
+	// The transaction of block A is indexed after it is saved and the one of block B before
+	if err := store.SetBlock(nil, blockA); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, tx := range []*models.Transaction{
+		{TransactionHash: []byte("block-test-a-tx"), BlockHash: blockA.BlockHash, BlockNumber: 10, SlotNumber: 100, IsValid: true},
+		{TransactionHash: []byte("block-test-b-tx"), BlockHash: blockB.BlockHash, BlockNumber: 11, SlotNumber: 120, IsValid: true},
+	} {
+		if err := store.SetTx(nil, tx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	// Saving a block again keeps its relevant transaction count
+	for _, block := range []*models.Block{blockB, blockC, {BlockHash: blockA.BlockHash, BlockNumber: 10, SlotNumber: 100, Era: "Conway", TxCount: 3}} {
+		if err := store.SetBlock(nil, block); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if !store.DB().Migrator().HasColumn(&models.Block{}, "block_time") {
+		t.Fatalf("expected a block_time column")
+	}
+	block, err := store.GetBlockByNumber(nil, blockC.BlockNumber)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if block == nil || !block.BlockTime.Equal(blockC.BlockTime) {
+		t.Fatalf("expected block time %s, got %+v", blockC.BlockTime, block)
+	}
+
+	testDefs := []struct {
+		blockNumber     uint64
+		blockHash       []byte
+		relevantTxCount uint64
+	}{
+		{10, blockA.BlockHash, 1},
+		{11, blockB.BlockHash, 1},
+		{12, blockC.BlockHash, 0},
+	}
+	for _, testDef := range testDefs {
+		block, err := store.GetBlockByNumber(nil, testDef.blockNumber)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if block == nil || !bytes.Equal(block.BlockHash, testDef.blockHash) || block.RelevantTxCount != testDef.relevantTxCount {
+			t.Fatalf("unexpected block %d: %+v", testDef.blockNumber, block)
+		}
+		block, err = store.GetBlockByHash(nil, testDef.blockHash)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if block == nil || block.BlockNumber != testDef.blockNumber {
+			t.Fatalf("unexpected block %s: %+v", testDef.blockHash, block)
+		}
+	}
+	block, err = store.GetLatestBlock(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if block == nil || block.BlockNumber != 12 || block.TxCount != 5 {
+		t.Fatalf("unexpected latest block: %+v", block)
+	}
+	block, err = store.GetBlockByNumber(nil, 13)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if block != nil {
+		t.Fatalf("expected no block, got %+v", block)
+	}
+}
@@ -0,0 +1,14 @@
+package database
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+)
+
+// GetDatumByHash retrieves a datum by its hash
+func (d *Database) GetDatumByHash(datumHash []byte, txn *Txn) (*models.Datum, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	return d.metadata.GetDatumByHash(txn.Metadata(), datumHash)
+}
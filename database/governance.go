@@ -0,0 +1,23 @@
+package database
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+)
+
+// GetGovernanceProposals retrieves governance proposals, optionally only those returning their deposit to the stake credential, with pagination support
+func (d *Database) GetGovernanceProposals(rewardCredential []byte, limit, offset int, txn *Txn) ([]models.GovernanceProposal, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	return d.metadata.GetGovernanceProposals(txn.Metadata(), rewardCredential, limit, offset)
+}
+
+// GetGovernanceVotes retrieves governance votes, optionally by voter and/or governance action, with pagination support
+func (d *Database) GetGovernanceVotes(voterHash []byte, actionTxHash []byte, actionIndex uint32, limit, offset int, txn *Txn) ([]models.GovernanceVote, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	return d.metadata.GetGovernanceVotes(txn.Metadata(), voterHash, actionTxHash, actionIndex, limit, offset)
+}
@@ -0,0 +1,14 @@
+package database
+
+// GetTxsByMetadataLabel retrieves transactions carrying metadata under the given label, with pagination support
+func (d *Database) GetTxsByMetadataLabel(label uint64, limit, offset int, txn *Txn) ([]Transaction, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	modelsTxs, err := d.metadata.GetTxsByMetadataLabel(txn.Metadata(), label, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return d.loadTransactions(modelsTxs, txn), nil
+}
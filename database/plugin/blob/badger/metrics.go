@@ -15,6 +15,8 @@
 package badger
 
 import (
+	"sync"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 )
@@ -23,7 +25,15 @@ const (
 	badgerMetricNamePrefix = "database_blob_"
 )
 
+// registerBlobMetricsOnce guards the metrics registration. Badger publishes its metrics
+// through expvar, which is process wide, so one collector serves every blob store.
+var registerBlobMetricsOnce sync.Once
+
 func (d *BlobStoreBadger) registerBlobMetrics() {
+	registerBlobMetricsOnce.Do(registerExpvarCollector)
+}
+
+func registerExpvarCollector() {
 	// Badger exposes metrics via expvar, so we need to set up some translation
 	collector := collectors.NewExpvarCollector(
 		map[string]*prometheus.Desc{
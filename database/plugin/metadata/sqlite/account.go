@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"gorm.io/gorm"
+)
+
+const credentialBackfillBatchSize = 1000
+
+// backfillCredentials populates the payment and stake credential columns for
+// inputs and outputs indexed before those columns existed.
+func (d *MetadataStoreSqlite) backfillCredentials() error {
+	var inputs []models.TransactionInput
+	result := d.db.Where("payment_credential IS NULL").
+		FindInBatches(&inputs, credentialBackfillBatchSize, func(tx *gorm.DB, batch int) error {
+			for _, input := range inputs {
+				paymentCredential, stakeCredential := d.parseCredentials(input.Address)
+				if err := tx.Model(&models.TransactionInput{}).
+					Where("id = ?", input.ID).
+					Updates(map[string]interface{}{
+						"payment_credential": paymentCredential,
+						"stake_credential":   stakeCredential,
+					}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	var outputs []models.TransactionOutput
+	result = d.db.Where("payment_credential IS NULL").
+		FindInBatches(&outputs, credentialBackfillBatchSize, func(tx *gorm.DB, batch int) error {
+			for _, output := range outputs {
+				paymentCredential, stakeCredential := d.parseCredentials(output.Address)
+				if err := tx.Model(&models.TransactionOutput{}).
+					Where("id = ?", output.ID).
+					Updates(map[string]interface{}{
+						"payment_credential": paymentCredential,
+						"stake_credential":   stakeCredential,
+					}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	return result.Error
+}
+
+// parseCredentials extracts the credentials from a stored bech32 address.
+// Unparseable addresses get empty credentials so they aren't revisited.
+func (d *MetadataStoreSqlite) parseCredentials(address []byte) ([]byte, []byte) {
+	paymentCredential, stakeCredential, err := credential.FromAddressString(string(address))
+	if err != nil {
+		d.logger.Warn("failed to parse address credentials", "address", string(address), "error", err)
+		return []byte{}, []byte{}
+	}
+	return paymentCredential, stakeCredential
+}
+
+// GetTxsByStakeCredential retrieves transactions with an input or output
+// delegated to the given stake credential, with pagination support
+func (d *MetadataStoreSqlite) GetTxsByStakeCredential(txn *gorm.DB, stakeCredential []byte, limit, offset int) ([]models.Transaction, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	return getTxsByCredential(db, "stake_credential", stakeCredential, limit, offset)
+}
+
+// GetUnspentOutputsByStakeCredential retrieves the outputs delegated to the
+// given stake credential that have not been consumed by an indexed input
+func (d *MetadataStoreSqlite) GetUnspentOutputsByStakeCredential(txn *gorm.DB, stakeCredential []byte, limit, offset int) ([]models.TransactionOutput, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	return getUnspentOutputsByColumn(db, "stake_credential", stakeCredential, limit, offset)
+}
+
+// GetWithdrawalsByStakeCredential retrieves the reward withdrawals made from
+// the given stake credential, newest first, with pagination support
+func (d *MetadataStoreSqlite) GetWithdrawalsByStakeCredential(txn *gorm.DB, stakeCredential []byte, limit, offset int) ([]models.Withdrawal, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var withdrawals []models.Withdrawal
+	query := db.Where("stake_credential = ?", stakeCredential).
+		Order("slot_number DESC, id DESC")
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	if result := query.Find(&withdrawals); result.Error != nil {
+		return nil, result.Error
+	}
+	return withdrawals, nil
+}
@@ -1,24 +1,44 @@
 package sqlite
 
 import (
+	"errors"
+
 	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
 	"gorm.io/gorm"
 )
 
-// AddAddress adds a new address to the database
-func (d *MetadataStoreSqlite) AddAddress(txn *gorm.DB, address string) error {
+// AddAddress adds a new entry to the address watchlist. An entry that was removed earlier
+// is replaced, so a removed address can be added again.
+func (d *MetadataStoreSqlite) AddAddress(txn *gorm.DB, address *models.Address) error {
 	db := txn
 	if db == nil {
 		db = d.db
 	}
-	newAddress := models.Address{
-		Address: address,
+	if address == nil {
+		return errors.New("address cannot be nil")
+	}
+	if address.Address == "" {
+		return errors.New("address cannot be empty")
 	}
-	result := db.Create(&newAddress)
+
+	var existing models.Address
+	result := db.Unscoped().Where("address = ?", address.Address).Limit(1).Find(&existing)
 	if result.Error != nil {
 		return result.Error
 	}
-	return nil
+	if result.RowsAffected > 0 {
+		if !existing.DeletedAt.Valid {
+			return models.ErrAddressAlreadyWatched
+		}
+		if err := db.Where("address_id = ?", existing.ID).Delete(&models.AddressTag{}).Error; err != nil {
+			return err
+		}
+		if err := db.Unscoped().Delete(&existing).Error; err != nil {
+			return err
+		}
+	}
+
+	return db.Create(address).Error
 }
 
 // GetAddress returns the address from eventCtx.TransactionHash the database
@@ -99,15 +119,157 @@ func (d *MetadataStoreSqlite) GetTxOutputsByAddress(txn *gorm.DB, address string
 	return outputs, nil
 }
 
+// GetAddressEntry returns the watchlist entry for an address, including its tags.
+func (d *MetadataStoreSqlite) GetAddressEntry(txn *gorm.DB, address string) (*models.Address, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var entry models.Address
+	result := db.Preload("Tags").Where("address = ?", address).First(&entry)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil Address and nil error if not found
+		}
+		return nil, result.Error
+	}
+	return &entry, nil
+}
+
+// GetAddressEntries returns watchlist entries with pagination support. Empty filters are
+// ignored; label matches any entry whose label contains it.
+func (d *MetadataStoreSqlite) GetAddressEntries(txn *gorm.DB, tag, label, source string, limit, offset int) ([]models.Address, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var entries []models.Address
+	query := db.Preload("Tags").Order("id")
+	if tag != "" {
+		query = query.Where("id IN (?)", db.Model(&models.AddressTag{}).Select("address_id").Where("tag = ?", tag))
+	}
+	if label != "" {
+		query = query.Where("label LIKE ?", "%"+label+"%")
+	}
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	result := query.Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return entries, nil
+}
+
+// GetAddressStartSlots returns every watched address mapped to the slot indexing starts at.
+func (d *MetadataStoreSqlite) GetAddressStartSlots(txn *gorm.DB) (map[string]uint64, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var addresses []models.Address
+	result := db.Select("address", "start_slot").Find(&addresses)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	startSlots := make(map[string]uint64, len(addresses))
+	for _, addr := range addresses {
+		startSlots[addr.Address] = addr.StartSlot
+	}
+	return startSlots, nil
+}
+
+// GetAddressesByTag returns the watched addresses in a group.
+func (d *MetadataStoreSqlite) GetAddressesByTag(txn *gorm.DB, tag string) ([]string, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var addresses []string
+	result := db.Model(&models.Address{}).
+		Joins("JOIN address_tags ON address_tags.address_id = \"Address\".id").
+		Where("address_tags.tag = ?", tag).
+		Pluck("\"Address\".address", &addresses)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return addresses, nil
+}
+
+// GetAddressTags returns every group with the number of watched addresses in it.
+func (d *MetadataStoreSqlite) GetAddressTags(txn *gorm.DB) ([]models.AddressTagCount, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var tags []models.AddressTagCount
+	result := db.Model(&models.AddressTag{}).
+		Select("address_tags.tag AS tag, COUNT(*) AS count").
+		Joins("JOIN \"Address\" ON \"Address\".id = address_tags.address_id AND \"Address\".deleted_at IS NULL").
+		Group("address_tags.tag").
+		Order("address_tags.tag").
+		Scan(&tags)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tags, nil
+}
+
+// RemoveAddresses removes several addresses from the watchlist and returns how many were removed.
+func (d *MetadataStoreSqlite) RemoveAddresses(txn *gorm.DB, addresses []string) (int64, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	if len(addresses) == 0 {
+		return 0, nil
+	}
+	var removed int64
+	err := db.Transaction(func(db *gorm.DB) error {
+		var err error
+		removed, err = removeAddresses(db, addresses)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
 // RemoveAddress removes an address from the database
 func (d *MetadataStoreSqlite) RemoveAddress(txn *gorm.DB, address string) error {
 	db := txn
 	if db == nil {
 		db = d.db
 	}
-	result := db.Where("address = ?", address).Delete(&models.Address{})
+	return db.Transaction(func(db *gorm.DB) error {
+		_, err := removeAddresses(db, []string{address})
+		return err
+	})
+}
+
+// removeAddresses soft deletes watchlist entries with their tags and returns how many were
+// removed. The tags are deleted explicitly, as a soft delete does not cascade.
+func removeAddresses(db *gorm.DB, addresses []string) (int64, error) {
+	addressIDs := db.Model(&models.Address{}).Select("id").Where("address IN ?", addresses)
+	if err := db.Where("address_id IN (?)", addressIDs).Delete(&models.AddressTag{}).Error; err != nil {
+		return 0, err
+	}
+	result := db.Where("address IN ?", addresses).Delete(&models.Address{})
 	if result.Error != nil {
-		return result.Error
+		return 0, result.Error
 	}
-	return nil
+	return result.RowsAffected, nil
+}
+
+// removeOrphanedAddressTags deletes the tags of watchlist entries removed before their tags
+// were deleted with them
+func (d *MetadataStoreSqlite) removeOrphanedAddressTags() error {
+	removedIDs := d.db.Unscoped().Model(&models.Address{}).Select("id").Where("deleted_at IS NOT NULL")
+	return d.db.Where("address_id IN (?)", removedIDs).Delete(&models.AddressTag{}).Error
 }
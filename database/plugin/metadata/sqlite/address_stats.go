@@ -0,0 +1,333 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const addressStatsBackfillBatchSize = 1000
+
+// GetAddressStats retrieves the activity stats of an address, or nil when no indexed
+// transaction involves it
+func (d *MetadataStoreSqlite) GetAddressStats(txn *gorm.DB, address []byte) (*models.AddressStats, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var stats models.AddressStats
+	result := db.Where("address = ?", address).Limit(1).Find(&stats)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	result = db.Model(&models.AddressAsset{}).Where("address = ?", address).Count(&stats.DistinctAssets)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	result = db.Model(&models.AddressCounterparty{}).Where("address = ?", address).Count(&stats.Counterparties)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &stats, nil
+}
+
+// addressActivity is what a transaction consumed from and produced to one address
+type addressActivity struct {
+	address  []byte
+	consumed uint64
+	produced uint64
+	assets   []models.AddressAsset
+}
+
+// setAddressStats updates the stats of the addresses a transaction consumed UTxOs from or
+// produced UTxOs to. Transactions that failed phase-2 validation consume their collateral
+// and produce their collateral return. Consumed UTxOs the transaction was indexed without
+// are resolved against the indexed outputs, and skipped when not indexed.
+func (d *MetadataStoreSqlite) setAddressStats(txn *gorm.DB, tx *models.Transaction) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+
+	var consumed, produced []models.TransactionOutput
+	var err error
+	fee := tx.Fee
+	if tx.IsValid {
+		for _, input := range tx.Inputs {
+			if len(input.Address) > 0 {
+				consumed = append(consumed, models.TransactionOutput{Address: input.Address, Amount: input.Amount, Asset: input.Asset})
+				continue
+			}
+			consumed, err = appendIndexedOutput(db, consumed, input.UTxOID, input.UTxOIDIndex)
+			if err != nil {
+				return err
+			}
+		}
+		produced = tx.Outputs
+	} else {
+		for _, collateral := range tx.Collateral {
+			consumed, err = appendIndexedOutput(db, consumed, collateral.UTxOID, collateral.UTxOIDIndex)
+			if err != nil {
+				return err
+			}
+		}
+		if tx.CollateralReturn != nil {
+			produced = []models.TransactionOutput{*tx.CollateralReturn}
+		}
+		fee = tx.TotalCollateral
+	}
+
+	var activities []*addressActivity
+	activityIndex := make(map[string]*addressActivity)
+	activityOf := func(address []byte) *addressActivity {
+		activity, ok := activityIndex[string(address)]
+		if !ok {
+			activity = &addressActivity{address: address}
+			activityIndex[string(address)] = activity
+			activities = append(activities, activity)
+		}
+		return activity
+	}
+	addAssets := func(activity *addressActivity, utxo models.TransactionOutput) {
+		for _, asset := range utxo.Asset {
+			activity.assets = append(activity.assets, models.AddressAsset{Address: activity.address, PolicyId: asset.PolicyId, NameHex: asset.NameHex})
+		}
+	}
+	var consumedTotal, producedTotal uint64
+	for _, utxo := range consumed {
+		if len(utxo.Address) == 0 {
+			continue
+		}
+		activity := activityOf(utxo.Address)
+		activity.consumed += utxo.Amount
+		consumedTotal += utxo.Amount
+		addAssets(activity, utxo)
+	}
+	for _, utxo := range produced {
+		if len(utxo.Address) == 0 {
+			continue
+		}
+		activity := activityOf(utxo.Address)
+		activity.produced += utxo.Amount
+		producedTotal += utxo.Amount
+		addAssets(activity, utxo)
+	}
+	if !tx.IsValid && fee == 0 && consumedTotal > producedTotal {
+		// Transactions without a total collateral field lose their collateral less the return
+		fee = consumedTotal - producedTotal
+	}
+
+	// The funding address contributed the most lovelace to the consumed UTxOs
+	var funder *addressActivity
+	for _, activity := range activities {
+		if activity.consumed > 0 && (funder == nil || activity.consumed > funder.consumed) {
+			funder = activity
+		}
+	}
+
+	for _, activity := range activities {
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.AddressTransaction{Address: activity.address, TransactionHash: tx.TransactionHash})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Already counted
+			continue
+		}
+
+		stats := models.AddressStats{
+			Address:       activity.address,
+			FirstSlot:     tx.SlotNumber,
+			FirstTxHash:   tx.TransactionHash,
+			LastSlot:      tx.SlotNumber,
+			LastTxHash:    tx.TransactionHash,
+			TxCount:       1,
+			TotalReceived: activity.produced,
+			TotalSent:     activity.consumed,
+		}
+		if activity == funder {
+			stats.FeesPaid = fee
+		}
+		result = db.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "address"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"first_slot":     gorm.Expr("MIN(address_stats.first_slot, excluded.first_slot)"),
+				"first_tx_hash":  gorm.Expr("CASE WHEN excluded.first_slot < address_stats.first_slot THEN excluded.first_tx_hash ELSE address_stats.first_tx_hash END"),
+				"last_slot":      gorm.Expr("MAX(address_stats.last_slot, excluded.last_slot)"),
+				"last_tx_hash":   gorm.Expr("CASE WHEN excluded.last_slot >= address_stats.last_slot THEN excluded.last_tx_hash ELSE address_stats.last_tx_hash END"),
+				"tx_count":       gorm.Expr("address_stats.tx_count + 1"),
+				"total_received": gorm.Expr("address_stats.total_received + excluded.total_received"),
+				"total_sent":     gorm.Expr("address_stats.total_sent + excluded.total_sent"),
+				"fees_paid":      gorm.Expr("address_stats.fees_paid + excluded.fees_paid"),
+			}),
+		}).Create(&stats)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(activity.assets) > 0 {
+			result = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&activity.assets)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		// Addresses paying this one if it received value, and addresses it paid if it sent value
+		var counterparties []models.AddressCounterparty
+		for _, other := range activities {
+			if other == activity {
+				continue
+			}
+			if (activity.consumed > 0 && other.produced > 0) || (activity.produced > 0 && other.consumed > 0) {
+				counterparties = append(counterparties, models.AddressCounterparty{Address: activity.address, Counterparty: other.address})
+			}
+		}
+		if len(counterparties) > 0 {
+			result = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&counterparties)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+	}
+	return nil
+}
+
+// backfillAddressStats counts the transactions indexed before address stats were kept,
+// replaying them in slot order. Transactions already counted for an address are skipped
+// through AddressTransaction, and each batch is counted in a single database transaction,
+// so an interrupted backfill resumes where it stopped.
+func (d *MetadataStoreSqlite) backfillAddressStats() error {
+	var lastSlot uint64
+	var lastID uint
+	for {
+		var transactions []models.Transaction
+		result := preloadAddressStatsTxs(d.db).
+			Where("NOT EXISTS (SELECT 1 FROM address_transactions WHERE address_transactions.transaction_hash = transactions.transaction_hash)").
+			Where("slot_number > ? OR (slot_number = ? AND id > ?)", lastSlot, lastSlot, lastID).
+			Order("slot_number, id").
+			Limit(addressStatsBackfillBatchSize).
+			Find(&transactions)
+		if result.Error != nil {
+			return result.Error
+		}
+		if len(transactions) == 0 {
+			return nil
+		}
+		err := d.db.Transaction(func(txn *gorm.DB) error {
+			for i := range transactions {
+				if err := d.setAddressStats(txn, &transactions[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		lastSlot = transactions[len(transactions)-1].SlotNumber
+		lastID = transactions[len(transactions)-1].ID
+	}
+}
+
+// removeAddressStats takes deleted transactions out of the stats of the addresses they
+// involved. Assets held and counterparties cannot be subtracted, so the stats of those
+// addresses are counted again from the transactions left to them.
+func (d *MetadataStoreSqlite) removeAddressStats(db *gorm.DB, txHashes [][]byte) error {
+	if len(txHashes) == 0 {
+		return nil
+	}
+	var addresses [][]byte
+	result := db.Model(&models.AddressTransaction{}).
+		Where("transaction_hash IN ?", txHashes).
+		Distinct().
+		Pluck("address", &addresses)
+	if result.Error != nil {
+		return result.Error
+	}
+	if len(addresses) == 0 {
+		return nil
+	}
+	var remainingTxHashes [][]byte
+	result = db.Model(&models.AddressTransaction{}).
+		Where("address IN ? AND transaction_hash NOT IN ?", addresses, txHashes).
+		Distinct().
+		Pluck("transaction_hash", &remainingTxHashes)
+	if result.Error != nil {
+		return result.Error
+	}
+	for _, model := range []any{&models.AddressStats{}, &models.AddressTransaction{}, &models.AddressAsset{}, &models.AddressCounterparty{}} {
+		if err := db.Where("address IN ?", addresses).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	// The other addresses of the remaining transactions already count them and are skipped
+	for start := 0; start < len(remainingTxHashes); start += addressStatsBackfillBatchSize {
+		end := min(start+addressStatsBackfillBatchSize, len(remainingTxHashes))
+		var transactions []models.Transaction
+		result = preloadAddressStatsTxs(db).
+			Where("transaction_hash IN ?", remainingTxHashes[start:end]).
+			Order("slot_number, id").
+			Find(&transactions)
+		if result.Error != nil {
+			return result.Error
+		}
+		for i := range transactions {
+			if err := d.setAddressStats(db, &transactions[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resetNetAddressStats clears the stats kept when received and sent lovelace were summed as
+// the net change of the address per transaction, so that they are counted again
+func (d *MetadataStoreSqlite) resetNetAddressStats() error {
+	if !d.db.Migrator().HasColumn(&models.AddressStats{}, "received") {
+		return nil
+	}
+	return d.db.Transaction(func(txn *gorm.DB) error {
+		for _, model := range []any{&models.AddressTransaction{}, &models.AddressAsset{}, &models.AddressCounterparty{}} {
+			if err := txn.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		// Dropping the columns alone would recreate the table without its indexes
+		if err := txn.Migrator().DropTable(&models.AddressStats{}); err != nil {
+			return err
+		}
+		return txn.AutoMigrate(&models.AddressStats{})
+	})
+}
+
+// preloadAddressStatsTxs preloads what setAddressStats reads of the transactions queried
+func preloadAddressStatsTxs(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Inputs").
+		Preload("Inputs.Asset").
+		Preload("Outputs").
+		Preload("Outputs.Asset").
+		Preload("Collateral").
+		Preload("CollateralReturn").
+		Preload("CollateralReturn.Asset")
+}
+
+// appendIndexedOutput appends the indexed output a UTxO points to, if any
+func appendIndexedOutput(db *gorm.DB, outputs []models.TransactionOutput, utxoID []byte, utxoIndex uint32) ([]models.TransactionOutput, error) {
+	var output models.TransactionOutput
+	result := db.Where("utxo_id = ? AND utxo_index = ?", utxoID, utxoIndex).
+		Preload("Asset").
+		Limit(1).
+		Find(&output)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return outputs, nil
+	}
+	return append(outputs, output), nil
+}
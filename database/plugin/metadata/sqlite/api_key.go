@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// AddAPIKey stores a newly issued API key.
+func (d *MetadataStoreSqlite) AddAPIKey(txn *gorm.DB, apiKey *models.APIKey) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	if apiKey == nil {
+		return errors.New("api key cannot be nil")
+	}
+	if len(apiKey.KeyHash) == 0 {
+		return errors.New("api key hash cannot be empty")
+	}
+	if apiKey.Scope != models.APIKeyScopeRead && apiKey.Scope != models.APIKeyScopeAdmin {
+		return errors.New("api key scope must be read or admin")
+	}
+	return db.Create(apiKey).Error
+}
+
+// GetAPIKeyByHash retrieves an API key by the hash of the raw key.
+func (d *MetadataStoreSqlite) GetAPIKeyByHash(txn *gorm.DB, keyHash []byte) (*models.APIKey, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var apiKey models.APIKey
+	result := db.Where("key_hash = ?", keyHash).First(&apiKey)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil APIKey and nil error if not found
+		}
+		return nil, result.Error
+	}
+	return &apiKey, nil
+}
+
+// GetAllAPIKeys returns all issued API keys, including revoked ones.
+func (d *MetadataStoreSqlite) GetAllAPIKeys(txn *gorm.DB) ([]models.APIKey, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var apiKeys []models.APIKey
+	result := db.Order("id").Find(&apiKeys)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiKeys, nil
+}
+
+// RevokeAPIKey marks the API key with the given ID as revoked.
+func (d *MetadataStoreSqlite) RevokeAPIKey(txn *gorm.DB, id uint) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	result := db.Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -40,46 +40,73 @@ func (d *MetadataStoreSqlite) SetAsset(txn *gorm.DB, asset *models.Asset) error
 	return nil
 }
 
-// GetTxsByPolicyId retrieves transactions associated with a given policy ID with pagination support.
-func (d *MetadataStoreSqlite) GetTxsByPolicyId(txn *gorm.DB, policyId []byte, limit, offset int) ([]models.Transaction, error) {
-	d.logger.Debug("GetTxsByPolicyId", "policyId_hex", hex.EncodeToString(policyId))
+// setUTxOAssets saves the assets of a UTxO unless they are already saved. Assets are linked
+// to a UTxO by its ID and index, so the input spending a UTxO shares the asset rows of the
+// output, and saving them again would count every asset twice.
+func (d *MetadataStoreSqlite) setUTxOAssets(txn *gorm.DB, utxoID []byte, utxoIndex uint32, assets []models.Asset) error {
 	db := txn
 	if db == nil {
 		db = d.db
 	}
-	var transactions []models.Transaction
-	query := db.Select("transactions.*").
-		Joins("JOIN transaction_outputs ON transactions.transaction_hash = transaction_outputs.transaction_hash").
-		Joins("JOIN assets ON transaction_outputs.utxo_id = assets.utxo_id AND transaction_outputs.utxo_index = assets.utxo_index").
-		Where("assets.policy_id = ?", policyId)
-
-	if limit > 0 || offset >= 0 {
-		query = query.Limit(limit).Offset(offset)
+	if len(assets) == 0 {
+		return nil
 	}
+	var count int64
+	result := db.Model(&models.Asset{}).Where("utxo_id = ? AND utxo_index = ?", utxoID, utxoIndex).Count(&count)
+	if result.Error != nil {
+		return result.Error
+	}
+	if count > 0 {
+		return nil
+	}
+	for _, asset := range assets {
+		// The relationship between Asset and the UTxO is via UTxOID and UTxOIDIndex
+		asset.ID = 0
+		asset.UTxOID = utxoID
+		asset.UTxOIDIndex = utxoIndex
+		if err := d.SetAsset(txn, &asset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	// Log the generated SQL query
-	sqlQuery := query.ToSQL(func(tx *gorm.DB) *gorm.DB {
-		return tx.Find(&transactions)
-	})
-	d.logger.Debug(fmt.Sprintf("GetTxsByPolicyId SQL: %s", sqlQuery))
+// removeDuplicateAssets deletes the asset rows saved again with the inputs spending a UTxO,
+// keeping one row per asset of each UTxO
+func (d *MetadataStoreSqlite) removeDuplicateAssets() error {
+	return d.db.Exec("DELETE FROM assets WHERE id NOT IN " +
+		"(SELECT MIN(id) FROM assets GROUP BY utxo_id, utxo_index, policy_id, name_hex)").Error
+}
 
-	result := query.
-		Preload("Inputs").
-		Preload("Inputs.Asset").
-		Preload("Inputs.Datum").
-		Preload("Outputs").
-		Preload("Outputs.Asset").
-		Preload("Outputs.Datum").
-		Preload("ReferenceInputs").
-		Preload("Witness").
-		Preload("Witness.Redeemers").
-		Find(&transactions)
+// inputSpentCondition matches the transaction_inputs rows of transactions that passed
+// phase-2 validation. Transactions that failed it spend none of their inputs.
+const inputSpentCondition = "EXISTS (SELECT 1 FROM transactions " +
+	"WHERE transactions.transaction_hash = transaction_inputs.transaction_hash AND transactions.is_valid)"
+
+// assetOutputTxHashes selects the hashes of the transactions that produced an output
+// holding an asset that matches the condition: the outputs of valid transactions and the
+// collateral returns of invalid ones
+func assetOutputTxHashes(db *gorm.DB, condition string, args ...any) *gorm.DB {
+	return db.Model(&models.TransactionOutput{}).
+		Select("COALESCE(transaction_outputs.collateral_return_of, transaction_outputs.transaction_hash)").
+		Joins("JOIN assets ON transaction_outputs.utxo_id = assets.utxo_id AND transaction_outputs.utxo_index = assets.utxo_index").
+		Where(utxoProducedCondition).
+		Where(condition, args...)
+}
 
-	if result.Error != nil {
-		d.logger.Error("GetTxsByPolicyId: database query failed", "error", result.Error)
-		return nil, result.Error
+// GetTxsByPolicyId retrieves transactions associated with a given policy ID with pagination support.
+func (d *MetadataStoreSqlite) GetTxsByPolicyId(txn *gorm.DB, policyId []byte, limit, offset int) ([]models.Transaction, error) {
+	d.logger.Debug("GetTxsByPolicyId", "policyId_hex", hex.EncodeToString(policyId))
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	txHashes := assetOutputTxHashes(db, "assets.policy_id = ?", policyId)
+	transactions, err := findTxs(db.Where("transaction_hash IN (?)", txHashes), limit, offset)
+	if err != nil {
+		d.logger.Error("GetTxsByPolicyId: database query failed", "error", err)
+		return nil, err
 	}
-
 	return transactions, nil
 }
 
@@ -89,33 +116,8 @@ func (d *MetadataStoreSqlite) GetTxsByTokenName(txn *gorm.DB, tokenName []byte,
 	if db == nil {
 		db = d.db
 	}
-	var transactions []models.Transaction
-	query := db.Select("transactions.*").
-		Joins("JOIN transaction_outputs ON transactions.transaction_hash = transaction_outputs.transaction_hash").
-		Joins("JOIN assets ON transaction_outputs.utxo_id = assets.utxo_id AND transaction_outputs.utxo_index = assets.utxo_index").
-		Where("assets.name = ?", tokenName)
-
-	if limit > 0 || offset >= 0 {
-		query = query.Limit(limit).Offset(offset)
-	}
-
-	result := query.
-		Preload("Inputs").
-		Preload("Inputs.Asset").
-		Preload("Inputs.Datum").
-		Preload("Outputs").
-		Preload("Outputs.Asset").
-		Preload("Outputs.Datum").
-		Preload("ReferenceInputs").
-		Preload("Witness").
-		Preload("Witness.Redeemers").
-		Find(&transactions)
-
-	if result.Error != nil {
-		return nil, result.Error
-	}
-
-	return transactions, nil
+	txHashes := assetOutputTxHashes(db, "assets.name = ?", tokenName)
+	return findTxs(db.Where("transaction_hash IN (?)", txHashes), limit, offset)
 }
 
 // GetTxsByAssetFingerprint retrieves transactions associated with a given asset fingerprint with pagination support.
@@ -124,41 +126,9 @@ func (d *MetadataStoreSqlite) GetTxsByAssetFingerprint(txn *gorm.DB, assetFinger
 	if db == nil {
 		db = d.db
 	}
-
-	var transactions []models.Transaction
 	d.logger.Debug(fmt.Sprintf("[ASSET_DEBUG] GetTxsByAssetFingerprint: assetFingerprint: %x, limit: %d, offset: %d", assetFingerprint, limit, offset))
-	query := db.Select("transactions.*").
-		Joins("JOIN transaction_outputs ON transactions.transaction_hash = transaction_outputs.transaction_hash").
-		Joins("JOIN assets ON transaction_outputs.utxo_id = assets.utxo_id AND transaction_outputs.utxo_index = assets.utxo_index").
-		Where("assets.fingerprint = ?", assetFingerprint)
-
-	if limit > 0 || offset >= 0 {
-		query = query.Limit(limit).Offset(offset)
-	}
-
-	// Log the generated SQL query
-	sqlQuery := query.ToSQL(func(tx *gorm.DB) *gorm.DB {
-		return tx.Find(&transactions)
-	})
-	d.logger.Debug(fmt.Sprintf("[ASSET_DEBUG] GetTxsByAssetFingerprint SQL: %s", sqlQuery))
-
-	result := query.
-		Preload("Inputs").
-		Preload("Inputs.Asset").
-		Preload("Inputs.Datum").
-		Preload("Outputs").
-		Preload("Outputs.Asset").
-		Preload("Outputs.Datum").
-		Preload("ReferenceInputs").
-		Preload("Witness").
-		Preload("Witness.Redeemers").
-		Find(&transactions)
-
-	if result.Error != nil {
-		return nil, result.Error
-	}
-
-	return transactions, nil
+	txHashes := assetOutputTxHashes(db, "assets.fingerprint = ?", assetFingerprint)
+	return findTxs(db.Where("transaction_hash IN (?)", txHashes), limit, offset)
 }
 
 // GetTxsByPolicyIdAndTokenName retrieves transactions associated with a given policy ID and token name with pagination support.
@@ -168,37 +138,16 @@ func (d *MetadataStoreSqlite) GetTxsByPolicyIdAndTokenName(txn *gorm.DB, policyI
 	if db == nil {
 		db = d.db
 	}
-	var transactions []models.Transaction
-	query := db.Select("transactions.*").
-		Joins("JOIN transaction_outputs ON transactions.transaction_hash = transaction_outputs.transaction_hash").
-		Joins("JOIN assets ON transaction_outputs.utxo_id = assets.utxo_id AND transaction_outputs.utxo_index = assets.utxo_index").
-		Where("assets.policy_id = ? AND assets.name = ?", policyId, tokenName)
-
-	if limit > 0 || offset >= 0 {
-		query = query.Limit(limit).Offset(offset)
+	txHashes := assetOutputTxHashes(db, "assets.policy_id = ? AND assets.name = ?", policyId, tokenName)
+	transactions, err := findTxs(db.Where("transaction_hash IN (?)", txHashes), limit, offset)
+	if err != nil {
+		d.logger.Error("GetTxsByPolicyIdAndTokenName: database query failed", "error", err)
+		return nil, err
 	}
-
-	result := query.
-		Preload("Inputs").
-		Preload("Inputs.Asset").
-		Preload("Inputs.Datum").
-		Preload("Outputs").
-		Preload("Outputs.Asset").
-		Preload("Outputs.Datum").
-		Preload("ReferenceInputs").
-		Preload("Witness").
-		Preload("Witness.Redeemers").
-		Find(&transactions)
-
-	if result.Error != nil {
-		d.logger.Error("GetTxsByPolicyIdAndTokenName: database query failed", "error", result.Error)
-		return nil, result.Error
-	}
-
 	return transactions, nil
 }
 
-// GetUTxOsByAssetFingerprint retrieves SimpleUTxO view models associated with a given asset fingerprint with pagination support.
+// GetUTxOsByAssetFingerprint retrieves the unspent outputs holding a given asset fingerprint with pagination support.
 func (d *MetadataStoreSqlite) GetUTxOsByAssetFingerprint(txn *gorm.DB, assetFingerprint []byte, limit, offset int) ([]models.SimpleUTxO, error) {
 	db := txn
 	if db == nil {
@@ -209,7 +158,9 @@ func (d *MetadataStoreSqlite) GetUTxOsByAssetFingerprint(txn *gorm.DB, assetFing
 	query := db.Table("assets").
 		Select("transaction_outputs.transaction_hash, transaction_outputs.utxo_id, transaction_outputs.utxo_index, transaction_outputs.address, transaction_outputs.amount, transaction_outputs.cbor").
 		Joins("JOIN transaction_outputs ON assets.utxo_id = transaction_outputs.utxo_id AND assets.utxo_index = transaction_outputs.utxo_index").
-		Where("assets.fingerprint = ?", assetFingerprint)
+		Where("assets.fingerprint = ?", assetFingerprint).
+		Where(utxoProducedCondition).
+		Where(utxoUnspentCondition)
 
 	if limit > 0 || offset >= 0 {
 		query = query.Limit(limit).Offset(offset)
@@ -223,7 +174,8 @@ func (d *MetadataStoreSqlite) GetUTxOsByAssetFingerprint(txn *gorm.DB, assetFing
 	return utxos, nil
 }
 
-// GetTransactionInputsByAssetFingerprint retrieves TransactionInput models associated with a given asset fingerprint with pagination support.
+// GetTransactionInputsByAssetFingerprint retrieves the inputs spending a given asset fingerprint with pagination support.
+// Inputs of transactions that failed phase-2 validation are left out.
 func (d *MetadataStoreSqlite) GetTransactionInputsByAssetFingerprint(txn *gorm.DB, assetFingerprint []byte, limit, offset int) ([]models.TransactionInput, error) {
 	db := txn
 	if db == nil {
@@ -231,21 +183,23 @@ func (d *MetadataStoreSqlite) GetTransactionInputsByAssetFingerprint(txn *gorm.D
 	}
 	var inputs []models.TransactionInput
 	query := db.Table("transaction_inputs").
+		Select("transaction_inputs.*").
 		Joins("JOIN assets ON transaction_inputs.utxo_id = assets.utxo_id AND transaction_inputs.utxo_index = assets.utxo_index").
-		Where("assets.fingerprint = ?", assetFingerprint)
+		Where("assets.fingerprint = ?", assetFingerprint).
+		Where(inputSpentCondition)
 
 	if limit > 0 || offset >= 0 {
 		query = query.Limit(limit).Offset(offset)
 	}
 
-	result := query.Preload("Asset").Find(&inputs)
+	result := query.Preload("Asset").Preload("Datum.Datum").Find(&inputs)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return inputs, nil
 }
 
-// GetTransactionOutputsByAssetFingerprint retrieves TransactionOutput models associated with a given asset fingerprint with pagination support.
+// GetTransactionOutputsByAssetFingerprint retrieves the unspent outputs holding a given asset fingerprint with pagination support.
 func (d *MetadataStoreSqlite) GetTransactionOutputsByAssetFingerprint(txn *gorm.DB, assetFingerprint []byte, limit, offset int) ([]models.TransactionOutput, error) {
 	db := txn
 	if db == nil {
@@ -253,20 +207,129 @@ func (d *MetadataStoreSqlite) GetTransactionOutputsByAssetFingerprint(txn *gorm.
 	}
 	var outputs []models.TransactionOutput
 	query := db.Table("transaction_outputs").
+		Select(utxoSlotSelect).
 		Joins("JOIN assets ON transaction_outputs.utxo_id = assets.utxo_id AND transaction_outputs.utxo_index = assets.utxo_index").
-		Where("assets.fingerprint = ?", assetFingerprint)
+		Where("assets.fingerprint = ?", assetFingerprint).
+		Where(utxoProducedCondition).
+		Where(utxoUnspentCondition)
 
 	if limit > 0 || offset >= 0 {
 		query = query.Limit(limit).Offset(offset)
 	}
 
-	result := query.Preload("Asset").Find(&outputs)
+	result := query.Preload("Asset").Preload("Datum.Datum").Find(&outputs)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return outputs, nil
 }
 
+// GetAssetHistoryTxs retrieves the transactions that consumed, produced, minted or burned
+// an asset, oldest first with pagination support. Only the UTxOs, their assets and the
+// mints of each transaction are loaded.
+func (d *MetadataStoreSqlite) GetAssetHistoryTxs(txn *gorm.DB, assetFingerprint []byte, limit, offset int) ([]models.Transaction, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var transactions []models.Transaction
+	query := db.Where(
+		"transaction_hash IN ("+
+			"SELECT transaction_outputs.transaction_hash FROM transaction_outputs "+
+			"JOIN assets ON transaction_outputs.utxo_id = assets.utxo_id AND transaction_outputs.utxo_index = assets.utxo_index "+
+			"WHERE assets.fingerprint = ? "+
+			"UNION SELECT transaction_outputs.collateral_return_of FROM transaction_outputs "+
+			"JOIN assets ON transaction_outputs.utxo_id = assets.utxo_id AND transaction_outputs.utxo_index = assets.utxo_index "+
+			"WHERE assets.fingerprint = ? AND transaction_outputs.collateral_return_of IS NOT NULL "+
+			"UNION SELECT transaction_inputs.transaction_hash FROM transaction_inputs "+
+			"JOIN assets ON transaction_inputs.utxo_id = assets.utxo_id AND transaction_inputs.utxo_index = assets.utxo_index "+
+			"WHERE assets.fingerprint = ? "+
+			"UNION SELECT collateral_inputs.transaction_hash FROM collateral_inputs "+
+			"JOIN assets ON collateral_inputs.utxo_id = assets.utxo_id AND collateral_inputs.utxo_index = assets.utxo_index "+
+			"WHERE assets.fingerprint = ? "+
+			"UNION SELECT mints.transaction_hash FROM mints WHERE mints.fingerprint = ?)",
+		assetFingerprint, assetFingerprint, assetFingerprint, assetFingerprint, assetFingerprint,
+	).Order("slot_number ASC, id ASC")
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	result := query.
+		Preload("Inputs").
+		Preload("Inputs.Asset").
+		Preload("Outputs").
+		Preload("Outputs.Asset").
+		Preload("Collateral").
+		Preload("CollateralReturn").
+		Preload("CollateralReturn.Asset").
+		Preload("Mints").
+		Find(&transactions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	for i := range transactions {
+		setOutputSlots(&transactions[i])
+	}
+	return transactions, nil
+}
+
+// GetPolicyAssets retrieves the asset names under a policy held in unspent outputs, with
+// the quantity held and the number of holding addresses, with pagination support.
+func (d *MetadataStoreSqlite) GetPolicyAssets(txn *gorm.DB, policyId []byte, limit, offset int) ([]models.PolicyAsset, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var policyAssets []models.PolicyAsset
+	query := db.Table("assets").
+		Select("assets.name_hex, MIN(assets.name) AS name, MIN(assets.fingerprint) AS fingerprint, SUM(assets.amount) AS supply, COUNT(DISTINCT transaction_outputs.address) AS holders").
+		Joins("JOIN transaction_outputs ON assets.utxo_id = transaction_outputs.utxo_id AND assets.utxo_index = transaction_outputs.utxo_index").
+		Where("assets.policy_id = ?", policyId).
+		Where(utxoProducedCondition).
+		Where(utxoUnspentCondition).
+		Group("assets.name_hex").
+		Order("assets.name_hex")
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	result := query.Scan(&policyAssets)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return policyAssets, nil
+}
+
+// GetAssetHolders retrieves the addresses holding an asset in unspent outputs, largest
+// holders first, with pagination support.
+func (d *MetadataStoreSqlite) GetAssetHolders(txn *gorm.DB, assetFingerprint []byte, limit, offset int) ([]models.AssetHolder, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var holders []models.AssetHolder
+	query := db.Table("assets").
+		Select("transaction_outputs.address, SUM(assets.amount) AS quantity").
+		Joins("JOIN transaction_outputs ON assets.utxo_id = transaction_outputs.utxo_id AND assets.utxo_index = transaction_outputs.utxo_index").
+		Where("assets.fingerprint = ?", assetFingerprint).
+		Where(utxoProducedCondition).
+		Where(utxoUnspentCondition).
+		Group("transaction_outputs.address").
+		Order("quantity DESC, transaction_outputs.address")
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	result := query.Scan(&holders)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return holders, nil
+}
+
 // CountUniqueAssets counts the number of unique assets based on their fingerprint.
 func (d *MetadataStoreSqlite) CountUniqueAssets(txn *gorm.DB) (int64, error) {
 	db := txn
@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// AddAuditLog stores an audit log entry.
+func (d *MetadataStoreSqlite) AddAuditLog(txn *gorm.DB, entry *models.AuditLog) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	if entry == nil {
+		return errors.New("audit log entry cannot be nil")
+	}
+	return db.Create(entry).Error
+}
+
+// GetAuditLogs retrieves audit log entries, newest first, with pagination support.
+// When apiKeyID is non-zero only entries made with that key are returned.
+func (d *MetadataStoreSqlite) GetAuditLogs(txn *gorm.DB, apiKeyID uint, limit, offset int) ([]models.AuditLog, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var entries []models.AuditLog
+	query := db.Order("id DESC")
+	if apiKeyID != 0 {
+		query = query.Where("api_key_id = ?", apiKeyID)
+	}
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	result := query.Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return entries, nil
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// utxoProducedBySlotCondition matches transaction_outputs rows that exist as UTxOs at a
+// slot, like utxoProducedCondition, taking the slot as its argument
+const utxoProducedBySlotCondition = "EXISTS (SELECT 1 FROM transactions WHERE transactions.slot_number <= ? AND (" +
+	"(transactions.transaction_hash = transaction_outputs.transaction_hash AND transactions.is_valid) OR " +
+	"(transactions.transaction_hash = transaction_outputs.collateral_return_of AND NOT transactions.is_valid)))"
+
+// utxoUnspentAtSlotCondition matches transaction_outputs rows not consumed by an indexed
+// transaction at a slot, like utxoUnspentCondition, taking the slot as both its arguments
+const utxoUnspentAtSlotCondition = "NOT EXISTS (SELECT 1 FROM transaction_inputs JOIN transactions ON transactions.transaction_hash = transaction_inputs.transaction_hash " +
+	"WHERE transaction_inputs.utxo_id = transaction_outputs.utxo_id AND transaction_inputs.utxo_index = transaction_outputs.utxo_index AND transactions.is_valid AND transactions.slot_number <= ?) " +
+	"AND NOT EXISTS (SELECT 1 FROM collateral_inputs JOIN transactions ON transactions.transaction_hash = collateral_inputs.transaction_hash " +
+	"WHERE collateral_inputs.utxo_id = transaction_outputs.utxo_id AND collateral_inputs.utxo_index = transaction_outputs.utxo_index AND NOT transactions.is_valid AND transactions.slot_number <= ?)"
+
+// GetAddressLovelaceAtSlot retrieves the lovelace an address held in UTxOs at the end of a
+// slot, with the number of UTxOs holding it
+func (d *MetadataStoreSqlite) GetAddressLovelaceAtSlot(txn *gorm.DB, address []byte, slot uint64) (uint64, int64, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var balance struct {
+		Lovelace  uint64
+		UTxOCount int64 `gorm:"column:utxo_count"`
+	}
+	result := db.Model(&models.TransactionOutput{}).
+		Select("COALESCE(SUM(transaction_outputs.amount), 0) AS lovelace, COUNT(*) AS utxo_count").
+		Where("transaction_outputs.address = ?", address).
+		Where(utxoProducedBySlotCondition, slot).
+		Where(utxoUnspentAtSlotCondition, slot, slot).
+		Scan(&balance)
+	if result.Error != nil {
+		return 0, 0, result.Error
+	}
+	return balance.Lovelace, balance.UTxOCount, nil
+}
+
+// GetAddressAssetsAtSlot retrieves the native assets an address held in UTxOs at the end
+// of a slot
+func (d *MetadataStoreSqlite) GetAddressAssetsAtSlot(txn *gorm.DB, address []byte, slot uint64) ([]models.AssetBalance, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var balances []models.AssetBalance
+	result := db.Table("assets").
+		Select("assets.policy_id, assets.name_hex, MIN(assets.name) AS name, MIN(assets.fingerprint) AS fingerprint, SUM(assets.amount) AS quantity").
+		Joins("JOIN transaction_outputs ON assets.utxo_id = transaction_outputs.utxo_id AND assets.utxo_index = transaction_outputs.utxo_index").
+		Where("transaction_outputs.address = ?", address).
+		Where(utxoProducedBySlotCondition, slot).
+		Where(utxoUnspentAtSlotCondition, slot, slot).
+		Group("assets.policy_id, assets.name_hex").
+		Order("assets.policy_id, assets.name_hex").
+		Scan(&balances)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return balances, nil
+}
+
+// GetAddressBalanceDeltas retrieves the lovelace an address received and sent, summed per
+// slot in slot order. An output counts as received at the slot of the transaction producing
+// it and as sent at the slot of the transaction consuming it.
+func (d *MetadataStoreSqlite) GetAddressBalanceDeltas(txn *gorm.DB, address []byte) ([]models.BalanceDelta, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var deltas []models.BalanceDelta
+	result := db.Raw(
+		"SELECT slot_number, SUM(received) AS received, SUM(sent) AS sent FROM ("+
+			"SELECT transactions.slot_number, transaction_outputs.amount AS received, 0 AS sent FROM transaction_outputs "+
+			"JOIN transactions ON (transactions.transaction_hash = transaction_outputs.transaction_hash AND transactions.is_valid) OR "+
+			"(transactions.transaction_hash = transaction_outputs.collateral_return_of AND NOT transactions.is_valid) "+
+			"WHERE transaction_outputs.address = ? "+
+			"UNION ALL SELECT transactions.slot_number, 0, transaction_outputs.amount FROM transaction_outputs "+
+			"JOIN transaction_inputs ON transaction_inputs.utxo_id = transaction_outputs.utxo_id AND transaction_inputs.utxo_index = transaction_outputs.utxo_index "+
+			"JOIN transactions ON transactions.transaction_hash = transaction_inputs.transaction_hash AND transactions.is_valid "+
+			"WHERE transaction_outputs.address = ? AND "+utxoProducedCondition+" "+
+			"UNION ALL SELECT transactions.slot_number, 0, transaction_outputs.amount FROM transaction_outputs "+
+			"JOIN collateral_inputs ON collateral_inputs.utxo_id = transaction_outputs.utxo_id AND collateral_inputs.utxo_index = transaction_outputs.utxo_index "+
+			"JOIN transactions ON transactions.transaction_hash = collateral_inputs.transaction_hash AND NOT transactions.is_valid "+
+			"WHERE transaction_outputs.address = ? AND "+utxoProducedCondition+
+			") AS movements GROUP BY slot_number ORDER BY slot_number",
+		address, address, address,
+	).Scan(&deltas)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return deltas, nil
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// blockRelevantTxCount counts the indexed transactions of the block a row of blocks is for
+const blockRelevantTxCount = "(SELECT COUNT(*) FROM transactions WHERE transactions.block_hash = blocks.block_hash)"
+
+// SetBlock saves a block, replacing the row of a block seen again with the same hash
+func (d *MetadataStoreSqlite) SetBlock(txn *gorm.DB, block *models.Block) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "block_hash"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block_number", "slot_number", "block_time", "era", "size", "issuer", "tx_count"}),
+	}).Create(block)
+	if result.Error != nil {
+		return result.Error
+	}
+	// Transactions of the block may have been indexed before it was saved
+	return countBlockRelevantTxs(db, block.BlockHash)
+}
+
+// countBlockRelevantTxs updates the number of indexed transactions of a block, if saved
+func countBlockRelevantTxs(db *gorm.DB, blockHash []byte) error {
+	result := db.Model(&models.Block{}).
+		Where("block_hash = ?", blockHash).
+		Update("relevant_tx_count", gorm.Expr(blockRelevantTxCount))
+	return result.Error
+}
+
+// GetBlockByHash retrieves a block by its hash, or nil when it was not seen
+func (d *MetadataStoreSqlite) GetBlockByHash(txn *gorm.DB, blockHash []byte) (*models.Block, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	return findBlock(db.Where("block_hash = ?", blockHash))
+}
+
+// GetBlockByNumber retrieves a block by its number, or nil when it was not seen. The
+// block saved last wins when a rollback replaced a block with another of the same number.
+func (d *MetadataStoreSqlite) GetBlockByNumber(txn *gorm.DB, blockNumber uint64) (*models.Block, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	return findBlock(db.Where("block_number = ?", blockNumber).Order("id DESC"))
+}
+
+// GetLatestBlock retrieves the block with the highest slot, or nil when no block was seen
+func (d *MetadataStoreSqlite) GetLatestBlock(txn *gorm.DB) (*models.Block, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	return findBlock(db.Order("slot_number DESC, id DESC"))
+}
+
+// findBlock retrieves the first block a query matches, or nil when it matches none
+func findBlock(query *gorm.DB) (*models.Block, error) {
+	var block models.Block
+	result := query.Limit(1).Find(&block)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &block, nil
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// GetCertificates retrieves decoded certificates, newest first, with pagination
+// support. Each non-empty filter narrows the result: the stake credential, the pool
+// or the DRep the certificate acts on, and the certificate types.
+func (d *MetadataStoreSqlite) GetCertificates(txn *gorm.DB, stakeCredential []byte, poolKeyHash []byte, drepCredential []byte, certTypes []string, limit, offset int) ([]models.Certificate, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var certificates []models.Certificate
+	query := db.Order("slot_number DESC, id DESC")
+	if len(stakeCredential) > 0 {
+		query = query.Where("stake_credential = ?", stakeCredential)
+	}
+	if len(poolKeyHash) > 0 {
+		query = query.Where("pool_key_hash = ?", poolKeyHash)
+	}
+	if len(drepCredential) > 0 {
+		query = query.Where("drep_credential = ?", drepCredential)
+	}
+	if len(certTypes) > 0 {
+		query = query.Where("type IN ?", certTypes)
+	}
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	result := query.Find(&certificates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return certificates, nil
+}
@@ -0,0 +1,156 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// GetTxsByPaymentCredential retrieves transactions with an input or output
+// locked by the given payment key hash or script hash, whatever the stake
+// part of the address, with pagination support
+func (d *MetadataStoreSqlite) GetTxsByPaymentCredential(txn *gorm.DB, paymentCredential []byte, limit, offset int) ([]models.Transaction, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	return getTxsByCredential(db, "payment_credential", paymentCredential, limit, offset)
+}
+
+// GetUnspentOutputsByPaymentCredential retrieves the outputs locked by the
+// given payment credential that have not been consumed by an indexed input
+func (d *MetadataStoreSqlite) GetUnspentOutputsByPaymentCredential(txn *gorm.DB, paymentCredential []byte, limit, offset int) ([]models.TransactionOutput, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	return getUnspentOutputsByColumn(db, "payment_credential", paymentCredential, limit, offset)
+}
+
+// utxoProducedCondition matches transaction_outputs rows that exist as UTxOs: the outputs
+// of transactions that passed phase-2 validation and the collateral returns of those
+// that failed it.
+const utxoProducedCondition = "EXISTS (SELECT 1 FROM transactions WHERE " +
+	"(transactions.transaction_hash = transaction_outputs.transaction_hash AND transactions.is_valid) OR " +
+	"(transactions.transaction_hash = transaction_outputs.collateral_return_of AND NOT transactions.is_valid))"
+
+// utxoUnspentCondition matches transaction_outputs rows not consumed by an indexed
+// transaction, either as an input of a valid transaction or as collateral of an invalid one.
+const utxoUnspentCondition = "NOT EXISTS (SELECT 1 FROM transaction_inputs JOIN transactions ON transactions.transaction_hash = transaction_inputs.transaction_hash " +
+	"WHERE transaction_inputs.utxo_id = transaction_outputs.utxo_id AND transaction_inputs.utxo_index = transaction_outputs.utxo_index AND transactions.is_valid) " +
+	"AND NOT EXISTS (SELECT 1 FROM collateral_inputs JOIN transactions ON transactions.transaction_hash = collateral_inputs.transaction_hash " +
+	"WHERE collateral_inputs.utxo_id = transaction_outputs.utxo_id AND collateral_inputs.utxo_index = transaction_outputs.utxo_index AND NOT transactions.is_valid)"
+
+// utxoSlotSelect selects transaction_outputs rows with the slot of the transaction that
+// produced them
+const utxoSlotSelect = "transaction_outputs.*, (SELECT transactions.slot_number FROM transactions " +
+	"WHERE transactions.transaction_hash IN (transaction_outputs.transaction_hash, transaction_outputs.collateral_return_of)) AS slot_number"
+
+// getTxsByCredential retrieves transactions with an input or output whose
+// credential column matches, newest first
+func getTxsByCredential(db *gorm.DB, column string, cred []byte, limit, offset int) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+	if len(cred) == 0 {
+		return transactions, nil
+	}
+
+	inputTxHashes := db.Model(&models.TransactionInput{}).
+		Select("transaction_hash").
+		Where(column+" = ?", cred)
+	outputTxHashes := db.Model(&models.TransactionOutput{}).
+		Select("transaction_hash").
+		Where(column+" = ?", cred)
+
+	query := db.Where("transaction_hash IN (?) OR transaction_hash IN (?)", inputTxHashes, outputTxHashes)
+	return findTxs(query, limit, offset)
+}
+
+// findTxs runs a transaction query newest first with pagination and loads
+// the nested data of each transaction
+func findTxs(query *gorm.DB, limit, offset int) ([]models.Transaction, error) {
+	return findTxsInOrder(query, "slot_number DESC, id DESC", limit, offset)
+}
+
+// findTxsInOrder runs a transaction query in the given order with pagination
+// and loads the nested data of each transaction
+func findTxsInOrder(query *gorm.DB, order string, limit, offset int) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+	query = query.Order(order)
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	result := preloadTxs(query).Find(&transactions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	for i := range transactions {
+		setOutputSlots(&transactions[i])
+	}
+	return transactions, nil
+}
+
+// setOutputSlots sets the slot of the outputs loaded with a transaction, which is not
+// stored with the outputs, to the slot of the transaction that produced them
+func setOutputSlots(transaction *models.Transaction) {
+	for i := range transaction.Outputs {
+		transaction.Outputs[i].SlotNumber = transaction.SlotNumber
+	}
+	if transaction.CollateralReturn != nil {
+		transaction.CollateralReturn.SlotNumber = transaction.SlotNumber
+	}
+}
+
+// preloadTxs loads the nested data of the transactions a query retrieves
+func preloadTxs(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Inputs").
+		Preload("Inputs.Asset").
+		Preload("Inputs.Datum.Datum").
+		Preload("Outputs").
+		Preload("Outputs.Asset").
+		Preload("Outputs.Datum.Datum").
+		Preload("ReferenceInputs").
+		Preload("ReferenceInputs.Asset").
+		Preload("ReferenceInputs.Datum.Datum").
+		Preload("Collateral").
+		Preload("CollateralReturn").
+		Preload("CollateralReturn.Asset").
+		Preload("CollateralReturn.Datum.Datum").
+		Preload("Witness").
+		Preload("Witness.Redeemers").
+		Preload("Witness.VkeyWitnesses").
+		Preload("Witness.NativeScripts").
+		Preload("ExecutedScripts").
+		Preload("Proposals").
+		Preload("Votes").
+		Preload("DecodedCertificates")
+}
+
+// getUnspentOutputsByColumn retrieves the UTxOs whose column (a credential or
+// script hash) matches and that no indexed transaction consumes
+func getUnspentOutputsByColumn(db *gorm.DB, column string, value []byte, limit, offset int) ([]models.TransactionOutput, error) {
+	var outputs []models.TransactionOutput
+	if len(value) == 0 {
+		return outputs, nil
+	}
+
+	query := db.Select(utxoSlotSelect).
+		Where("transaction_outputs."+column+" = ?", value).
+		Where(utxoProducedCondition).
+		Where(utxoUnspentCondition).
+		Order("transaction_outputs.id DESC")
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	result := query.
+		Preload("Asset").
+		Preload("Datum.Datum").
+		Find(&outputs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return outputs, nil
+}
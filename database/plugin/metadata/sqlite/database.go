@@ -120,6 +120,39 @@ func New(
 			return db, err
 		}
 	}
+	if err := db.backfillCredentials(); err != nil {
+		return db, err
+	}
+	// Removed watchlist entries used to keep their tags
+	if err := db.removeOrphanedAddressTags(); err != nil {
+		return db, err
+	}
+	// Inputs used to save the assets of the output they spend a second time
+	if err := db.removeDuplicateAssets(); err != nil {
+		return db, err
+	}
+	// Datums indexed before UTxOs linked to them through utxo_datums
+	if err := db.backfillUTxODatums(); err != nil {
+		return db, err
+	}
+	// Transactions indexed before phase-2 validity was recorded all passed validation
+	if err := db.db.Model(&models.Transaction{}).Where("is_valid IS NULL").Update("is_valid", true).Error; err != nil {
+		return db, err
+	}
+	// Redeemers indexed before purposes were recorded get the purpose of their tag
+	for tag := uint8(0); models.RedeemerPurpose(tag) != ""; tag++ {
+		if err := db.db.Model(&models.Redeemer{}).Where("purpose IS NULL AND tag = ?", tag).Update("purpose", models.RedeemerPurpose(tag)).Error; err != nil {
+			return db, err
+		}
+	}
+	// Address stats used to sum the net lovelace change per transaction
+	if err := db.resetNetAddressStats(); err != nil {
+		return db, err
+	}
+	// Transactions indexed before address stats were kept
+	if err := db.backfillAddressStats(); err != nil {
+		return db, err
+	}
 	return db, nil
 }
 
@@ -5,23 +5,25 @@ import (
 
 	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models" // Corrected import path based on go.mod
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// GetDatum retrieves a Datum by UTxOID and UTxOIDIndex.
-func (d *MetadataStoreSqlite) GetDatum(txn *gorm.DB, utxoID []byte, utxoIndex uint32) (*models.Datum, error) {
+// GetDatum retrieves the datum link of a UTxO by UTxOID and UTxOIDIndex, with the datum
+// content when it has been seen.
+func (d *MetadataStoreSqlite) GetDatum(txn *gorm.DB, utxoID []byte, utxoIndex uint32) (*models.UTxODatum, error) {
 	db := txn
 	if db == nil {
 		db = d.db
 	}
-	var datum models.Datum
-	result := db.Where("utxo_id = ? AND utxo_index = ?", utxoID, utxoIndex).First(&datum)
+	var utxoDatum models.UTxODatum
+	result := db.Preload("Datum").Where("utxo_id = ? AND utxo_index = ?", utxoID, utxoIndex).First(&utxoDatum)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil // Return nil Datum and nil error if not found
 		}
 		return nil, result.Error // Return other errors
 	}
-	return &datum, nil
+	return &utxoDatum, nil
 }
 
 // GetDatumByHash retrieves a Datum by its hash.
@@ -41,13 +43,9 @@ func (d *MetadataStoreSqlite) GetDatumByHash(txn *gorm.DB, datumHash []byte) (*m
 	return &datum, nil
 }
 
-
-// SetDatum stores or updates a Datum.
+// SetDatum stores a Datum. Datums are content-addressed, so a datum already stored
+// under the same hash is kept.
 func (d *MetadataStoreSqlite) SetDatum(txn *gorm.DB, datum *models.Datum) error {
-	db := txn
-	if db == nil {
-		db = d.db
-	}
 	if datum == nil {
 		return errors.New("datum cannot be nil")
 	}
@@ -57,7 +55,55 @@ func (d *MetadataStoreSqlite) SetDatum(txn *gorm.DB, datum *models.Datum) error
 	if len(datum.DatumCbor) == 0 {
 		return errors.New("datum cbor cannot be empty")
 	}
+	return d.setDatums(txn, []models.Datum{*datum})
+}
 
-	result := db.Save(datum) // Save will create or update based on primary key
+// setDatums stores the datums seen in a transaction, once per hash
+func (d *MetadataStoreSqlite) setDatums(txn *gorm.DB, datums []models.Datum) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	if len(datums) == 0 {
+		return nil
+	}
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "datum_hash"}},
+		DoNothing: true,
+	}).Create(&datums)
 	return result.Error
 }
+
+// setUTxODatum links a UTxO to the hash of its datum, keeping an existing link. The
+// same UTxO is saved as an output when created and as an input when spent.
+func (d *MetadataStoreSqlite) setUTxODatum(txn *gorm.DB, utxoDatum *models.UTxODatum) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	if utxoDatum == nil || len(utxoDatum.DatumHash) == 0 {
+		return nil
+	}
+	link := models.UTxODatum{
+		UTxOID:      utxoDatum.UTxOID,
+		UTxOIDIndex: utxoDatum.UTxOIDIndex,
+		DatumHash:   utxoDatum.DatumHash,
+		Inline:      utxoDatum.Inline,
+	}
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "utxo_id"}, {Name: "utxo_index"}},
+		DoNothing: true,
+	}).Create(&link)
+	return result.Error
+}
+
+// backfillUTxODatums links the UTxOs of datums stored before datums were stored once per
+// hash, when the datum table held the UTxO of the inline datum it was saved with.
+func (d *MetadataStoreSqlite) backfillUTxODatums() error {
+	migrator := d.db.Migrator()
+	if !migrator.HasColumn(&models.Datum{}, "utxo_id") || !migrator.HasColumn(&models.Datum{}, "utxo_index") {
+		return nil
+	}
+	return d.db.Exec("INSERT OR IGNORE INTO utxo_datums (utxo_id, utxo_index, datum_hash, inline) " +
+		"SELECT utxo_id, utxo_index, datum_hash, true FROM datum WHERE utxo_id IS NOT NULL").Error
+}
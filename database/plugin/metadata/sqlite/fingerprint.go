@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// AddFingerprint adds a asset fingerprint to the watchlist.
+func (d *MetadataStoreSqlite) AddFingerprint(txn *gorm.DB, fingerprint *models.Fingerprint) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	if fingerprint == nil {
+		return errors.New("fingerprint cannot be nil")
+	}
+	if fingerprint.Fingerprint == "" {
+		return errors.New("asset fingerprint cannot be empty")
+	}
+	var count int64
+	if err := db.Model(&models.Fingerprint{}).Where("fingerprint = ?", fingerprint.Fingerprint).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return models.ErrFingerprintAlreadyWatched
+	}
+	return db.Create(fingerprint).Error
+}
+
+// GetFingerprints returns every watched asset fingerprint.
+func (d *MetadataStoreSqlite) GetFingerprints(txn *gorm.DB) ([]models.Fingerprint, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var entries []models.Fingerprint
+	result := db.Order("id").Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return entries, nil
+}
+
+// RemoveFingerprint removes a asset fingerprint from the watchlist.
+func (d *MetadataStoreSqlite) RemoveFingerprint(txn *gorm.DB, fingerprint string) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	result := db.Where("fingerprint = ?", fingerprint).Delete(&models.Fingerprint{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
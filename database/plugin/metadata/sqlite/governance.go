@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// GetGovernanceProposals retrieves governance proposals, newest first, with
+// pagination support. A non-empty reward credential keeps only the proposals
+// whose deposit returns to that stake credential.
+func (d *MetadataStoreSqlite) GetGovernanceProposals(txn *gorm.DB, rewardCredential []byte, limit, offset int) ([]models.GovernanceProposal, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var proposals []models.GovernanceProposal
+	query := db.Order("slot_number DESC, id DESC")
+	if len(rewardCredential) > 0 {
+		query = query.Where("reward_credential = ?", rewardCredential)
+	}
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	result := query.Find(&proposals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return proposals, nil
+}
+
+// GetGovernanceVotes retrieves governance votes, newest first, with pagination
+// support. A non-empty voter hash keeps only that voter's votes and a non-empty
+// action transaction hash keeps only the votes on that governance action.
+func (d *MetadataStoreSqlite) GetGovernanceVotes(txn *gorm.DB, voterHash []byte, actionTxHash []byte, actionIndex uint32, limit, offset int) ([]models.GovernanceVote, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var votes []models.GovernanceVote
+	query := db.Order("slot_number DESC, id DESC")
+	if len(voterHash) > 0 {
+		query = query.Where("voter_hash = ?", voterHash)
+	}
+	if len(actionTxHash) > 0 {
+		query = query.Where("gov_action_tx_hash = ? AND gov_action_index = ?", actionTxHash, actionIndex)
+	}
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	result := query.Find(&votes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return votes, nil
+}
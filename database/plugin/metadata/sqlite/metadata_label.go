@@ -0,0 +1,21 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// GetTxsByMetadataLabel retrieves transactions carrying metadata under the given
+// label, with pagination support
+func (d *MetadataStoreSqlite) GetTxsByMetadataLabel(txn *gorm.DB, label uint64, limit, offset int) ([]models.Transaction, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+
+	labelledTxHashes := db.Model(&models.MetadataLabel{}).
+		Select("transaction_hash").
+		Where("label = ?", label)
+
+	return findTxs(db.Where("transaction_hash IN (?)", labelledTxHashes), limit, offset)
+}
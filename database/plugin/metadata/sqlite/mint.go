@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// redeemerTagMint is the ledger redeemer tag for minting policies
+const redeemerTagMint = 1
+
+// mintValidCondition matches the mints of transactions that passed phase-2 validation.
+// Transactions that failed it mint nothing.
+const mintValidCondition = "EXISTS (SELECT 1 FROM transactions " +
+	"WHERE transactions.transaction_hash = mints.transaction_hash AND transactions.is_valid)"
+
+// GetMintsByPolicyId retrieves the mint and burn history of a policy, newest
+// first, with the mint redeemer attached where there is one. Mints of transactions
+// that failed phase-2 validation are left out.
+func (d *MetadataStoreSqlite) GetMintsByPolicyId(txn *gorm.DB, policyId []byte, limit, offset int) ([]models.Mint, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var mints []models.Mint
+	query := db.Where("policy_id = ?", policyId).
+		Where(mintValidCondition).
+		Order("slot_number DESC, id DESC")
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	if result := query.Find(&mints); result.Error != nil {
+		return nil, result.Error
+	}
+
+	// Attach the mint redeemers
+	for i := range mints {
+		if mints[i].RedeemerIndex == nil {
+			continue
+		}
+		var redeemer models.Redeemer
+		result := db.Where("transaction_hash = ? AND tag = ? AND `index` = ?", mints[i].TransactionHash, redeemerTagMint, *mints[i].RedeemerIndex).
+			First(&redeemer)
+		if result.Error != nil {
+			if result.Error == gorm.ErrRecordNotFound {
+				continue
+			}
+			return nil, result.Error
+		}
+		mints[i].Redeemer = &redeemer
+	}
+	return mints, nil
+}
+
+// GetAssetSupplyByPolicyId sums the indexed mints and burns of each asset name
+// under a policy, leaving out transactions that failed phase-2 validation
+func (d *MetadataStoreSqlite) GetAssetSupplyByPolicyId(txn *gorm.DB, policyId []byte) ([]models.AssetSupply, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var supplies []models.AssetSupply
+	result := db.Model(&models.Mint{}).
+		Select("name_hex, MIN(name) AS name, MIN(fingerprint) AS fingerprint, SUM(quantity) AS supply, SUM(CASE WHEN quantity > 0 THEN quantity ELSE 0 END) AS minted, SUM(CASE WHEN quantity < 0 THEN -quantity ELSE 0 END) AS burned").
+		Where("policy_id = ?", policyId).
+		Where(mintValidCondition).
+		Group("name_hex").
+		Order("name_hex").
+		Scan(&supplies)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return supplies, nil
+}
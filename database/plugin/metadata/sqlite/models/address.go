@@ -1,14 +1,47 @@
 package models
 
 import (
+	"errors"
+
 	"gorm.io/gorm"
 )
 
+const (
+	AddressSourceAPI  = "api"
+	AddressSourceBulk = "bulk"
+)
+
+// ErrAddressAlreadyWatched is returned when adding an address that is already on the watchlist.
+var ErrAddressAlreadyWatched = errors.New("address is already on the watchlist")
+
+// Address is a watchlist entry. Transactions touching the address at or after StartSlot are indexed.
 type Address struct {
 	gorm.Model
 	Address   string `gorm:"uniqueIndex"`
+	Label     string `gorm:"index"`
+	Source    string `gorm:"index"`
+	StartSlot uint64
+	Metadata  []byte       `gorm:"type:blob"` // free-form JSON object
+	Tags      []AddressTag `gorm:"foreignKey:AddressID;constraint:OnDelete:CASCADE"`
 }
 
 func (Address) TableName() string {
 	return "Address"
 }
+
+// AddressTag puts a watchlist entry in a group.
+type AddressTag struct {
+	ID        uint   `gorm:"primarykey"`
+	AddressID uint   `gorm:"uniqueIndex:idx_address_tag"`
+	Tag       string `gorm:"uniqueIndex:idx_address_tag;index"`
+}
+
+func (AddressTag) TableName() string {
+	return "address_tags"
+}
+
+// AddressTagCount is the number of watched addresses in a group.
+type AddressTagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
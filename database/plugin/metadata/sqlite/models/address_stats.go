@@ -0,0 +1,62 @@
+package models
+
+// AddressStats is the activity of an address, updated as each transaction involving it is
+// indexed. TotalReceived and TotalSent sum the lovelace of the UTxOs the transactions
+// produced to and consumed from the address, change included. FeesPaid sums the fees of the
+// transactions the address funded, i.e. contributed the most lovelace to the inputs of.
+type AddressStats struct {
+	ID            uint   `gorm:"primaryKey" json:"id"`
+	Address       []byte `gorm:"uniqueIndex;type:blob" json:"address"`
+	FirstSlot     uint64 `json:"first_slot"`
+	FirstTxHash   []byte `gorm:"type:blob" json:"first_tx_hash"`
+	LastSlot      uint64 `json:"last_slot"`
+	LastTxHash    []byte `gorm:"type:blob" json:"last_tx_hash"`
+	TxCount       int64  `json:"tx_count"`
+	TotalReceived uint64 `json:"total_received"`
+	TotalSent     uint64 `json:"total_sent"`
+	FeesPaid      uint64 `json:"fees_paid"`
+	// DistinctAssets and Counterparties are counted from AddressAsset and AddressCounterparty
+	DistinctAssets int64 `gorm:"-" json:"distinct_assets"`
+	Counterparties int64 `gorm:"-" json:"counterparties"`
+}
+
+func (AddressStats) TableName() string {
+	return "address_stats"
+}
+
+// AddressTransaction records that a transaction was counted in the stats of an address, so
+// that a transaction indexed again is not counted twice
+type AddressTransaction struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	Address         []byte `gorm:"uniqueIndex:idx_address_transaction;type:blob" json:"address"`
+	TransactionHash []byte `gorm:"uniqueIndex:idx_address_transaction;index;type:blob" json:"transaction_hash"`
+}
+
+func (AddressTransaction) TableName() string {
+	return "address_transactions"
+}
+
+// AddressAsset is an asset an address has held. PolicyId and NameHex are stored as strings,
+// like in Asset.
+type AddressAsset struct {
+	ID       uint   `gorm:"primaryKey" json:"id"`
+	Address  []byte `gorm:"uniqueIndex:idx_address_asset;type:blob" json:"address"`
+	PolicyId []byte `gorm:"uniqueIndex:idx_address_asset;type:blob" json:"policy_id"`
+	NameHex  []byte `gorm:"uniqueIndex:idx_address_asset;type:blob" json:"name_hex"`
+}
+
+func (AddressAsset) TableName() string {
+	return "address_assets"
+}
+
+// AddressCounterparty is an address on the other side of a transaction of an address: an
+// address it paid, or an address that paid it
+type AddressCounterparty struct {
+	ID           uint   `gorm:"primaryKey" json:"id"`
+	Address      []byte `gorm:"uniqueIndex:idx_address_counterparty;type:blob" json:"address"`
+	Counterparty []byte `gorm:"uniqueIndex:idx_address_counterparty;type:blob" json:"counterparty"`
+}
+
+func (AddressCounterparty) TableName() string {
+	return "address_counterparties"
+}
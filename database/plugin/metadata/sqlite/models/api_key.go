@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	APIKeyScopeRead  = "read"
+	APIKeyScopeAdmin = "admin"
+)
+
+// APIKey is an API key issued to a client. Only the SHA-256 hash of the key is stored.
+type APIKey struct {
+	gorm.Model
+	Name      string     `gorm:"index" json:"name"`
+	Prefix    string     `gorm:"index" json:"prefix"`
+	KeyHash   []byte     `gorm:"type:blob;uniqueIndex" json:"-"`
+	Scope     string     `json:"scope"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	// DailyRowQuota overrides the configured daily row quota for this key. 0 uses the configured one.
+	DailyRowQuota int64 `json:"daily_row_quota"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// IsRevoked reports whether the key has been revoked.
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// HasScope reports whether the key grants the given scope. Admin keys can also read.
+func (k *APIKey) HasScope(scope string) bool {
+	switch scope {
+	case APIKeyScopeRead:
+		return k.Scope == APIKeyScopeRead || k.Scope == APIKeyScopeAdmin
+	case APIKeyScopeAdmin:
+		return k.Scope == APIKeyScopeAdmin
+	}
+	return false
+}
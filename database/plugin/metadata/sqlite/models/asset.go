@@ -14,3 +14,20 @@ type Asset struct {
 func (Asset) TableName() string {
 	return "assets"
 }
+
+// PolicyAsset is an asset name under a policy with the quantity held in unspent outputs and
+// the number of addresses holding it. PolicyId, NameHex and Fingerprint are stored as strings,
+// like in Asset.
+type PolicyAsset struct {
+	NameHex     []byte `json:"name_hex"`
+	Name        []byte `json:"name"`
+	Fingerprint []byte `json:"fingerprint"`
+	Supply      uint64 `json:"supply"`
+	Holders     int64  `json:"holders"`
+}
+
+// AssetHolder is an address holding an asset in unspent outputs, with the quantity it holds.
+type AssetHolder struct {
+	Address  []byte `json:"address"`
+	Quantity uint64 `json:"quantity"`
+}
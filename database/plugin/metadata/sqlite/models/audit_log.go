@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AuditLog records a change made through an admin endpoint and the API key that made it.
+type AuditLog struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt   time.Time `gorm:"index" json:"created_at"`
+	APIKeyID    uint      `gorm:"index" json:"api_key_id"`
+	APIKeyName  string    `json:"api_key_name"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	RequestBody []byte    `gorm:"type:blob" json:"request_body"`
+	StatusCode  int       `json:"status_code"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -0,0 +1,18 @@
+package models
+
+// AssetBalance is the quantity of an asset held at an address. PolicyId, NameHex and
+// Fingerprint are stored as strings, like in Asset.
+type AssetBalance struct {
+	PolicyId    []byte `json:"policy_id"`
+	NameHex     []byte `json:"name_hex"`
+	Name        []byte `json:"name"`
+	Fingerprint []byte `json:"fingerprint"`
+	Quantity    uint64 `json:"quantity"`
+}
+
+// BalanceDelta is the lovelace an address received and sent in the transactions of a slot
+type BalanceDelta struct {
+	SlotNumber uint64 `json:"slot_number"`
+	Received   uint64 `json:"received"`
+	Sent       uint64 `json:"sent"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Block is a block seen on chain sync, whether or not any of its transactions was indexed.
+// BlockHash is stored as a hex string, like Transaction.BlockHash. Issuer is the pool ID of
+// the block producer, empty for Byron blocks. RelevantTxCount is the number of its
+// transactions that were indexed.
+type Block struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	BlockHash       []byte    `gorm:"uniqueIndex;type:blob" json:"block_hash"`
+	BlockNumber     uint64    `gorm:"index" json:"block_number"`
+	SlotNumber      uint64    `gorm:"index" json:"slot_number"`
+	BlockTime       time.Time `json:"block_time"`
+	Era             string    `json:"era"`
+	Size            uint64    `json:"size"`
+	Issuer          string    `json:"issuer"`
+	TxCount         uint64    `json:"tx_count"`
+	RelevantTxCount uint64    `json:"relevant_tx_count"`
+}
+
+func (Block) TableName() string {
+	return "blocks"
+}
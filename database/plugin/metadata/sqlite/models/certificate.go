@@ -0,0 +1,125 @@
+package models
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/types"
+)
+
+// Certificate types, by ledger certificate tag
+const (
+	CertificateTypeStakeRegistration               = "stake_registration"
+	CertificateTypeStakeDeregistration             = "stake_deregistration"
+	CertificateTypeStakeDelegation                 = "stake_delegation"
+	CertificateTypePoolRegistration                = "pool_registration"
+	CertificateTypePoolRetirement                  = "pool_retirement"
+	CertificateTypeGenesisKeyDelegation            = "genesis_key_delegation"
+	CertificateTypeMoveInstantaneousRewards        = "move_instantaneous_rewards"
+	CertificateTypeRegistration                    = "registration"
+	CertificateTypeDeregistration                  = "deregistration"
+	CertificateTypeVoteDelegation                  = "vote_delegation"
+	CertificateTypeStakeVoteDelegation             = "stake_vote_delegation"
+	CertificateTypeStakeRegistrationDelegation     = "stake_registration_delegation"
+	CertificateTypeVoteRegistrationDelegation      = "vote_registration_delegation"
+	CertificateTypeStakeVoteRegistrationDelegation = "stake_vote_registration_delegation"
+	CertificateTypeAuthCommitteeHot                = "auth_committee_hot"
+	CertificateTypeResignCommitteeCold             = "resign_committee_cold"
+	CertificateTypeDrepRegistration                = "drep_registration"
+	CertificateTypeDrepDeregistration              = "drep_deregistration"
+	CertificateTypeDrepUpdate                      = "drep_update"
+)
+
+var certificateTypes = []string{
+	CertificateTypeStakeRegistration,
+	CertificateTypeStakeDeregistration,
+	CertificateTypeStakeDelegation,
+	CertificateTypePoolRegistration,
+	CertificateTypePoolRetirement,
+	CertificateTypeGenesisKeyDelegation,
+	CertificateTypeMoveInstantaneousRewards,
+	CertificateTypeRegistration,
+	CertificateTypeDeregistration,
+	CertificateTypeVoteDelegation,
+	CertificateTypeStakeVoteDelegation,
+	CertificateTypeStakeRegistrationDelegation,
+	CertificateTypeVoteRegistrationDelegation,
+	CertificateTypeStakeVoteRegistrationDelegation,
+	CertificateTypeAuthCommitteeHot,
+	CertificateTypeResignCommitteeCold,
+	CertificateTypeDrepRegistration,
+	CertificateTypeDrepDeregistration,
+	CertificateTypeDrepUpdate,
+}
+
+// CertificateDelegationTypes are the certificate types that delegate stake to a pool
+// and/or voting power to a DRep
+var CertificateDelegationTypes = []string{
+	CertificateTypeStakeDelegation,
+	CertificateTypeVoteDelegation,
+	CertificateTypeStakeVoteDelegation,
+	CertificateTypeStakeRegistrationDelegation,
+	CertificateTypeVoteRegistrationDelegation,
+	CertificateTypeStakeVoteRegistrationDelegation,
+}
+
+// CertificateType returns the type of a certificate from its ledger tag, or "" for an unknown tag
+func CertificateType(tag uint) string {
+	if tag < uint(len(certificateTypes)) {
+		return certificateTypes[tag]
+	}
+	return ""
+}
+
+// IsCertificateType reports whether the string is a known certificate type
+func IsCertificateType(certType string) bool {
+	for _, t := range certificateTypes {
+		if t == certType {
+			return true
+		}
+	}
+	return false
+}
+
+// Certificate is a decoded transaction certificate. Only the fields of its type are set.
+type Certificate struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	TransactionHash []byte `gorm:"index;type:blob" json:"transaction_hash"`
+	// CertIndex is the position of the certificate in the transaction body
+	CertIndex   uint32 `json:"cert_index"`
+	BlockNumber uint64 `json:"block_number"`
+	SlotNumber  uint64 `gorm:"index" json:"slot_number"`
+	Type        string `gorm:"index" json:"type"`
+	// StakeCredential is the stake credential registered, deregistered or delegated;
+	// StakeCredentialType is 0 for a key hash and 1 for a script hash
+	StakeCredential     []byte `gorm:"type:blob;index" json:"stake_credential"`
+	StakeCredentialType *uint8 `json:"stake_credential_type"`
+	// PoolKeyHash is the pool delegated to, registered or retired
+	PoolKeyHash []byte `gorm:"type:blob;index" json:"pool_key_hash"`
+	// DrepCredential is the DRep delegated to, registered, deregistered or updated.
+	// DrepType is 0 for a key hash, 1 for a script hash, 2 for always abstain and 3 for
+	// always no confidence; the last two have no credential.
+	DrepType                *uint8 `json:"drep_type"`
+	DrepCredential          []byte `gorm:"type:blob;index" json:"drep_credential"`
+	CommitteeColdCredential []byte `gorm:"type:blob" json:"committee_cold_credential"`
+	CommitteeHotCredential  []byte `gorm:"type:blob" json:"committee_hot_credential"`
+	// Amount is the deposit paid or refunded, or the total moved by instantaneous rewards
+	Amount int64 `json:"amount"`
+	// Epoch is the retirement epoch of a pool retirement
+	Epoch uint64 `json:"epoch"`
+	// Pool registration parameters. Margin is a fraction such as "1/50" and PoolRelays
+	// holds the relays as a JSON array.
+	VrfKeyHash        []byte               `gorm:"type:blob" json:"vrf_key_hash"`
+	Pledge            uint64               `json:"pledge"`
+	Cost              uint64               `json:"cost"`
+	Margin            string               `json:"margin"`
+	PoolRewardAccount []byte               `gorm:"type:blob" json:"pool_reward_account"`
+	PoolOwners        types.ByteSliceSlice `gorm:"type:blob" json:"pool_owners"`
+	PoolRelays        string               `json:"pool_relays"`
+	PoolMetadataUrl   string               `json:"pool_metadata_url"`
+	PoolMetadataHash  []byte               `gorm:"type:blob" json:"pool_metadata_hash"`
+	AnchorUrl         string               `json:"anchor_url"`
+	AnchorDataHash    []byte               `gorm:"type:blob" json:"anchor_data_hash"`
+	Cbor              []byte               `gorm:"type:blob" json:"cbor"`
+}
+
+func (Certificate) TableName() string {
+	return "certificates"
+}
@@ -0,0 +1,14 @@
+package models
+
+// CollateralInput is a UTxO pledged as collateral by a transaction. It is only
+// consumed if the transaction fails phase-2 validation.
+type CollateralInput struct {
+	ID              uint   `gorm:"primaryKey"`
+	TransactionHash []byte `gorm:"type:blob;index" json:"transaction_hash"`
+	UTxOID          []byte `gorm:"type:blob;column:utxo_id" json:"utxo_id"`
+	UTxOIDIndex     uint32 `gorm:"index:collateral_input_utxo_idx;column:utxo_index" json:"utxo_index"`
+}
+
+func (CollateralInput) TableName() string {
+	return "collateral_inputs"
+}
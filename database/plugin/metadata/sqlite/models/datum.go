@@ -1,13 +1,29 @@
 package models
 
+// Datum is a datum stored once per hash, whether it was seen inline in an output or in the
+// witness set of a transaction. UTxOs link to it through UTxODatum.
 type Datum struct {
-	ID          uint   `gorm:"primaryKey"`
-	UTxOID      []byte `gorm:"type:blob;index:datum_utxo_idx;column:utxo_id" json:"utxo_id"`
-	UTxOIDIndex uint32 `gorm:"index:datum_utxo_idx;column:utxo_index" json:"utxo_index"`
-	DatumHash   []byte `gorm:"type:blob;not null;unique" json:"datum_hash"`
-	DatumCbor   []byte `gorm:"type:blob;not null" json:"datum_cbor"`
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	DatumHash []byte `gorm:"type:blob;not null;unique" json:"datum_hash"`
+	DatumCbor []byte `gorm:"type:blob;not null" json:"datum_cbor"`
 }
 
 func (Datum) TableName() string {
 	return "datum"
 }
+
+// UTxODatum links a UTxO to the hash of its datum. Inline is set when the output carries
+// the datum itself rather than its hash. Datum is nil until the datum content has been
+// seen, inline or in the witness set of a transaction.
+type UTxODatum struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	UTxOID      []byte `gorm:"type:blob;uniqueIndex:utxo_datum_idx;column:utxo_id" json:"utxo_id"`
+	UTxOIDIndex uint32 `gorm:"uniqueIndex:utxo_datum_idx;column:utxo_index" json:"utxo_index"`
+	DatumHash   []byte `gorm:"type:blob;index" json:"datum_hash"`
+	Inline      bool   `json:"inline"`
+	Datum       *Datum `gorm:"foreignKey:DatumHash;references:DatumHash" json:"datum,omitempty"`
+}
+
+func (UTxODatum) TableName() string {
+	return "utxo_datums"
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrFingerprintAlreadyWatched is returned when adding an asset fingerprint that is already watched.
+var ErrFingerprintAlreadyWatched = errors.New("fingerprint is already on the watchlist")
+
+// Fingerprint is a watched asset (CIP-14 fingerprint). Transactions moving the asset are indexed.
+type Fingerprint struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	Fingerprint string    `gorm:"uniqueIndex" json:"fingerprint"` // bech32, asset1...
+	Label       string    `json:"label"`
+}
+
+func (Fingerprint) TableName() string {
+	return "fingerprints"
+}
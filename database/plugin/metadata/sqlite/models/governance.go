@@ -0,0 +1,49 @@
+package models
+
+// GovernanceProposal is a Conway proposal procedure. The governance action it submits is
+// identified by the transaction hash and the position of the proposal in the transaction.
+type GovernanceProposal struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	TransactionHash []byte `gorm:"index:idx_governance_proposal_action;type:blob" json:"transaction_hash"`
+	ProposalIndex   uint32 `gorm:"index:idx_governance_proposal_action" json:"proposal_index"`
+	BlockNumber     uint64 `json:"block_number"`
+	SlotNumber      uint64 `gorm:"index" json:"slot_number"`
+	Deposit         uint64 `json:"deposit"`
+	// RewardAccount receives the deposit back; RewardCredential is its stake credential
+	RewardAccount    string `json:"reward_account"`
+	RewardCredential []byte `gorm:"type:blob;index" json:"reward_credential"`
+	ActionType       uint8  `gorm:"index" json:"action_type"`
+	// PrevActionTxHash and PrevActionIndex identify the previous action of the same purpose, if any
+	PrevActionTxHash []byte  `gorm:"type:blob" json:"prev_action_tx_hash"`
+	PrevActionIndex  *uint32 `json:"prev_action_index"`
+	// PolicyHash is the guardrail script of parameter changes and treasury withdrawals
+	PolicyHash     []byte `gorm:"type:blob" json:"policy_hash"`
+	AnchorUrl      string `json:"anchor_url"`
+	AnchorDataHash []byte `gorm:"type:blob" json:"anchor_data_hash"`
+	ActionCbor     []byte `gorm:"type:blob" json:"action_cbor"`
+}
+
+func (GovernanceProposal) TableName() string {
+	return "governance_proposals"
+}
+
+// GovernanceVote is a vote cast in a Conway voting procedure.
+type GovernanceVote struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	TransactionHash []byte `gorm:"index;type:blob" json:"transaction_hash"`
+	BlockNumber     uint64 `json:"block_number"`
+	SlotNumber      uint64 `gorm:"index" json:"slot_number"`
+	// VoterType is the ledger voter tag: committee hot key/script, DRep key/script or stake pool
+	VoterType uint8  `json:"voter_type"`
+	VoterHash []byte `gorm:"type:blob;index" json:"voter_hash"`
+	// GovActionTxHash and GovActionIndex identify the governance action voted on
+	GovActionTxHash []byte `gorm:"index:idx_governance_vote_action;type:blob" json:"gov_action_tx_hash"`
+	GovActionIndex  uint32 `gorm:"index:idx_governance_vote_action" json:"gov_action_index"`
+	Vote            uint8  `json:"vote"`
+	AnchorUrl       string `json:"anchor_url"`
+	AnchorDataHash  []byte `gorm:"type:blob" json:"anchor_data_hash"`
+}
+
+func (GovernanceVote) TableName() string {
+	return "governance_votes"
+}
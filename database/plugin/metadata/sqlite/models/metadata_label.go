@@ -0,0 +1,16 @@
+package models
+
+// MetadataLabel indexes a transaction by one of its metadata labels. Cbor is the
+// metadatum stored under the label.
+type MetadataLabel struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	TransactionHash []byte `gorm:"index;type:blob" json:"transaction_hash"`
+	BlockNumber     uint64 `json:"block_number"`
+	SlotNumber      uint64 `gorm:"index" json:"slot_number"`
+	Label           uint64 `gorm:"index" json:"label"`
+	Cbor            []byte `gorm:"type:blob" json:"cbor"`
+}
+
+func (MetadataLabel) TableName() string {
+	return "metadata_labels"
+}
@@ -0,0 +1,32 @@
+package models
+
+// Mint is a mint (positive quantity) or burn (negative quantity) of one asset in a transaction.
+// PolicyId, NameHex and Fingerprint are stored as strings, like in Asset.
+type Mint struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	TransactionHash []byte `gorm:"index;type:blob" json:"transaction_hash"`
+	BlockNumber     uint64 `json:"block_number"`
+	SlotNumber      uint64 `gorm:"index" json:"slot_number"`
+	PolicyId        []byte `gorm:"index:idx_mint_policy_name;type:blob" json:"policy_id"`
+	NameHex         []byte `gorm:"index:idx_mint_policy_name;type:blob" json:"name_hex"`
+	Name            []byte `gorm:"type:blob" json:"name"`
+	Fingerprint     []byte `gorm:"index;type:blob" json:"fingerprint"`
+	Quantity        int64  `json:"quantity"`
+	// RedeemerIndex is the index of the mint redeemer for the policy, nil for native script policies
+	RedeemerIndex *uint     `json:"redeemer_index"`
+	Redeemer      *Redeemer `gorm:"-" json:"redeemer,omitempty"`
+}
+
+func (Mint) TableName() string {
+	return "mints"
+}
+
+// AssetSupply is the net minted quantity of an asset name under a policy.
+type AssetSupply struct {
+	NameHex     []byte `json:"name_hex"`
+	Name        []byte `json:"name"`
+	Fingerprint []byte `json:"fingerprint"`
+	Supply      int64  `json:"supply"`
+	Minted      int64  `json:"minted"`
+	Burned      int64  `json:"burned"`
+}
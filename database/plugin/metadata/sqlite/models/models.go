@@ -7,12 +7,36 @@ import (
 // MigrateModels contains a list of model objects that should have DB migrations applied
 var MigrateModels = []any{
 	&Address{},
+	&AddressTag{},
+	&Policy{},
+	&Fingerprint{},
 	&Transaction{},
 	&TransactionInput{},
 	&TransactionOutput{},
 	&Asset{},
 	&Datum{},
+	&UTxODatum{},
 	&Redeemer{},
 	&Witness{},
+	&VkeyWitness{},
+	&NativeScript{},
+	&Script{},
+	&TransactionScript{},
 	&SimpleUTxO{}, // Add SimpleUTxO to the migration list
+	&Withdrawal{},
+	&Mint{},
+	&CollateralInput{},
+	&GovernanceProposal{},
+	&GovernanceVote{},
+	&Certificate{},
+	&MetadataLabel{},
+	&TokenMetadata{},
+	&Block{},
+	&AddressStats{},
+	&AddressTransaction{},
+	&AddressAsset{},
+	&AddressCounterparty{},
+	&APIKey{},
+	&AuditLog{},
+	&QuotaUsage{},
 }
@@ -0,0 +1,14 @@
+package models
+
+// NativeScript is a native (timelock/multisig) script carried in a
+// transaction witness set.
+type NativeScript struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	TransactionHash []byte `gorm:"index;type:blob" json:"transaction_hash"`
+	ScriptHash      []byte `gorm:"index;type:blob" json:"script_hash"`
+	Cbor            []byte `gorm:"type:blob" json:"cbor"`
+}
+
+func (NativeScript) TableName() string {
+	return "native_scripts"
+}
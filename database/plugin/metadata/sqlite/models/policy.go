@@ -0,0 +1,21 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrPolicyAlreadyWatched is returned when adding a policy ID that is already watched.
+var ErrPolicyAlreadyWatched = errors.New("policy is already on the watchlist")
+
+// Policy is a watched minting policy. Transactions moving any asset of the policy are indexed.
+type Policy struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	PolicyID  string    `gorm:"uniqueIndex" json:"policy_id"` // hex encoded
+	Label     string    `json:"label"`
+}
+
+func (Policy) TableName() string {
+	return "policies"
+}
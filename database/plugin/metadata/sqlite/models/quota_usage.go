@@ -0,0 +1,14 @@
+package models
+
+// QuotaUsage counts the result rows served to a client on a given UTC day.
+// Client is "key:<api key id>" for authenticated requests and "ip:<address>" otherwise.
+type QuotaUsage struct {
+	ID     uint   `gorm:"primarykey"`
+	Client string `gorm:"uniqueIndex:idx_quota_usage_client_day"`
+	Day    string `gorm:"uniqueIndex:idx_quota_usage_client_day"`
+	Rows   int64
+}
+
+func (QuotaUsage) TableName() string {
+	return "quota_usage"
+}
@@ -1,11 +1,49 @@
 package models
 
+// Redeemer purposes, in redeemer tag order
+const (
+	RedeemerPurposeSpend   = "spend"
+	RedeemerPurposeMint    = "mint"
+	RedeemerPurposeCert    = "cert"
+	RedeemerPurposeReward  = "reward"
+	RedeemerPurposeVote    = "vote"
+	RedeemerPurposePropose = "propose"
+)
+
+var redeemerPurposes = []string{
+	RedeemerPurposeSpend,
+	RedeemerPurposeMint,
+	RedeemerPurposeCert,
+	RedeemerPurposeReward,
+	RedeemerPurposeVote,
+	RedeemerPurposePropose,
+}
+
+// RedeemerPurpose returns the purpose name of a redeemer tag, or an empty string for unknown tags
+func RedeemerPurpose(tag uint8) string {
+	if int(tag) < len(redeemerPurposes) {
+		return redeemerPurposes[tag]
+	}
+	return ""
+}
+
 type Redeemer struct {
 	ID              uint   `gorm:"primaryKey"`
 	TransactionHash []byte `gorm:"index"`
 	Index           uint   `gorm:"index" json:"index"`
 	Tag             uint8  `gorm:"index" json:"tag"`
 	Cbor            []byte `gorm:"type:blob" json:"cbor"`
+	ExUnitsMem      uint64 `json:"ex_units_mem"`
+	ExUnitsSteps    uint64 `json:"ex_units_steps"`
+	Purpose         string `gorm:"index" json:"purpose"`
+	// ScriptHash is the script the redeemer is passed to, when it could be resolved
+	ScriptHash []byte `gorm:"type:blob;index" json:"script_hash"`
+	// The purpose target: the spent UTxO for spend, the certificate position for cert and
+	// the reward account for reward. The policy ID of a mint is its script hash.
+	UTxOID           []byte  `gorm:"type:blob;column:utxo_id" json:"utxo_id"`
+	UTxOIDIndex      *uint32 `gorm:"column:utxo_index" json:"utxo_index"`
+	CertificateIndex *uint   `json:"certificate_index"`
+	RewardAccount    string  `json:"reward_account"`
 }
 
 func (Redeemer) TableName() string {
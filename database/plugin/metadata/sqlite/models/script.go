@@ -0,0 +1,38 @@
+package models
+
+// Script languages, numbered as in script references and script hash prefixes
+const (
+	ScriptLanguageNative   uint8 = 0
+	ScriptLanguagePlutusV1 uint8 = 1
+	ScriptLanguagePlutusV2 uint8 = 2
+	ScriptLanguagePlutusV3 uint8 = 3
+)
+
+// Script is a native or Plutus script seen in a witness set or an output script
+// reference. Each script is stored once, from the first transaction it was seen in.
+type Script struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	ScriptHash      []byte `gorm:"uniqueIndex;type:blob" json:"script_hash"`
+	Language        uint8  `json:"language"`
+	Cbor            []byte `gorm:"type:blob" json:"cbor"`
+	Size            int    `json:"size"`
+	FirstSeenTxHash []byte `gorm:"type:blob" json:"first_seen_tx_hash"`
+	FirstSeenSlot   uint64 `json:"first_seen_slot"`
+}
+
+func (Script) TableName() string {
+	return "scripts"
+}
+
+// TransactionScript records a script executed by a transaction: a spending, minting,
+// withdrawal, certifying, voting or proposing script, whether supplied in the witness set
+// or by a reference input.
+type TransactionScript struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	TransactionHash []byte `gorm:"index;type:blob" json:"transaction_hash"`
+	ScriptHash      []byte `gorm:"index;type:blob" json:"script_hash"`
+}
+
+func (TransactionScript) TableName() string {
+	return "transaction_scripts"
+}
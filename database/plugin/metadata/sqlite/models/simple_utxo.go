@@ -1,10 +1,24 @@
 package models
 
+// SimpleUTxO is a UTxO reference. Reference inputs are stored as SimpleUTxOs, resolved
+// at ingest through Kupo like spent inputs, or against the output they point to when that
+// output has been indexed; the assets and datum of the UTxO are linked by UTxOID and
+// UTxOIDIndex.
 type SimpleUTxO struct {
-	ID              uint   `gorm:"primaryKey"`
-	TransactionHash []byte `gorm:"type:blob" json:"transaction_hash"`
-	UTxOID          []byte `gorm:"type:blob;column:utxo_id" json:"utxo_id"`
-	UTxOIDIndex     uint32 `gorm:"index:simple_utxo_idx;column:utxo_index" json:"utxo_index"`
+	ID                uint       `gorm:"primaryKey"`
+	TransactionHash   []byte     `gorm:"type:blob" json:"transaction_hash"`
+	UTxOID            []byte     `gorm:"type:blob;index;column:utxo_id" json:"utxo_id"`
+	UTxOIDIndex       uint32     `gorm:"index:simple_utxo_idx;column:utxo_index" json:"utxo_index"`
+	Resolved          bool       `json:"resolved"`
+	Address           []byte     `gorm:"type:blob" json:"address"`
+	PaymentCredential []byte     `gorm:"type:blob" json:"payment_credential"`
+	StakeCredential   []byte     `gorm:"type:blob" json:"stake_credential"`
+	Amount            uint64     `json:"amount"`
+	Asset             []Asset    `gorm:"foreignKey:UTxOID,UTxOIDIndex;references:UTxOID,UTxOIDIndex;<-:false" json:"asset"`
+	Datum             *UTxODatum `gorm:"foreignKey:UTxOID,UTxOIDIndex;references:UTxOID,UTxOIDIndex;<-:false" json:"datum"`
+	Cbor              []byte     `gorm:"type:blob" json:"cbor"`
+	// ReferenceScriptHash is the hash of the script reference carried by the UTxO, if any
+	ReferenceScriptHash []byte `gorm:"type:blob" json:"reference_script_hash,omitempty"`
 }
 
 func (SimpleUTxO) TableName() string {
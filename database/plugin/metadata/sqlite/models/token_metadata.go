@@ -0,0 +1,31 @@
+package models
+
+// Token metadata standards
+const (
+	TokenMetadataStandardCIP25 = "cip25"
+	TokenMetadataStandardCIP68 = "cip68"
+)
+
+// TokenMetadata is the latest metadata resolved for an asset. CIP-25 metadata is keyed by
+// the minted asset and CIP-68 metadata by its reference token (CIP-67 label 100), whose
+// datum holds the metadata of the matching user tokens. PolicyId, NameHex and Fingerprint
+// are stored as strings, like in Asset.
+type TokenMetadata struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	PolicyId    []byte `gorm:"uniqueIndex:idx_token_metadata_asset;type:blob" json:"policy_id"`
+	NameHex     []byte `gorm:"uniqueIndex:idx_token_metadata_asset;type:blob" json:"name_hex"`
+	Fingerprint []byte `gorm:"index;type:blob" json:"fingerprint"`
+	Standard    string `json:"standard"`
+	Version     int64  `json:"version"`
+	// Metadata is the metadata as JSON, Cbor the metadatum or datum it was decoded from
+	Metadata        string `json:"metadata"`
+	Cbor            []byte `gorm:"type:blob" json:"cbor"`
+	TransactionHash []byte `gorm:"type:blob" json:"transaction_hash"`
+	SlotNumber      uint64 `json:"slot_number"`
+	// OutputIndex is the output holding the CIP-68 reference token, nil for CIP-25 metadata
+	OutputIndex *uint32 `json:"output_index"`
+}
+
+func (TokenMetadata) TableName() string {
+	return "token_metadata"
+}
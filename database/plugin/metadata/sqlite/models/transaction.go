@@ -46,11 +46,45 @@ type Transaction struct {
 	Outputs         []TransactionOutput `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"outputs"`
 	ReferenceInputs []SimpleUTxO        `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"reference_inputs"`
 	Metadata        []byte              `gorm:"type:blob" json:"metadata"`
+	// MetadataLabels indexes Metadata by label, one row per label
+	MetadataLabels  []MetadataLabel     `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"-"`
 	Fee             uint64              `gorm:"index" json:"fee"`
 	TTL             uint64              `gorm:"index" json:"ttl"`
 	Withdrawals     WithdrawalsMap      `gorm:"type:blob" json:"withdrawals"`
+	// StakeWithdrawals holds the same withdrawals as Withdrawals, one indexed row per stake credential
+	StakeWithdrawals []Withdrawal       `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"-"`
+	Mints           []Mint              `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"mints"`
 	Witness         Witness             `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"witness"`
+	// Datums are the datums seen inline in the outputs and in the witness set. They are
+	// stored once per datum hash, so they are saved apart from the transaction.
+	Datums          []Datum             `gorm:"-" json:"-"`
+	// Scripts are the scripts seen in the witness set and output script references. They are
+	// stored once per script hash, so they are saved apart from the transaction.
+	Scripts         []Script            `gorm:"-" json:"-"`
+	ExecutedScripts []TransactionScript `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"executed_scripts"`
+	// TokenMetadata is the CIP-25 and CIP-68 token metadata the transaction sets. It is kept
+	// once per asset, so it is saved apart from the transaction.
+	TokenMetadata   []TokenMetadata     `gorm:"-" json:"-"`
 	Certificates    types.ByteSliceSlice `gorm:"type:blob" json:"certificate"`
+	// DecodedCertificates holds the same certificates as Certificates, decoded into one row each
+	DecodedCertificates []Certificate   `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"decoded_certificates"`
+	ValidityIntervalStart uint64            `gorm:"index" json:"validity_interval_start"`
+	Collateral      []CollateralInput   `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"collateral"`
+	// CollateralReturn is stored with the outputs but linked by CollateralReturnOf, so it is not one of Outputs
+	CollateralReturn *TransactionOutput `gorm:"foreignKey:CollateralReturnOf;references:TransactionHash" json:"collateral_return"`
+	TotalCollateral uint64              `json:"total_collateral"`
+	RequiredSigners types.ByteSliceSlice `gorm:"type:blob" json:"required_signers"`
+	// NetworkId is nil when the body has no network ID field
+	NetworkId       *uint8              `json:"network_id"`
+	ScriptDataHash  []byte              `gorm:"type:blob" json:"script_data_hash"`
+	// IsValid is false for transactions that failed phase-2 (script) validation. Such transactions
+	// consume their collateral and produce their collateral return instead of their inputs and outputs.
+	IsValid         bool                `gorm:"index" json:"is_valid"`
+	Proposals       []GovernanceProposal `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"proposals"`
+	Votes           []GovernanceVote     `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"votes"`
+	// CurrentTreasuryValue is the treasury value the transaction asserts, zero when absent
+	CurrentTreasuryValue int64          `json:"current_treasury_value"`
+	TreasuryDonation     uint64         `json:"treasury_donation"`
 }
 
 // TableName overrides the table name
@@ -1,15 +1,25 @@
 package models
 
 type TransactionOutput struct {
-	ID              uint    `gorm:"primaryKey" json:"id"`
-	TransactionHash []byte  `gorm:"index;type:blob" json:"transaction_hash"`
-	UTxOID          []byte  `gorm:"type:blob;column:utxo_id" json:"utxo_id"`
-	UTxOIDIndex     uint32  `gorm:"index:tx_output_utxo_idx;column:utxo_index" json:"utxo_index"`
-	Address         []byte  `gorm:"type:blob" json:"address"`
-	Amount          uint64  `gorm:"index" json:"amount"`
-	Asset           []Asset `gorm:"foreignKey:UTxOID,UTxOIDIndex;references:UTxOID,UTxOIDIndex" json:"asset"`
-	Datum           Datum   `gorm:"foreignKey:UTxOID,UTxOIDIndex;references:UTxOID,UTxOIDIndex" json:"datum"`
-	Cbor            []byte  `gorm:"type:blob" json:"cbor"`
+	ID                uint    `gorm:"primaryKey" json:"id"`
+	TransactionHash   []byte  `gorm:"index;type:blob" json:"transaction_hash"`
+	UTxOID            []byte  `gorm:"type:blob;column:utxo_id" json:"utxo_id"`
+	UTxOIDIndex       uint32  `gorm:"index:tx_output_utxo_idx;column:utxo_index" json:"utxo_index"`
+	Address           []byte  `gorm:"type:blob" json:"address"`
+	PaymentCredential []byte  `gorm:"type:blob;index" json:"payment_credential"`
+	StakeCredential   []byte  `gorm:"type:blob;index" json:"stake_credential"`
+	Amount            uint64  `gorm:"index" json:"amount"`
+	Asset             []Asset `gorm:"foreignKey:UTxOID,UTxOIDIndex;references:UTxOID,UTxOIDIndex" json:"asset"`
+	// Datum is saved apart from the UTxO, as the datum is stored once per hash
+	Datum *UTxODatum `gorm:"foreignKey:UTxOID,UTxOIDIndex;references:UTxOID,UTxOIDIndex;<-:false" json:"datum"`
+	Cbor  []byte     `gorm:"type:blob" json:"cbor"`
+	// ReferenceScriptHash is the hash of the script reference carried by the output, if any
+	ReferenceScriptHash []byte `gorm:"type:blob;index" json:"reference_script_hash,omitempty"`
+	// CollateralReturnOf is set instead of TransactionHash on collateral return outputs
+	CollateralReturnOf []byte `gorm:"type:blob;index" json:"collateral_return_of,omitempty"`
+	// SlotNumber is the slot of the transaction that produced the output. It is not stored,
+	// and only loaded by the queries returning UTxOs apart from their transaction.
+	SlotNumber uint64 `gorm:"->;-:migration" json:"-"`
 }
 
 func (TransactionOutput) TableName() string {
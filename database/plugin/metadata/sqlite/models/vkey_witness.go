@@ -0,0 +1,16 @@
+package models
+
+// VkeyWitness is a verification key witness of a transaction. Bootstrap
+// (Byron) witnesses are stored here too, flagged by Bootstrap.
+type VkeyWitness struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	TransactionHash []byte `gorm:"index;type:blob" json:"transaction_hash"`
+	KeyHash         []byte `gorm:"index;type:blob" json:"key_hash"`
+	Vkey            []byte `gorm:"type:blob" json:"vkey"`
+	Signature       []byte `gorm:"type:blob" json:"signature"`
+	Bootstrap       bool   `json:"bootstrap"`
+}
+
+func (VkeyWitness) TableName() string {
+	return "vkey_witnesses"
+}
@@ -0,0 +1,16 @@
+package models
+
+// Withdrawal is a reward withdrawal made by a transaction.
+type Withdrawal struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	TransactionHash []byte `gorm:"index;type:blob" json:"transaction_hash"`
+	BlockNumber     uint64 `json:"block_number"`
+	SlotNumber      uint64 `gorm:"index" json:"slot_number"`
+	StakeAddress    string `json:"stake_address"`
+	StakeCredential []byte `gorm:"type:blob;index" json:"stake_credential"`
+	Amount          uint64 `json:"amount"`
+}
+
+func (Withdrawal) TableName() string {
+	return "withdrawals"
+}
@@ -12,7 +12,8 @@ type Witness struct {
 	PlutusV2Scripts types.ByteSliceSlice `gorm:"type:blob" json:"plutus_v2_scripts"`
 	PlutusV3Scripts types.ByteSliceSlice `gorm:"type:blob" json:"plutus_v3_scripts"`
 	Redeemers       []Redeemer           `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"redeemers"`
-	// NativeScripts []NativeScript
+	VkeyWitnesses   []VkeyWitness        `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"vkey_witnesses"`
+	NativeScripts   []NativeScript       `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"native_scripts"`
 }
 
 func (Witness) TableName() string {
@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// AddPolicy adds a policy ID to the watchlist.
+func (d *MetadataStoreSqlite) AddPolicy(txn *gorm.DB, policy *models.Policy) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	if policy == nil {
+		return errors.New("policy cannot be nil")
+	}
+	if policy.PolicyID == "" {
+		return errors.New("policy ID cannot be empty")
+	}
+	var count int64
+	if err := db.Model(&models.Policy{}).Where("policy_id = ?", policy.PolicyID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return models.ErrPolicyAlreadyWatched
+	}
+	return db.Create(policy).Error
+}
+
+// GetPolicies returns every watched policy ID.
+func (d *MetadataStoreSqlite) GetPolicies(txn *gorm.DB) ([]models.Policy, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var entries []models.Policy
+	result := db.Order("id").Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return entries, nil
+}
+
+// RemovePolicy removes a policy ID from the watchlist.
+func (d *MetadataStoreSqlite) RemovePolicy(txn *gorm.DB, policyID string) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	result := db.Where("policy_id = ?", policyID).Delete(&models.Policy{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
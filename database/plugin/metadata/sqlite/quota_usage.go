@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetQuotaUsage returns the number of rows served to the client on the given day.
+func (d *MetadataStoreSqlite) GetQuotaUsage(txn *gorm.DB, client string, day string) (int64, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var usage models.QuotaUsage
+	result := db.Where("client = ? AND day = ?", client, day).First(&usage)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, result.Error
+	}
+	return usage.Rows, nil
+}
+
+// AddQuotaUsage adds rows to the number of rows served to the client on the given day.
+func (d *MetadataStoreSqlite) AddQuotaUsage(txn *gorm.DB, client string, day string, rows int64) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	usage := models.QuotaUsage{
+		Client: client,
+		Day:    day,
+		Rows:   rows,
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "client"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]any{"rows": gorm.Expr("quota_usage.rows + ?", rows)}),
+	}).Create(&usage).Error
+}
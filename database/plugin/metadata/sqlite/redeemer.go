@@ -59,3 +59,30 @@ func (d *MetadataStoreSqlite) GetRedeemersByWitnessIdAndTag(txn *gorm.DB, witnes
 	}
 	return redeemers, nil
 }
+
+// GetRedeemers retrieves redeemers passed to the given script and/or with the
+// given purpose, newest first, with pagination support. Empty filters are ignored.
+func (d *MetadataStoreSqlite) GetRedeemers(txn *gorm.DB, scriptHash []byte, purpose string, limit, offset int) ([]models.Redeemer, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var redeemers []models.Redeemer
+	query := db.Order("id DESC")
+	if len(scriptHash) > 0 {
+		query = query.Where("script_hash = ?", scriptHash)
+	}
+	if purpose != "" {
+		query = query.Where("purpose = ?", purpose)
+	}
+
+	if limit > 0 || offset >= 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+
+	result := query.Find(&redeemers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return redeemers, nil
+}
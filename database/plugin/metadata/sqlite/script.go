@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// setScripts saves the scripts seen in a transaction, keeping the first
+// record of scripts already in the registry
+func (d *MetadataStoreSqlite) setScripts(txn *gorm.DB, scripts []models.Script) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	if len(scripts) == 0 {
+		return nil
+	}
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "script_hash"}},
+		DoNothing: true,
+	}).Create(&scripts)
+	return result.Error
+}
+
+// GetScriptByHash retrieves a script from the registry by its hash
+func (d *MetadataStoreSqlite) GetScriptByHash(txn *gorm.DB, scriptHash []byte) (*models.Script, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var script models.Script
+	result := db.Where("script_hash = ?", scriptHash).Limit(1).Find(&script)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &script, nil
+}
+
+// GetReferenceUTxOsByScriptHash retrieves the unspent outputs carrying the
+// script as a reference script, with pagination support
+func (d *MetadataStoreSqlite) GetReferenceUTxOsByScriptHash(txn *gorm.DB, scriptHash []byte, limit, offset int) ([]models.TransactionOutput, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	return getUnspentOutputsByColumn(db, "reference_script_hash", scriptHash, limit, offset)
+}
+
+// GetTxsByScriptHash retrieves transactions that executed the script, with
+// pagination support
+func (d *MetadataStoreSqlite) GetTxsByScriptHash(txn *gorm.DB, scriptHash []byte, limit, offset int) ([]models.Transaction, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	if len(scriptHash) == 0 {
+		return []models.Transaction{}, nil
+	}
+
+	executedTxHashes := db.Model(&models.TransactionScript{}).
+		Select("transaction_hash").
+		Where("script_hash = ?", scriptHash)
+
+	return findTxs(db.Where("transaction_hash IN (?)", executedTxHashes), limit, offset)
+}
@@ -0,0 +1,24 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// GetTxsBySigner retrieves transactions carrying a vkey or bootstrap witness
+// for the given key hash, with pagination support
+func (d *MetadataStoreSqlite) GetTxsBySigner(txn *gorm.DB, keyHash []byte, limit, offset int) ([]models.Transaction, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	if len(keyHash) == 0 {
+		return []models.Transaction{}, nil
+	}
+
+	signedTxHashes := db.Model(&models.VkeyWitness{}).
+		Select("transaction_hash").
+		Where("key_hash = ?", keyHash)
+
+	return findTxs(db.Where("transaction_hash IN (?)", signedTxHashes), limit, offset)
+}
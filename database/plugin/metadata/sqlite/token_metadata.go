@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// setTokenMetadata saves the token metadata set by a transaction, replacing the
+// metadata previously resolved for the same assets
+func (d *MetadataStoreSqlite) setTokenMetadata(txn *gorm.DB, tokenMetadata []models.TokenMetadata) error {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	if len(tokenMetadata) == 0 {
+		return nil
+	}
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "policy_id"}, {Name: "name_hex"}},
+		UpdateAll: true,
+	}).Create(&tokenMetadata)
+	return result.Error
+}
+
+// GetTokenMetadata retrieves the latest metadata resolved for an asset, keyed by
+// its policy ID and hex asset name
+func (d *MetadataStoreSqlite) GetTokenMetadata(txn *gorm.DB, policyId, nameHex []byte) (*models.TokenMetadata, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var tokenMetadata models.TokenMetadata
+	result := db.Where("policy_id = ? AND name_hex = ?", policyId, nameHex).Limit(1).Find(&tokenMetadata)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &tokenMetadata, nil
+}
+
+// GetAssetByFingerprint retrieves the policy ID and hex asset name of an asset
+// by its fingerprint, from its mints or else from the outputs holding it
+func (d *MetadataStoreSqlite) GetAssetByFingerprint(txn *gorm.DB, fingerprint []byte) ([]byte, []byte, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var mint models.Mint
+	result := db.Select("policy_id", "name_hex").Where("fingerprint = ?", fingerprint).Limit(1).Find(&mint)
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return mint.PolicyId, mint.NameHex, nil
+	}
+	var asset models.Asset
+	result = db.Select("policy_id", "name_hex").Where("fingerprint = ?", fingerprint).Limit(1).Find(&asset)
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil, nil
+	}
+	return asset.PolicyId, asset.NameHex, nil
+}
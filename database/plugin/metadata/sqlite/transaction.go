@@ -24,19 +24,33 @@ func (d *MetadataStoreSqlite) SetTx(txn *gorm.DB, tx *models.Transaction) error
 	// Basic validation for nested data could be added here if needed,
 	// but the individual setter functions should handle validation for their respective types.
 
-	// Save main transaction metadata
-	result := db.Save(tx)
+	// Save main transaction metadata. Assets are saved with the inputs and outputs, once per UTxO.
+	result := db.Omit("Inputs.Asset", "Outputs.Asset").Save(tx)
 	if result.Error != nil {
 		return result.Error
 	}
 
 	// Save nested data
+	if err := d.setScripts(txn, tx.Scripts); err != nil {
+		return err
+	}
+	if err := d.setTokenMetadata(txn, tx.TokenMetadata); err != nil {
+		return err
+	}
+	if err := d.setDatums(txn, tx.Datums); err != nil {
+		return err
+	}
 	if err := d.setInputs(txn, tx.Inputs, tx.TransactionHash); err != nil {
 		return err
 	}
 	if err := d.setOutputs(txn, tx.Outputs, tx.TransactionHash); err != nil {
 		return err
 	}
+	if tx.CollateralReturn != nil {
+		if err := d.setUTxODatum(txn, tx.CollateralReturn.Datum); err != nil {
+			return err
+		}
+	}
 	if err := d.setReferenceInputs(txn, tx.ReferenceInputs, tx.TransactionHash); err != nil {
 		return err
 	}
@@ -46,6 +60,14 @@ func (d *MetadataStoreSqlite) SetTx(txn *gorm.DB, tx *models.Transaction) error
 			return err
 		}
 	}
+	if err := d.setAddressStats(txn, tx); err != nil {
+		return err
+	}
+	if len(tx.BlockHash) > 0 {
+		if err := countBlockRelevantTxs(db, tx.BlockHash); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -58,10 +80,18 @@ func (d *MetadataStoreSqlite) setInputs(txn *gorm.DB, inputs []models.Transactio
 	}
 	for _, input := range inputs {
 		input.TransactionHash = txHash // Set foreign key
-		result := db.Save(&input)
+		result := db.Omit("Asset").Save(&input)
 		if result.Error != nil {
 			return result.Error
 		}
+		// The assets of an input are those of the output it spends, saved with the output
+		// when it was indexed
+		if err := d.setUTxOAssets(txn, input.UTxOID, input.UTxOIDIndex, input.Asset); err != nil {
+			return err
+		}
+		if err := d.setUTxODatum(txn, input.Datum); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -75,20 +105,16 @@ func (d *MetadataStoreSqlite) setOutputs(txn *gorm.DB, outputs []models.Transact
 	for _, output := range outputs {
 		output.TransactionHash = txHash // Set foreign key
 		// Save the TransactionOutput record
-		result := db.Save(&output)
+		result := db.Omit("Asset").Save(&output)
 		if result.Error != nil {
 			return result.Error
 		}
+		if err := d.setUTxODatum(txn, output.Datum); err != nil {
+			return err
+		}
 		// Save nested assets within the output
-		for _, asset := range output.Asset {
-			// The relationship between Asset and TransactionOutput is via UTxOID and UTxOIDIndex
-			// Ensure these fields are set correctly on the asset before saving
-			asset.UTxOID = output.UTxOID
-			asset.UTxOIDIndex = output.UTxOIDIndex
-			d.logger.Debug(fmt.Sprintf("setOutputs: Saving Asset - Output UTxOID=%x, Output UTxOIDIndex=%d, Asset UTxOID=%x, Asset UTxOIDIndex=%d, PolicyId=%x, Name=%x", output.UTxOID, output.UTxOIDIndex, asset.UTxOID, asset.UTxOIDIndex, asset.PolicyId, asset.Name))
-			if err := d.SetAsset(txn, &asset); err != nil { // Assuming SetAsset takes a single asset and handles its saving
-				return err
-			}
+		if err := d.setUTxOAssets(txn, output.UTxOID, output.UTxOIDIndex, output.Asset); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -106,6 +132,14 @@ func (d *MetadataStoreSqlite) setReferenceInputs(txn *gorm.DB, refInputs []model
 		if result.Error != nil {
 			return result.Error
 		}
+		// The assets and datum of a reference input resolved through Kupo are linked to the
+		// UTxO it reads, like those of a spent input
+		if err := d.setUTxOAssets(txn, refInput.UTxOID, refInput.UTxOIDIndex, refInput.Asset); err != nil {
+			return err
+		}
+		if err := d.setUTxODatum(txn, refInput.Datum); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -143,23 +177,14 @@ func (d *MetadataStoreSqlite) GetTxByTxHash(txn *gorm.DB, txHash []byte) (*model
 		db = d.db
 	}
 	var transaction models.Transaction
-	result := db.Where("transaction_hash = ?", txHash).
-		Preload("Inputs").
-		Preload("Inputs.Asset").
-		Preload("Inputs.Datum").
-		Preload("Outputs").
-		Preload("Outputs.Asset").
-		Preload("Outputs.Datum").
-		Preload("ReferenceInputs").
-		Preload("Witness").
-		Preload("Witness.Redeemers").
-		First(&transaction)
+	result := preloadTxs(db.Where("transaction_hash = ?", txHash)).First(&transaction)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil // Return nil transaction and nil error if not found
 		}
 		return nil, result.Error
 	}
+	setOutputSlots(&transaction)
 	return &transaction, nil
 }
 
@@ -170,84 +195,35 @@ func (d *MetadataStoreSqlite) GetTxByID(txn *gorm.DB, id uint) (*models.Transact
 		db = d.db
 	}
 	var transaction models.Transaction
-	result := db.
-		Preload("Inputs").
-		Preload("Inputs.Asset").
-		Preload("Inputs.Datum").
-		Preload("Outputs").
-		Preload("Outputs.Asset").
-		Preload("Outputs.Datum").
-		Preload("ReferenceInputs").
-		Preload("Witness").
-		Preload("Witness.Redeemers").
-		First(&transaction, id) // Find by primary key
+	result := preloadTxs(db).First(&transaction, id) // Find by primary key
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil // Return nil transaction and nil error if not found
 		}
 		return nil, result.Error
 	}
+	setOutputSlots(&transaction)
 	return &transaction, nil
 }
 
-// GetTxsByBlockNumber retrieves all transactions for a given block number with pagination support
+// GetTxsByBlockNumber retrieves all transactions for a given block number, in block order,
+// with pagination support
 func (d *MetadataStoreSqlite) GetTxsByBlockNumber(txn *gorm.DB, blockNumber uint64, limit, offset int) ([]models.Transaction, error) {
 	db := txn
 	if db == nil {
 		db = d.db
 	}
-	var transactions []models.Transaction
-	query := db.Where("block_number = ?", blockNumber)
-
-	if limit > 0 || offset >= 0 {
-		query = query.Limit(limit).Offset(offset)
-	}
-
-	result := query.
-		Preload("Inputs").
-		Preload("Inputs.Asset").
-		Preload("Inputs.Datum").
-		Preload("Outputs").
-		Preload("Outputs.Asset").
-		Preload("Outputs.Datum").
-		Preload("ReferenceInputs").
-		Preload("Witness").
-		Preload("Witness.Redeemers").
-		Find(&transactions)
-	if result.Error != nil {
-		return nil, result.Error
-	}
-	return transactions, nil
+	return findTxsInOrder(db.Where("block_number = ?", blockNumber), "id", limit, offset)
 }
 
-// GetTxsBySlotRange retrieves all transactions for a given slot range with pagination support
+// GetTxsBySlotRange retrieves all transactions for a given slot range, oldest first, with
+// pagination support
 func (d *MetadataStoreSqlite) GetTxsBySlotRange(txn *gorm.DB, startSlot, endSlot uint64, limit, offset int) ([]models.Transaction, error) {
 	db := txn
 	if db == nil {
 		db = d.db
 	}
-	var transactions []models.Transaction
-	query := db.Where("slot_number BETWEEN ? AND ?", startSlot, endSlot)
-
-	if limit > 0 || offset >= 0 {
-		query = query.Limit(limit).Offset(offset)
-	}
-
-	result := query.
-		Preload("Inputs").
-		Preload("Inputs.Asset").
-		Preload("Inputs.Datum").
-		Preload("Outputs").
-		Preload("Outputs.Asset").
-		Preload("Outputs.Datum").
-		Preload("ReferenceInputs").
-		Preload("Witness").
-		Preload("Witness.Redeemers").
-		Find(&transactions)
-	if result.Error != nil {
-		return nil, result.Error
-	}
-	return transactions, nil
+	return findTxsInOrder(db.Where("slot_number BETWEEN ? AND ?", startSlot, endSlot), "slot_number, id", limit, offset)
 }
 
 // GetTxsByOutputAddress retrieves transactions where the given address appears in the outputs with pagination support.
@@ -275,48 +251,40 @@ func (d *MetadataStoreSqlite) GetTxsByOutputAddress(txn *gorm.DB, address string
 	}
 
 	// Retrieve the Transaction records using these transaction hashes with pagination
-	query := db.Where("transaction_hash IN (?)", outputTxHashes)
-
-	if limit > 0 || offset >= 0 {
-		query = query.Limit(limit).Offset(offset)
-	}
-
-	result = query.
-		Preload("Inputs").
-		Preload("Inputs.Asset").
-		Preload("Inputs.Datum").
-		Preload("Outputs").
-		Preload("Outputs.Asset").
-		Preload("Outputs.Datum").
-		Preload("ReferenceInputs").
-		Preload("Witness").
-		Preload("Witness.Redeemers").
-		Find(&transactions)
-	if result.Error != nil {
-		return nil, result.Error
-	}
-
-	return transactions, nil
+	return findTxsInOrder(db.Where("transaction_hash IN (?)", outputTxHashes), "slot_number, id", limit, offset)
 }
 
 // GetTxsByAnyAddress retrieves transactions where the given address appears in either inputs or outputs with pagination support.
 // This function queries both the transaction_inputs and transaction_outputs tables
 // to find transactions associated with the given address.
 func (d *MetadataStoreSqlite) GetTxsByAnyAddress(txn *gorm.DB, address string, limit, offset int) ([]models.Transaction, error) {
+	return d.GetTxsByAnyAddresses(txn, []string{address}, limit, offset)
+}
+
+// GetTxsByAnyAddresses retrieves transactions where any of the given addresses appears in either inputs or outputs with pagination support.
+func (d *MetadataStoreSqlite) GetTxsByAnyAddresses(txn *gorm.DB, addresses []string, limit, offset int) ([]models.Transaction, error) {
 	db := txn
 	if db == nil {
 		db = d.db
 	}
 	var transactions []models.Transaction
 	uniqueTxHashes := make(map[string]bool)
-	d.logger.Debug(fmt.Sprintf("GetTxsByAnyAddress: querying for address: %s", address))
+	d.logger.Debug(fmt.Sprintf("GetTxsByAnyAddresses: querying for addresses: %v", addresses))
+
+	addressBytes := make([][]byte, len(addresses))
+	for i, address := range addresses {
+		addressBytes[i] = []byte(address)
+	}
+	if len(addressBytes) == 0 {
+		return transactions, nil
+	}
 
 	// Get transaction hashes from inputs
 	var inputTxHashes [][]byte
 	result := db.Model(&models.TransactionInput{}).
 		Select("transaction_inputs.transaction_hash").
 		// Where("transaction_inputs.address = ?", decodedAddress.Bytes()).
-		Where("transaction_inputs.address = ?", []byte(address)).
+		Where("transaction_inputs.address IN ?", addressBytes).
 		Find(&inputTxHashes)
 	if result.Error != nil {
 		return nil, result.Error
@@ -330,7 +298,7 @@ func (d *MetadataStoreSqlite) GetTxsByAnyAddress(txn *gorm.DB, address string, l
 	result = db.Model(&models.TransactionOutput{}).
 		Select("transaction_hash").
 		// Where("address = ?", decodedAddress.Bytes()).
-		Where("address = ?", []byte(address)).
+		Where("address IN ?", addressBytes).
 		Find(&outputTxHashes)
 	if result.Error != nil {
 		return nil, result.Error
@@ -344,34 +312,14 @@ func (d *MetadataStoreSqlite) GetTxsByAnyAddress(txn *gorm.DB, address string, l
 	for hashStr := range uniqueTxHashes {
 		finalTxHashes = append(finalTxHashes, []byte(hashStr))
 	}
-	d.logger.Debug(fmt.Sprintf("GetTxsByAnyAddress: found %d unique transaction hashes", len(finalTxHashes)))
+	d.logger.Debug(fmt.Sprintf("GetTxsByAnyAddresses: found %d unique transaction hashes", len(finalTxHashes)))
 
 	if len(finalTxHashes) == 0 {
-		return transactions, nil // No transactions found for this address
+		return transactions, nil // No transactions found for these addresses
 	}
 
 	// Retrieve the Transaction records with pagination
-	query := db.Where("transaction_hash IN (?)", finalTxHashes)
-
-	if limit > 0 || offset >= 0 {
-		query = query.Limit(limit).Offset(offset)
-	}
-
-	result = query.
-		Preload("Inputs").
-		Preload("Inputs.Asset").
-		Preload("Inputs.Datum").
-		Preload("Outputs").
-		Preload("Outputs.Asset").
-		Preload("Outputs.Datum").
-		Preload("ReferenceInputs").
-		Preload("Witness").
-		Preload("Witness.Redeemers").
-		Find(&transactions)
-		if result.Error != nil {
-			return nil, result.Error
-		}
-		return transactions, nil
+	return findTxsInOrder(db.Where("transaction_hash IN (?)", finalTxHashes), "slot_number, id", limit, offset)
 }
 
 // SetTxs inserts or updates a batch of transaction records.
@@ -402,28 +350,13 @@ func (d *MetadataStoreSqlite) SetTxs(txn *gorm.DB, txs []*models.Transaction) er
 	return nil
 }
 
-// GetTxs retrieves a list of transactions with pagination
+// GetTxs retrieves a list of transactions, oldest first, with pagination
 func (d *MetadataStoreSqlite) GetTxs(txn *gorm.DB, limit, offset int) ([]models.Transaction, error) {
 	db := txn
 	if db == nil {
 		db = d.db
 	}
-	var transactions []models.Transaction
-	result := db.Limit(limit).Offset(offset).
-		Preload("Inputs").
-		Preload("Inputs.Asset").
-		Preload("Inputs.Datum").
-		Preload("Outputs").
-		Preload("Outputs.Asset").
-		Preload("Outputs.Datum").
-		Preload("ReferenceInputs").
-		Preload("Witness").
-		Preload("Witness.Redeemers").
-		Find(&transactions)
-	if result.Error != nil {
-		return nil, result.Error
-	}
-	return transactions, nil
+	return findTxsInOrder(db, "slot_number, id", limit, offset)
 }
 
 // CountTxs gets the total count of transaction records
@@ -440,31 +373,41 @@ func (d *MetadataStoreSqlite) CountTxs(txn *gorm.DB) (int64, error) {
 	return count, nil
 }
 
-// DeleteTxByHash deletes a single transaction by its hash
+// DeleteTxByHash deletes a single transaction by its hash and takes it out of the stats
+// of the addresses it involved
 func (d *MetadataStoreSqlite) DeleteTxByHash(txn *gorm.DB, txHash []byte) error {
 	db := txn
 	if db == nil {
 		db = d.db
 	}
-	result := db.Where("transaction_hash = ?", txHash).Delete(&models.Transaction{})
-	if result.Error != nil {
-		return result.Error
-	}
-	return nil
+	return db.Transaction(func(db *gorm.DB) error {
+		result := db.Where("transaction_hash = ?", txHash).Delete(&models.Transaction{})
+		if result.Error != nil {
+			return result.Error
+		}
+		return d.removeAddressStats(db, [][]byte{txHash})
+	})
 }
 
-// DeleteTxsByBlockNumber deletes all transactions for a given block number
+// DeleteTxsByBlockNumber deletes all transactions for a given block number and takes them
+// out of the stats of the addresses they involved
 func (d *MetadataStoreSqlite) DeleteTxsByBlockNumber(txn *gorm.DB, blockNumber uint64) error {
 	db := txn
 	if db == nil {
 		db = d.db
 	}
-	result := db.Where("block_number = ?", blockNumber).Delete(&models.Transaction{})
-	if result.Error != nil {
-		return result.Error
-	}
-
-	return nil
+	return db.Transaction(func(db *gorm.DB) error {
+		var txHashes [][]byte
+		result := db.Model(&models.Transaction{}).Where("block_number = ?", blockNumber).Pluck("transaction_hash", &txHashes)
+		if result.Error != nil {
+			return result.Error
+		}
+		result = db.Where("block_number = ?", blockNumber).Delete(&models.Transaction{})
+		if result.Error != nil {
+			return result.Error
+		}
+		return d.removeAddressStats(db, txHashes)
+	})
 }
 
 // GetTxsByInputAddress retrieves transaction inputs where the given address appears in the inputs with pagination support.
@@ -484,7 +427,7 @@ func (d *MetadataStoreSqlite) GetTxsByInputAddress(txn *gorm.DB, address string,
 
 	result := query.
 		Preload("Asset").
-		Preload("Datum").
+		Preload("Datum.Datum").
 		Find(&transactionInputs)
 
 	if result.Error != nil {
@@ -37,7 +37,10 @@ func (d *MetadataStoreSqlite) GetTxOutputByUTxO(txn *gorm.DB, utxoID []byte, utx
 		db = d.db
 	}
 	var output models.TransactionOutput
-	result := db.Where("utxo_id = ? AND utxo_index = ?", utxoID, utxoIndex).First(&output)
+	result := db.Where("utxo_id = ? AND utxo_index = ?", utxoID, utxoIndex).
+		Preload("Asset").
+		Preload("Datum.Datum").
+		First(&output)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, nil // Return nil TransactionOutput and nil error if not found
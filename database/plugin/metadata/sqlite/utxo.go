@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"gorm.io/gorm"
+)
+
+// GetTxsReferencingUTxO retrieves transactions that read a UTxO as a reference
+// input, with pagination support
+func (d *MetadataStoreSqlite) GetTxsReferencingUTxO(txn *gorm.DB, utxoID []byte, utxoIndex uint32, limit, offset int) ([]models.Transaction, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+
+	referencingTxHashes := db.Model(&models.SimpleUTxO{}).
+		Select("transaction_hash").
+		Where("utxo_id = ? AND utxo_index = ?", utxoID, utxoIndex)
+
+	return findTxs(db.Where("transaction_hash IN (?)", referencingTxHashes), limit, offset)
+}
+
+// GetProducedUTxO retrieves an indexed output that exists as a UTxO, with its assets and
+// datum. It returns nil for outputs of transactions that failed phase-2 validation, which
+// never produced them.
+func (d *MetadataStoreSqlite) GetProducedUTxO(txn *gorm.DB, utxoID []byte, utxoIndex uint32) (*models.TransactionOutput, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var output models.TransactionOutput
+	result := db.Where("utxo_id = ? AND utxo_index = ?", utxoID, utxoIndex).
+		Where(utxoProducedCondition).
+		Preload("Asset").
+		Preload("Datum.Datum").
+		Limit(1).
+		Find(&output)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &output, nil
+}
+
+// GetSpendingTxHash retrieves the hash of the indexed transaction that consumed a
+// UTxO, as an input of a valid transaction or collateral of an invalid one. It
+// returns nil when the UTxO is unspent.
+func (d *MetadataStoreSqlite) GetSpendingTxHash(txn *gorm.DB, utxoID []byte, utxoIndex uint32) ([]byte, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var txHashes [][]byte
+	result := db.Raw(
+		"SELECT transaction_inputs.transaction_hash FROM transaction_inputs "+
+			"JOIN transactions ON transactions.transaction_hash = transaction_inputs.transaction_hash "+
+			"WHERE transaction_inputs.utxo_id = ? AND transaction_inputs.utxo_index = ? AND transactions.is_valid "+
+			"UNION ALL SELECT collateral_inputs.transaction_hash FROM collateral_inputs "+
+			"JOIN transactions ON transactions.transaction_hash = collateral_inputs.transaction_hash "+
+			"WHERE collateral_inputs.utxo_id = ? AND collateral_inputs.utxo_index = ? AND NOT transactions.is_valid "+
+			"LIMIT 1",
+		utxoID, utxoIndex, utxoID, utxoIndex,
+	).Scan(&txHashes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(txHashes) == 0 {
+		return nil, nil
+	}
+	return txHashes[0], nil
+}
+
+// GetTxWithUTxOs retrieves a transaction with only the UTxOs it consumed and
+// produced: its inputs, outputs, collateral and collateral return
+func (d *MetadataStoreSqlite) GetTxWithUTxOs(txn *gorm.DB, txHash []byte) (*models.Transaction, error) {
+	db := txn
+	if db == nil {
+		db = d.db
+	}
+	var transaction models.Transaction
+	result := db.Where("transaction_hash = ?", txHash).
+		Preload("Inputs").
+		Preload("Outputs").
+		Preload("Collateral").
+		Preload("CollateralReturn").
+		Limit(1).
+		Find(&transaction)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	setOutputSlots(&transaction)
+	return &transaction, nil
+}
@@ -36,10 +36,40 @@ type MetadataStore interface {
 	Where(txn *gorm.DB, query interface{}, args ...interface{}) *gorm.DB
 
 	// Address
-	AddAddress(txn *gorm.DB, address string) error
+	AddAddress(txn *gorm.DB, address *models.Address) error
 	GetAddress(txn *gorm.DB, address string) (string, error)
+	GetAddressEntry(txn *gorm.DB, address string) (*models.Address, error)
+	GetAddressEntries(txn *gorm.DB, tag, label, source string, limit, offset int) ([]models.Address, error)
+	GetAddressStartSlots(txn *gorm.DB) (map[string]uint64, error)
+	GetAddressesByTag(txn *gorm.DB, tag string) ([]string, error)
+	GetAddressTags(txn *gorm.DB) ([]models.AddressTagCount, error)
 	GetAllAddresses(txn *gorm.DB) ([]string, error)
 	RemoveAddress(txn *gorm.DB, address string) error
+	RemoveAddresses(txn *gorm.DB, addresses []string) (int64, error)
+
+	// Policy
+	AddPolicy(txn *gorm.DB, policy *models.Policy) error
+	GetPolicies(txn *gorm.DB) ([]models.Policy, error)
+	RemovePolicy(txn *gorm.DB, policyID string) error
+
+	// Fingerprint
+	AddFingerprint(txn *gorm.DB, fingerprint *models.Fingerprint) error
+	GetFingerprints(txn *gorm.DB) ([]models.Fingerprint, error)
+	RemoveFingerprint(txn *gorm.DB, fingerprint string) error
+
+	// API keys
+	AddAPIKey(txn *gorm.DB, apiKey *models.APIKey) error
+	GetAPIKeyByHash(txn *gorm.DB, keyHash []byte) (*models.APIKey, error)
+	GetAllAPIKeys(txn *gorm.DB) ([]models.APIKey, error)
+	RevokeAPIKey(txn *gorm.DB, id uint) error
+
+	// Audit log
+	AddAuditLog(txn *gorm.DB, entry *models.AuditLog) error
+	GetAuditLogs(txn *gorm.DB, apiKeyID uint, limit, offset int) ([]models.AuditLog, error)
+
+	// Quota usage
+	GetQuotaUsage(txn *gorm.DB, client string, day string) (int64, error)
+	AddQuotaUsage(txn *gorm.DB, client string, day string, rows int64) error
 
 	// Transaction
 	SetTx(txn *gorm.DB, tx *models.Transaction) error
@@ -49,6 +79,7 @@ type MetadataStore interface {
 	GetTxsByInputAddress(txn *gorm.DB, address string, limit, offset int) ([]models.TransactionInput, error)
 	GetTxsByOutputAddress(txn *gorm.DB, address string, limit, offset int) ([]models.Transaction, error)
 	GetTxsByAnyAddress(txn *gorm.DB, address string, limit, offset int) ([]models.Transaction, error)
+	GetTxsByAnyAddresses(txn *gorm.DB, addresses []string, limit, offset int) ([]models.Transaction, error)
 	SetTxs(txn *gorm.DB, txs []*models.Transaction) error
 	GetTxs(txn *gorm.DB, limit, offset int) ([]models.Transaction, error)
 	CountTxs(txn *gorm.DB) (int64, error)
@@ -67,14 +98,15 @@ type MetadataStore interface {
 	SetAsset(txn *gorm.DB, asset *models.Asset) error
 	CountUniqueAssets(txn *gorm.DB) (int64, error)
 
-	GetDatum(txn *gorm.DB, utxoID []byte, utxoIndex uint32) (*models.Datum, error)
-	GetDatumByHash(txn *gorm.DB, arg1 []byte) (*models.Datum, error)
+	GetDatum(txn *gorm.DB, utxoID []byte, utxoIndex uint32) (*models.UTxODatum, error)
+	GetDatumByHash(txn *gorm.DB, datumHash []byte) (*models.Datum, error)
 	SetDatum(txn *gorm.DB, datum *models.Datum) error
 
 	SetRedeemer(txn *gorm.DB, redeemer *models.Redeemer) error
 	GetRedeemersByWitnessId(txn *gorm.DB, witnessID uint) ([]models.Redeemer, error)
 	GetRedeemersByWitnessIdAndIndexAndTag(txn *gorm.DB, witnessID uint, index uint, tag []byte) (*models.Redeemer, error)
 	GetRedeemersByWitnessIdAndTag(txn *gorm.DB, witnessID uint, tag []byte) ([]models.Redeemer, error)
+	GetRedeemers(txn *gorm.DB, scriptHash []byte, purpose string, limit, offset int) ([]models.Redeemer, error)
 
 	SetSimpleUTxO(txn *gorm.DB, utxo *models.SimpleUTxO) error
 	GetSimpleUTxOByUTxO(txn *gorm.DB, utxoID []byte, utxoIndex uint32) (*models.SimpleUTxO, error)
@@ -104,6 +136,64 @@ type MetadataStore interface {
 	GetUTxOsByAssetFingerprint(txn *gorm.DB, assetFingerprint []byte, limit, offset int) ([]models.SimpleUTxO, error)
 	GetTransactionInputsByAssetFingerprint(txn *gorm.DB, assetFingerprint []byte, limit, offset int) ([]models.TransactionInput, error)
 	GetTransactionOutputsByAssetFingerprint(txn *gorm.DB, assetFingerprint []byte, limit, offset int) ([]models.TransactionOutput, error)
+	GetAssetHistoryTxs(txn *gorm.DB, assetFingerprint []byte, limit, offset int) ([]models.Transaction, error)
+	GetPolicyAssets(txn *gorm.DB, policyId []byte, limit, offset int) ([]models.PolicyAsset, error)
+	GetAssetHolders(txn *gorm.DB, assetFingerprint []byte, limit, offset int) ([]models.AssetHolder, error)
+
+	// Account (stake credential) queries
+	GetTxsByStakeCredential(txn *gorm.DB, stakeCredential []byte, limit, offset int) ([]models.Transaction, error)
+	GetUnspentOutputsByStakeCredential(txn *gorm.DB, stakeCredential []byte, limit, offset int) ([]models.TransactionOutput, error)
+	GetWithdrawalsByStakeCredential(txn *gorm.DB, stakeCredential []byte, limit, offset int) ([]models.Withdrawal, error)
+
+	// Payment credential (key hash or script hash) queries
+	GetTxsByPaymentCredential(txn *gorm.DB, paymentCredential []byte, limit, offset int) ([]models.Transaction, error)
+	GetUnspentOutputsByPaymentCredential(txn *gorm.DB, paymentCredential []byte, limit, offset int) ([]models.TransactionOutput, error)
+
+	// Signer (vkey witness) queries
+	GetTxsBySigner(txn *gorm.DB, keyHash []byte, limit, offset int) ([]models.Transaction, error)
+
+	// Script registry queries
+	GetScriptByHash(txn *gorm.DB, scriptHash []byte) (*models.Script, error)
+	GetReferenceUTxOsByScriptHash(txn *gorm.DB, scriptHash []byte, limit, offset int) ([]models.TransactionOutput, error)
+	GetTxsByScriptHash(txn *gorm.DB, scriptHash []byte, limit, offset int) ([]models.Transaction, error)
+
+	// Governance queries
+	GetGovernanceProposals(txn *gorm.DB, rewardCredential []byte, limit, offset int) ([]models.GovernanceProposal, error)
+	GetGovernanceVotes(txn *gorm.DB, voterHash []byte, actionTxHash []byte, actionIndex uint32, limit, offset int) ([]models.GovernanceVote, error)
+
+	// Certificate queries
+	GetCertificates(txn *gorm.DB, stakeCredential []byte, poolKeyHash []byte, drepCredential []byte, certTypes []string, limit, offset int) ([]models.Certificate, error)
+
+	// Metadata label queries
+	GetTxsByMetadataLabel(txn *gorm.DB, label uint64, limit, offset int) ([]models.Transaction, error)
+
+	// UTxO queries
+	GetTxsReferencingUTxO(txn *gorm.DB, utxoID []byte, utxoIndex uint32, limit, offset int) ([]models.Transaction, error)
+	GetProducedUTxO(txn *gorm.DB, utxoID []byte, utxoIndex uint32) (*models.TransactionOutput, error)
+	GetSpendingTxHash(txn *gorm.DB, utxoID []byte, utxoIndex uint32) ([]byte, error)
+	GetTxWithUTxOs(txn *gorm.DB, txHash []byte) (*models.Transaction, error)
+
+	// Balance queries
+	GetAddressLovelaceAtSlot(txn *gorm.DB, address []byte, slot uint64) (uint64, int64, error)
+	GetAddressAssetsAtSlot(txn *gorm.DB, address []byte, slot uint64) ([]models.AssetBalance, error)
+	GetAddressBalanceDeltas(txn *gorm.DB, address []byte) ([]models.BalanceDelta, error)
+
+	// Block queries
+	SetBlock(txn *gorm.DB, block *models.Block) error
+	GetBlockByHash(txn *gorm.DB, blockHash []byte) (*models.Block, error)
+	GetBlockByNumber(txn *gorm.DB, blockNumber uint64) (*models.Block, error)
+	GetLatestBlock(txn *gorm.DB) (*models.Block, error)
+
+	// Address stats queries
+	GetAddressStats(txn *gorm.DB, address []byte) (*models.AddressStats, error)
+
+	// Token metadata queries
+	GetTokenMetadata(txn *gorm.DB, policyId, nameHex []byte) (*models.TokenMetadata, error)
+	GetAssetByFingerprint(txn *gorm.DB, fingerprint []byte) ([]byte, []byte, error)
+
+	// Mint and burn queries
+	GetMintsByPolicyId(txn *gorm.DB, policyId []byte, limit, offset int) ([]models.Mint, error)
+	GetAssetSupplyByPolicyId(txn *gorm.DB, policyId []byte) ([]models.AssetSupply, error)
 }
 
 // For now, this always returns a sqlite plugin
@@ -0,0 +1,35 @@
+package database
+
+// GetWatchedPolicyIDs returns all watched policy IDs from the database
+func (d *Database) GetWatchedPolicyIDs() ([]string, error) {
+	txn := d.Transaction(false)
+	defer txn.Discard()
+
+	policies, err := d.metadata.GetPolicies(txn.Metadata())
+	if err != nil {
+		return nil, err
+	}
+
+	policyIDs := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		policyIDs = append(policyIDs, policy.PolicyID)
+	}
+	return policyIDs, nil
+}
+
+// GetWatchedFingerprints returns all watched asset fingerprints from the database
+func (d *Database) GetWatchedFingerprints() ([]string, error) {
+	txn := d.Transaction(false)
+	defer txn.Discard()
+
+	entries, err := d.metadata.GetFingerprints(txn.Metadata())
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprints := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		fingerprints = append(fingerprints, entry.Fingerprint)
+	}
+	return fingerprints, nil
+}
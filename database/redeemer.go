@@ -0,0 +1,14 @@
+package database
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+)
+
+// GetRedeemers retrieves redeemers by script hash and/or purpose, with pagination support
+func (d *Database) GetRedeemers(scriptHash []byte, purpose string, limit, offset int, txn *Txn) ([]models.Redeemer, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	return d.metadata.GetRedeemers(txn.Metadata(), scriptHash, purpose, limit, offset)
+}
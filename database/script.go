@@ -0,0 +1,36 @@
+package database
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+)
+
+// GetScriptByHash retrieves a script from the script registry by its hash
+func (d *Database) GetScriptByHash(scriptHash []byte, txn *Txn) (*models.Script, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	return d.metadata.GetScriptByHash(txn.Metadata(), scriptHash)
+}
+
+// GetReferenceUTxOsByScriptHash retrieves the unspent outputs carrying the script as a reference script, with pagination support
+func (d *Database) GetReferenceUTxOsByScriptHash(scriptHash []byte, limit, offset int, txn *Txn) ([]models.TransactionOutput, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	return d.metadata.GetReferenceUTxOsByScriptHash(txn.Metadata(), scriptHash, limit, offset)
+}
+
+// GetTxsByScriptHash retrieves transactions that executed the script, with pagination support
+func (d *Database) GetTxsByScriptHash(scriptHash []byte, limit, offset int, txn *Txn) ([]Transaction, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	modelsTxs, err := d.metadata.GetTxsByScriptHash(txn.Metadata(), scriptHash, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return d.loadTransactions(modelsTxs, txn), nil
+}
@@ -0,0 +1,14 @@
+package database
+
+// GetTxsBySigner retrieves transactions witnessed by the given verification key hash, with pagination support
+func (d *Database) GetTxsBySigner(keyHash []byte, limit, offset int, txn *Txn) ([]Transaction, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	modelsTxs, err := d.metadata.GetTxsBySigner(txn.Metadata(), keyHash, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return d.loadTransactions(modelsTxs, txn), nil
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"encoding/hex"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/txmetadata"
+)
+
+// AssetTokenMetadata is the token metadata resolved for an asset
+type AssetTokenMetadata struct {
+	PolicyId []byte
+	NameHex  []byte
+	// Label is the CIP-67 label of the asset name, nil when it has none
+	Label *uint16
+	// Metadata is nil when no metadata was resolved for the asset
+	Metadata *models.TokenMetadata
+}
+
+// GetTokenMetadataByFingerprint resolves the token metadata of an asset by its fingerprint.
+// CIP-68 user tokens (labels 222, 333 and 444) resolve to the datum metadata of their
+// reference token; other assets resolve to their CIP-25 metadata. It returns nil when the
+// asset has not been indexed.
+func (d *Database) GetTokenMetadataByFingerprint(fingerprint []byte, txn *Txn) (*AssetTokenMetadata, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	policyId, nameHex, err := d.metadata.GetAssetByFingerprint(txn.Metadata(), fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	if policyId == nil {
+		return nil, nil
+	}
+	asset := &AssetTokenMetadata{
+		PolicyId: policyId,
+		NameHex:  nameHex,
+	}
+
+	metadataNameHex := nameHex
+	assetName, err := hex.DecodeString(string(nameHex))
+	if err != nil {
+		d.Logger().Warn("failed to decode asset name", "nameHex", string(nameHex), "error", err)
+	}
+	if label, rest, ok := txmetadata.CIP67Label(assetName); ok {
+		asset.Label = &label
+		switch label {
+		case txmetadata.CIP68NFTLabel, txmetadata.CIP68FTLabel, txmetadata.CIP68RFTLabel:
+			referenceName := append(txmetadata.CIP67Prefix(txmetadata.CIP68ReferenceTokenLabel), rest...)
+			metadataNameHex = []byte(hex.EncodeToString(referenceName))
+		}
+	}
+	asset.Metadata, err = d.metadata.GetTokenMetadata(txn.Metadata(), policyId, metadataNameHex)
+	if err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
@@ -21,6 +21,9 @@ import (
 
 	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
 	"github.com/Andamio-Platform/andamio-indexer/database/types"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/internal/txmetadata"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
 	"github.com/dgraph-io/badger/v4"
 )
 
@@ -40,6 +43,41 @@ type Transaction struct {
 	Witness         models.Witness             `gorm:"foreignKey:TransactionHash;references:TransactionHash" json:"witness"`
 	Certificates    types.ByteSliceSlice       `gorm:"type:blob" json:"certificate"`
 	TransactionCBOR []byte                     `gorm:"type:blob" json:"transaction_cbor"`
+
+	ValidityIntervalStart uint64                      `json:"validity_interval_start"`
+	Collateral            []models.CollateralInput    `json:"collateral"`
+	CollateralReturn      *models.TransactionOutput   `json:"collateral_return"`
+	TotalCollateral       uint64                      `json:"total_collateral"`
+	RequiredSigners       types.ByteSliceSlice        `json:"required_signers"`
+	NetworkId             *uint8                      `json:"network_id"`
+	ScriptDataHash        []byte                      `json:"script_data_hash"`
+	IsValid               bool                        `json:"is_valid"`
+	ExecutedScripts       []models.TransactionScript  `json:"executed_scripts"`
+	Proposals             []models.GovernanceProposal `json:"proposals"`
+	Votes                 []models.GovernanceVote     `json:"votes"`
+	CurrentTreasuryValue  int64                       `json:"current_treasury_value"`
+	TreasuryDonation      uint64                      `json:"treasury_donation"`
+	// DecodedCertificates are the certificates decoded, in body order
+	DecodedCertificates []models.Certificate `json:"decoded_certificates"`
+}
+
+// TxBody holds the transaction body fields stored alongside the inputs, outputs, fee,
+// TTL and withdrawals.
+type TxBody struct {
+	ValidityIntervalStart uint64
+	Collateral            []models.CollateralInput
+	CollateralReturn      *models.TransactionOutput
+	TotalCollateral       uint64
+	RequiredSigners       [][]byte
+	NetworkId             *uint8
+	ScriptDataHash        []byte
+	// IsValid is the phase-2 validity flag
+	IsValid bool
+	// Conway governance fields
+	Proposals            []models.GovernanceProposal
+	Votes                []models.GovernanceVote
+	CurrentTreasuryValue int64
+	TreasuryDonation     uint64
 }
 
 // TableName overrides the table name
@@ -47,6 +85,42 @@ func (Transaction) TableName() string {
 	return "transactions"
 }
 
+// newTransaction converts a transaction model into a database transaction. The CBOR
+// is loaded separately from the blob store.
+func newTransaction(modelTx models.Transaction) Transaction {
+	return Transaction{
+		ID:              modelTx.ID,
+		BlockHash:       modelTx.BlockHash,
+		BlockNumber:     modelTx.BlockNumber,
+		SlotNumber:      modelTx.SlotNumber,
+		TransactionHash: modelTx.TransactionHash,
+		Inputs:          modelTx.Inputs,
+		Outputs:         modelTx.Outputs,
+		ReferenceInputs: modelTx.ReferenceInputs,
+		Metadata:        modelTx.Metadata,
+		Fee:             modelTx.Fee,
+		TTL:             modelTx.TTL,
+		Withdrawals:     modelTx.Withdrawals,
+		Witness:         modelTx.Witness,
+		Certificates:    modelTx.Certificates,
+
+		ValidityIntervalStart: modelTx.ValidityIntervalStart,
+		Collateral:            modelTx.Collateral,
+		CollateralReturn:      modelTx.CollateralReturn,
+		TotalCollateral:       modelTx.TotalCollateral,
+		RequiredSigners:       modelTx.RequiredSigners,
+		NetworkId:             modelTx.NetworkId,
+		ScriptDataHash:        modelTx.ScriptDataHash,
+		IsValid:               modelTx.IsValid,
+		ExecutedScripts:       modelTx.ExecutedScripts,
+		Proposals:             modelTx.Proposals,
+		Votes:                 modelTx.Votes,
+		CurrentTreasuryValue:  modelTx.CurrentTreasuryValue,
+		TreasuryDonation:      modelTx.TreasuryDonation,
+		DecodedCertificates:   modelTx.DecodedCertificates,
+	}
+}
+
 // GetID returns the ID of the Transaction.
 func (tx *Transaction) GetID() uint {
 	return tx.ID
@@ -211,7 +285,7 @@ func (tx *Transaction) loadCbor(txn *Txn) error {
 }
 
 // NewTx stores a transaction's metadata in the metadata store and its CBOR in the blob store
-func (d *Database) NewTx(blockHash []byte, blockNumber uint64, slotNumber uint64, transactionHash []byte, inputs []models.TransactionInput, outputs []models.TransactionOutput, referenceInputs []models.SimpleUTxO, metadata []byte, fee uint64, ttl uint64, withdrawals map[string]uint64, witness models.Witness, certificates [][]byte, transactionCBOR []byte, txn *Txn) error {
+func (d *Database) NewTx(blockHash []byte, blockNumber uint64, slotNumber uint64, transactionHash []byte, inputs []models.TransactionInput, outputs []models.TransactionOutput, referenceInputs []models.SimpleUTxO, metadata []byte, fee uint64, ttl uint64, withdrawals map[string]uint64, witness models.Witness, certificates []models.Certificate, mints []models.Mint, scripts []models.Script, executedScripts [][]byte, tokenMetadata []models.TokenMetadata, body TxBody, transactionCBOR []byte, txn *Txn) error {
 	if txn == nil {
 		txn = d.Transaction(true)
 		defer txn.Commit() //nolint:errcheck
@@ -224,20 +298,163 @@ func (d *Database) NewTx(blockHash []byte, blockNumber uint64, slotNumber uint64
 		return err
 	}
 
+	// Index withdrawals by stake credential
+	var stakeWithdrawals []models.Withdrawal
+	for stakeAddress, amount := range withdrawals {
+		_, stakeCredential, err := credential.FromAddressString(stakeAddress)
+		if err != nil {
+			d.Logger().Warn("failed to parse withdrawal stake address", "stakeAddress", stakeAddress, "error", err)
+		}
+		stakeWithdrawals = append(stakeWithdrawals, models.Withdrawal{
+			TransactionHash: transactionHash,
+			BlockNumber:     blockNumber,
+			SlotNumber:      slotNumber,
+			StakeAddress:    stakeAddress,
+			StakeCredential: stakeCredential,
+			Amount:          amount,
+		})
+	}
+
+	// Reference inputs are resolved through Kupo at ingest. Resolve them against the
+	// outputs they point to when those are indexed, which also carry the output CBOR.
+	for i := range referenceInputs {
+		output, err := d.metadata.GetTxOutputByUTxO(txn.Metadata(), referenceInputs[i].UTxOID, referenceInputs[i].UTxOIDIndex)
+		if err != nil {
+			return err
+		}
+		if output == nil {
+			continue
+		}
+		referenceInputs[i].Resolved = true
+		referenceInputs[i].Address = output.Address
+		referenceInputs[i].PaymentCredential = output.PaymentCredential
+		referenceInputs[i].StakeCredential = output.StakeCredential
+		referenceInputs[i].Amount = output.Amount
+		referenceInputs[i].Cbor = output.Cbor
+		referenceInputs[i].ReferenceScriptHash = output.ReferenceScriptHash
+	}
+
+	for i := range mints {
+		mints[i].TransactionHash = transactionHash
+		mints[i].BlockNumber = blockNumber
+		mints[i].SlotNumber = slotNumber
+	}
+
+	// Index metadata by label
+	var metadataLabels []models.MetadataLabel
+	metadataEntries, err := txmetadata.Entries(metadata)
+	if err != nil {
+		d.Logger().Warn("failed to decode transaction metadata", "txHash", hex.EncodeToString(transactionHash), "error", err)
+	}
+	for _, entry := range metadataEntries {
+		metadataLabels = append(metadataLabels, models.MetadataLabel{
+			TransactionHash: transactionHash,
+			BlockNumber:     blockNumber,
+			SlotNumber:      slotNumber,
+			Label:           entry.Label,
+			Cbor:            entry.Cbor,
+		})
+	}
+
+	var rawCertificates [][]byte
+	for i := range certificates {
+		certificates[i].TransactionHash = transactionHash
+		certificates[i].BlockNumber = blockNumber
+		certificates[i].SlotNumber = slotNumber
+		rawCertificates = append(rawCertificates, certificates[i].Cbor)
+	}
+
+	for i := range scripts {
+		scripts[i].FirstSeenTxHash = transactionHash
+		scripts[i].FirstSeenSlot = slotNumber
+	}
+	for i := range body.Proposals {
+		body.Proposals[i].TransactionHash = transactionHash
+		body.Proposals[i].BlockNumber = blockNumber
+		body.Proposals[i].SlotNumber = slotNumber
+	}
+	for i := range body.Votes {
+		body.Votes[i].TransactionHash = transactionHash
+		body.Votes[i].BlockNumber = blockNumber
+		body.Votes[i].SlotNumber = slotNumber
+	}
+
+	for i := range tokenMetadata {
+		tokenMetadata[i].TransactionHash = transactionHash
+		tokenMetadata[i].SlotNumber = slotNumber
+	}
+
+	// Store datums once per hash: inline datums, and the witness datums that resolve
+	// outputs carrying only a datum hash
+	var datums []models.Datum
+	for _, input := range inputs {
+		if input.Datum != nil && input.Datum.Datum != nil {
+			datums = append(datums, *input.Datum.Datum)
+		}
+	}
+	for _, refInput := range referenceInputs {
+		if refInput.Datum != nil && refInput.Datum.Datum != nil {
+			datums = append(datums, *refInput.Datum.Datum)
+		}
+	}
+	for _, output := range outputs {
+		if output.Datum != nil && output.Datum.Datum != nil {
+			datums = append(datums, *output.Datum.Datum)
+		}
+	}
+	if body.CollateralReturn != nil && body.CollateralReturn.Datum != nil && body.CollateralReturn.Datum.Datum != nil {
+		datums = append(datums, *body.CollateralReturn.Datum.Datum)
+	}
+	for _, data := range witness.PlutusData {
+		datums = append(datums, models.Datum{
+			DatumHash: lcommon.Blake2b256Hash(data).Bytes(),
+			DatumCbor: data,
+		})
+	}
+
+	var transactionScripts []models.TransactionScript
+	for _, scriptHash := range executedScripts {
+		transactionScripts = append(transactionScripts, models.TransactionScript{
+			TransactionHash: transactionHash,
+			ScriptHash:      scriptHash,
+		})
+	}
+
 	tempTx := models.Transaction{
-		BlockHash:       blockHash,
-		BlockNumber:     blockNumber,
-		SlotNumber:      slotNumber,
-		TransactionHash: transactionHash,
-		Inputs:          inputs,
-		Outputs:         outputs,
-		ReferenceInputs: referenceInputs,
-		Metadata:        metadata,
-		Fee:             fee,
-		TTL:             ttl,
-		Withdrawals:     withdrawals,
-		Witness:         witness,
-		Certificates:    types.ByteSliceSlice(certificates),
+		BlockHash:        blockHash,
+		BlockNumber:      blockNumber,
+		SlotNumber:       slotNumber,
+		TransactionHash:  transactionHash,
+		Inputs:           inputs,
+		Outputs:          outputs,
+		ReferenceInputs:  referenceInputs,
+		Metadata:         metadata,
+		MetadataLabels:   metadataLabels,
+		Fee:              fee,
+		TTL:              ttl,
+		Withdrawals:      withdrawals,
+		StakeWithdrawals: stakeWithdrawals,
+		Mints:            mints,
+		Witness:          witness,
+		Certificates:     types.ByteSliceSlice(rawCertificates),
+
+		ValidityIntervalStart: body.ValidityIntervalStart,
+		Collateral:            body.Collateral,
+		CollateralReturn:      body.CollateralReturn,
+		TotalCollateral:       body.TotalCollateral,
+		RequiredSigners:       types.ByteSliceSlice(body.RequiredSigners),
+		NetworkId:             body.NetworkId,
+		ScriptDataHash:        body.ScriptDataHash,
+		IsValid:               body.IsValid,
+		Scripts:               scripts,
+		ExecutedScripts:       transactionScripts,
+		Proposals:             body.Proposals,
+		Votes:                 body.Votes,
+		CurrentTreasuryValue:  body.CurrentTreasuryValue,
+		TreasuryDonation:      body.TreasuryDonation,
+		DecodedCertificates:   certificates,
+		TokenMetadata:         tokenMetadata,
+		Datums:                datums,
 	}
 
 	// Store metadata in metadata DB
@@ -260,22 +477,7 @@ func (d *Database) GetTxByTxHash(txHash []byte, txn *Txn) (*Transaction, error)
 		return nil, errors.New("transaction not found") // Or return a specific not found error
 	}
 
-	dbTx := &Transaction{
-		ID:              modelTx.ID,
-		BlockHash:       modelTx.BlockHash,
-		BlockNumber:     modelTx.BlockNumber,
-		SlotNumber:      modelTx.SlotNumber,
-		TransactionHash: modelTx.TransactionHash,
-		Inputs:          modelTx.Inputs,
-		Outputs:         modelTx.Outputs,
-		ReferenceInputs: modelTx.ReferenceInputs,
-		Metadata:        modelTx.Metadata,
-		Fee:             modelTx.Fee,
-		TTL:             modelTx.TTL,
-		Withdrawals:     modelTx.Withdrawals,
-		Witness:         modelTx.Witness,
-		Certificates:    modelTx.Certificates,
-	}
+	dbTx := newTransaction(*modelTx)
 
 	// Get CBOR from blob DB
 	cborKey := TxBlobKey(txHash)
@@ -295,7 +497,7 @@ func (d *Database) GetTxByTxHash(txHash []byte, txn *Txn) (*Transaction, error)
 		}
 	}
 
-	return dbTx, nil
+	return &dbTx, nil
 }
 
 // GetTxsByBlockNumber retrieves transaction metadata by block number with pagination support
@@ -312,23 +514,7 @@ func (d *Database) GetTxsByBlockNumber(blockNumber uint64, limit, offset int, tx
 	// Convert models.Transaction to database.Transaction and load CBOR
 	dbTxs := make([]Transaction, len(modelsTxs))
 	for i, modelTx := range modelsTxs {
-		dbTxs[i] = Transaction{
-			ID:              modelTx.ID,
-			BlockHash:       modelTx.BlockHash,
-			BlockNumber:     modelTx.BlockNumber,
-			SlotNumber:      modelTx.SlotNumber,
-			TransactionHash: modelTx.TransactionHash,
-			Inputs:          modelTx.Inputs,
-			Outputs:         modelTx.Outputs,
-			ReferenceInputs: modelTx.ReferenceInputs,
-			Metadata:        modelTx.Metadata,
-			Fee:             modelTx.Fee,
-			TTL:             modelTx.TTL,
-			Withdrawals:     modelTx.Withdrawals,
-			Witness:         modelTx.Witness,
-			Certificates:    modelTx.Certificates,
-			// TransactionCBOR will be loaded below
-		}
+		dbTxs[i] = newTransaction(modelTx)
 
 		// Load CBOR for each transaction
 		cborKey := TxBlobKey(modelTx.TransactionHash)
@@ -362,22 +548,7 @@ func (d *Database) GetTxsBySlotRange(startSlot, endSlot uint64, limit, offset in
 
 	dbTxs := make([]Transaction, len(modelsTxs))
 	for i, modelTx := range modelsTxs {
-		dbTxs[i] = Transaction{
-			ID:              modelTx.ID,
-			BlockHash:       modelTx.BlockHash,
-			BlockNumber:     modelTx.BlockNumber,
-			SlotNumber:      modelTx.SlotNumber,
-			TransactionHash: modelTx.TransactionHash,
-			Inputs:          modelTx.Inputs,
-			Outputs:         modelTx.Outputs,
-			ReferenceInputs: modelTx.ReferenceInputs,
-			Metadata:        modelTx.Metadata,
-			Fee:             modelTx.Fee,
-			TTL:             modelTx.TTL,
-			Withdrawals:     modelTx.Withdrawals,
-			Witness:         modelTx.Witness,
-			Certificates:    modelTx.Certificates,
-		}
+		dbTxs[i] = newTransaction(modelTx)
 
 		// Load CBOR for each transaction
 		cborKey := TxBlobKey(modelTx.TransactionHash)
@@ -422,22 +593,7 @@ func (d *Database) GetTxsByOutputAddress(address string, limit, offset int, txn
 
 	dbTxs := make([]Transaction, len(modelsTxs))
 	for i, modelTx := range modelsTxs {
-		dbTxs[i] = Transaction{
-			ID:              modelTx.ID,
-			BlockHash:       modelTx.BlockHash,
-			BlockNumber:     modelTx.BlockNumber,
-			SlotNumber:      modelTx.SlotNumber,
-			TransactionHash: modelTx.TransactionHash,
-			Inputs:          modelTx.Inputs,
-			Outputs:         modelTx.Outputs,
-			ReferenceInputs: modelTx.ReferenceInputs,
-			Metadata:        modelTx.Metadata,
-			Fee:             modelTx.Fee,
-			TTL:             modelTx.TTL,
-			Withdrawals:     modelTx.Withdrawals,
-			Witness:         modelTx.Witness,
-			Certificates:    modelTx.Certificates,
-		}
+		dbTxs[i] = newTransaction(modelTx)
 
 		// Load CBOR for each transaction
 		cborKey := TxBlobKey(modelTx.TransactionHash)
@@ -458,53 +614,43 @@ func (d *Database) GetTxsByOutputAddress(address string, limit, offset int, txn
 
 // GetTxsByAnyAddress retrieves transaction metadata by any address (input or output) with pagination support
 func (d *Database) GetTxsByAnyAddress(address string, limit, offset int, txn *Txn) ([]Transaction, error) {
+	return d.GetTxsByAnyAddresses([]string{address}, limit, offset, txn)
+}
+
+// GetTxsByAnyAddresses retrieves transaction metadata by any of the given addresses (input or output) with pagination support
+func (d *Database) GetTxsByAnyAddresses(addresses []string, limit, offset int, txn *Txn) ([]Transaction, error) {
 	if txn == nil {
 		txn = d.Transaction(false)
 		defer txn.Commit() //nolint:errcheck
 	}
-	modelsTxs, err := d.metadata.GetTxsByAnyAddress(txn.Metadata(), address, limit, offset)
+	modelsTxs, err := d.metadata.GetTxsByAnyAddresses(txn.Metadata(), addresses, limit, offset)
 	if err != nil {
 		return nil, err
 	}
-	d.Logger().Debug("GetTxsByAnyAddress: retrieved transactions from metadata", "count", len(modelsTxs))
+	d.Logger().Debug("GetTxsByAnyAddresses: retrieved transactions from metadata", "count", len(modelsTxs))
 	if len(modelsTxs) == 0 {
-		d.Logger().Debug("GetTxsByAnyAddress: no transactions found for address", "address", address)
+		d.Logger().Debug("GetTxsByAnyAddresses: no transactions found for addresses", "addresses", addresses)
 	}
 
 	dbTxs := make([]Transaction, len(modelsTxs))
 	for i, modelTx := range modelsTxs {
-		d.Logger().Debug("GetTxsByAnyAddress: processing transaction for CBOR retrieval", "index", i, "txHash", hex.EncodeToString(modelTx.TransactionHash))
+		d.Logger().Debug("GetTxsByAnyAddresses: processing transaction for CBOR retrieval", "index", i, "txHash", hex.EncodeToString(modelTx.TransactionHash))
 		// Log inputs and their assets
 		for j, input := range modelTx.Inputs {
-			d.Logger().Debug(fmt.Sprintf("GetTxsByAnyAddress:   Input[%d]: UTxOID=%x, UTxOIDIndex=%d", j, input.UTxOID, input.UTxOIDIndex))
+			d.Logger().Debug(fmt.Sprintf("GetTxsByAnyAddresses:   Input[%d]: UTxOID=%x, UTxOIDIndex=%d", j, input.UTxOID, input.UTxOIDIndex))
 			for k, asset := range input.Asset {
-				d.Logger().Debug(fmt.Sprintf("GetTxsByAnyAddress:     Input Asset[%d]: UTxOID=%x, UTxOIDIndex=%d, PolicyId=%x, Name=%x", k, asset.UTxOID, asset.UTxOIDIndex, asset.PolicyId, asset.Name))
+				d.Logger().Debug(fmt.Sprintf("GetTxsByAnyAddresses:     Input Asset[%d]: UTxOID=%x, UTxOIDIndex=%d, PolicyId=%x, Name=%x", k, asset.UTxOID, asset.UTxOIDIndex, asset.PolicyId, asset.Name))
 			}
 		}
 		// Log outputs and their assets
 		for j, output := range modelTx.Outputs {
-			d.Logger().Debug(fmt.Sprintf("GetTxsByAnyAddress:   Output[%d]: UTxOID=%x, UTxOIDIndex=%d", j, output.UTxOID, output.UTxOIDIndex))
+			d.Logger().Debug(fmt.Sprintf("GetTxsByAnyAddresses:   Output[%d]: UTxOID=%x, UTxOIDIndex=%d", j, output.UTxOID, output.UTxOIDIndex))
 			for k, asset := range output.Asset {
-				d.Logger().Debug(fmt.Sprintf("GetTxsByAnyAddress:     Output Asset[%d]: UTxOID=%x, UTxOIDIndex=%d, PolicyId=%x, Name=%x", k, asset.UTxOID, asset.UTxOIDIndex, asset.PolicyId, asset.Name))
+				d.Logger().Debug(fmt.Sprintf("GetTxsByAnyAddresses:     Output Asset[%d]: UTxOID=%x, UTxOIDIndex=%d, PolicyId=%x, Name=%x", k, asset.UTxOID, asset.UTxOIDIndex, asset.PolicyId, asset.Name))
 			}
 		}
 
-		dbTxs[i] = Transaction{
-			ID:              modelTx.ID,
-			BlockHash:       modelTx.BlockHash,
-			BlockNumber:     modelTx.BlockNumber,
-			SlotNumber:      modelTx.SlotNumber,
-			TransactionHash: modelTx.TransactionHash,
-			Inputs:          modelTx.Inputs,
-			Outputs:         modelTx.Outputs,
-			ReferenceInputs: modelTx.ReferenceInputs,
-			Metadata:        modelTx.Metadata,
-			Fee:             modelTx.Fee,
-			TTL:             modelTx.TTL,
-			Withdrawals:     modelTx.Withdrawals,
-			Witness:         modelTx.Witness,
-			Certificates:    modelTx.Certificates,
-		}
+		dbTxs[i] = newTransaction(modelTx)
 		// Load CBOR for each transaction
 		cborKey := TxBlobKey(modelTx.TransactionHash)
 		cborItem, err := txn.Blob().Get(cborKey)
@@ -535,22 +681,7 @@ func (d *Database) GetTxsByAssetFingerprint(assetFingerprint []byte, limit, offs
 
 	dbTxs := make([]Transaction, len(modelsTxs))
 	for i, modelTx := range modelsTxs {
-		dbTxs[i] = Transaction{
-			ID:              modelTx.ID,
-			BlockHash:       modelTx.BlockHash,
-			BlockNumber:     modelTx.BlockNumber,
-			SlotNumber:      modelTx.SlotNumber,
-			TransactionHash: modelTx.TransactionHash,
-			Inputs:          modelTx.Inputs,
-			Outputs:         modelTx.Outputs,
-			ReferenceInputs: modelTx.ReferenceInputs,
-			Metadata:        modelTx.Metadata,
-			Fee:             modelTx.Fee,
-			TTL:             modelTx.TTL,
-			Withdrawals:     modelTx.Withdrawals,
-			Witness:         modelTx.Witness,
-			Certificates:    modelTx.Certificates,
-		}
+		dbTxs[i] = newTransaction(modelTx)
 
 		// Load CBOR for each transaction
 		cborKey := TxBlobKey(modelTx.TransactionHash)
@@ -582,22 +713,7 @@ func (d *Database) GetTxsByPolicyIdAndTokenName(policyID []byte, tokenName []byt
 
 	dbTxs := make([]Transaction, len(modelsTxs))
 	for i, modelTx := range modelsTxs {
-		dbTxs[i] = Transaction{
-			ID:              modelTx.ID,
-			BlockHash:       modelTx.BlockHash,
-			BlockNumber:     modelTx.BlockNumber,
-			SlotNumber:      modelTx.SlotNumber,
-			TransactionHash: modelTx.TransactionHash,
-			Inputs:          modelTx.Inputs,
-			Outputs:         modelTx.Outputs,
-			ReferenceInputs: modelTx.ReferenceInputs,
-			Metadata:        modelTx.Metadata,
-			Fee:             modelTx.Fee,
-			TTL:             modelTx.TTL,
-			Withdrawals:     modelTx.Withdrawals,
-			Witness:         modelTx.Witness,
-			Certificates:    modelTx.Certificates,
-		}
+		dbTxs[i] = newTransaction(modelTx)
 
 		// Load CBOR for each transaction
 		cborKey := TxBlobKey(modelTx.TransactionHash)
@@ -629,22 +745,7 @@ func (d *Database) GetTxsByPolicyId(policyID []byte, limit, offset int, txn *Txn
 
 	dbTxs := make([]Transaction, len(modelsTxs))
 	for i, modelTx := range modelsTxs {
-		dbTxs[i] = Transaction{
-			ID:              modelTx.ID,
-			BlockHash:       modelTx.BlockHash,
-			BlockNumber:     modelTx.BlockNumber,
-			SlotNumber:      modelTx.SlotNumber,
-			TransactionHash: modelTx.TransactionHash,
-			Inputs:          modelTx.Inputs,
-			Outputs:         modelTx.Outputs,
-			ReferenceInputs: modelTx.ReferenceInputs,
-			Metadata:        modelTx.Metadata,
-			Fee:             modelTx.Fee,
-			TTL:             modelTx.TTL,
-			Withdrawals:     modelTx.Withdrawals,
-			Witness:         modelTx.Witness,
-			Certificates:    modelTx.Certificates,
-		}
+		dbTxs[i] = newTransaction(modelTx)
 
 		// Load CBOR for each transaction
 		cborKey := TxBlobKey(modelTx.TransactionHash)
@@ -676,22 +777,7 @@ func (d *Database) GetTxsByTokenName(tokenName []byte, limit, offset int, txn *T
 
 	dbTxs := make([]Transaction, len(modelsTxs))
 	for i, modelTx := range modelsTxs {
-		dbTxs[i] = Transaction{
-			ID:              modelTx.ID,
-			BlockHash:       modelTx.BlockHash,
-			BlockNumber:     modelTx.BlockNumber,
-			SlotNumber:      modelTx.SlotNumber,
-			TransactionHash: modelTx.TransactionHash,
-			Inputs:          modelTx.Inputs,
-			Outputs:         modelTx.Outputs,
-			ReferenceInputs: modelTx.ReferenceInputs,
-			Metadata:        modelTx.Metadata,
-			Fee:             modelTx.Fee,
-			TTL:             modelTx.TTL,
-			Withdrawals:     modelTx.Withdrawals,
-			Witness:         modelTx.Witness,
-			Certificates:    modelTx.Certificates,
-		}
+		dbTxs[i] = newTransaction(modelTx)
 
 		// Load CBOR for each transaction
 		cborKey := TxBlobKey(modelTx.TransactionHash)
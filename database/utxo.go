@@ -0,0 +1,205 @@
+package database
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+)
+
+// GetTxsReferencingUTxO retrieves transactions that read a UTxO as a reference input, with pagination support
+func (d *Database) GetTxsReferencingUTxO(utxoID []byte, utxoIndex uint32, limit, offset int, txn *Txn) ([]Transaction, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	modelsTxs, err := d.metadata.GetTxsReferencingUTxO(txn.Metadata(), utxoID, utxoIndex, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return d.loadTransactions(modelsTxs, txn), nil
+}
+
+// Limits of the spend chain walks GetUTxOLineage performs. The chain can fan out at each
+// transaction, so the transactions and UTxOs visited in each direction are bounded too.
+const (
+	MaxLineageDepth = 10
+	MaxLineageSteps = 100
+	MaxLineageUTxOs = 1000
+)
+
+// UTxO is an indexed UTxO with the transactions that created and spent it
+type UTxO struct {
+	Output    models.TransactionOutput
+	CreatedBy *Transaction
+	// SpentBy is nil while the UTxO is unspent
+	SpentBy *Transaction
+}
+
+// UTxORef identifies a UTxO by the hash of the transaction that created it and its index
+type UTxORef struct {
+	UTxOID      []byte
+	UTxOIDIndex uint32
+}
+
+// UTxOLineageStep is a transaction in the spend chain of a UTxO, Depth transactions away
+// from it, with the UTxOs it consumed and produced
+type UTxOLineageStep struct {
+	Depth           int
+	TransactionHash []byte
+	BlockNumber     uint64
+	SlotNumber      uint64
+	IsValid         bool
+	Consumed        []UTxORef
+	Produced        []UTxORef
+}
+
+// UTxOLineage is the spend chain around a UTxO. Ancestors are the transactions that
+// created the UTxO and, recursively, the UTxOs they consumed; descendants the
+// transactions that spent it and, recursively, the UTxOs they produced.
+type UTxOLineage struct {
+	Ancestors   []UTxOLineageStep
+	Descendants []UTxOLineageStep
+	// Truncated is set when a walk stopped at MaxLineageSteps transactions or
+	// MaxLineageUTxOs UTxOs before reaching the requested depth
+	Truncated bool
+}
+
+// GetUTxO retrieves an indexed UTxO with the transactions that created and spent it. It
+// returns nil when no indexed transaction produced the UTxO, including for the outputs of
+// transactions that failed phase-2 validation.
+func (d *Database) GetUTxO(utxoID []byte, utxoIndex uint32, txn *Txn) (*UTxO, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	output, err := d.metadata.GetProducedUTxO(txn.Metadata(), utxoID, utxoIndex)
+	if err != nil {
+		return nil, err
+	}
+	if output == nil {
+		return nil, nil
+	}
+	utxo := &UTxO{Output: *output}
+	utxo.CreatedBy, err = d.GetTxByTxHash(utxoID, txn)
+	if err != nil {
+		return nil, err
+	}
+	spendingTxHash, err := d.metadata.GetSpendingTxHash(txn.Metadata(), utxoID, utxoIndex)
+	if err != nil {
+		return nil, err
+	}
+	if spendingTxHash != nil {
+		utxo.SpentBy, err = d.GetTxByTxHash(spendingTxHash, txn)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return utxo, nil
+}
+
+// GetUTxOLineage walks the spend chain of a UTxO backward and forward, up to depth
+// transactions away. Only indexed transactions are part of the chain.
+func (d *Database) GetUTxOLineage(utxoID []byte, utxoIndex uint32, depth int, txn *Txn) (*UTxOLineage, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	if depth > MaxLineageDepth {
+		depth = MaxLineageDepth
+	}
+	start := []UTxORef{{UTxOID: utxoID, UTxOIDIndex: utxoIndex}}
+	lineage := &UTxOLineage{}
+	var ancestorsTruncated, descendantsTruncated bool
+	var err error
+
+	// Backward, the transaction that created each UTxO is the one its UTxOID names
+	lineage.Ancestors, ancestorsTruncated, err = d.walkLineage(start, depth, txn, func(utxo UTxORef) ([]byte, error) {
+		return utxo.UTxOID, nil
+	}, func(step UTxOLineageStep) []UTxORef {
+		return step.Consumed
+	})
+	if err != nil {
+		return nil, err
+	}
+	lineage.Descendants, descendantsTruncated, err = d.walkLineage(start, depth, txn, func(utxo UTxORef) ([]byte, error) {
+		return d.metadata.GetSpendingTxHash(txn.Metadata(), utxo.UTxOID, utxo.UTxOIDIndex)
+	}, func(step UTxOLineageStep) []UTxORef {
+		return step.Produced
+	})
+	if err != nil {
+		return nil, err
+	}
+	lineage.Truncated = ancestorsTruncated || descendantsTruncated
+	return lineage, nil
+}
+
+// walkLineage walks a spend chain breadth first. next returns the transaction linked to a
+// UTxO in the walk direction, if any, and follow the UTxOs to continue the walk from. It
+// reports whether the walk stopped at MaxLineageSteps or MaxLineageUTxOs.
+func (d *Database) walkLineage(start []UTxORef, depth int, txn *Txn, next func(UTxORef) ([]byte, error), follow func(UTxOLineageStep) []UTxORef) ([]UTxOLineageStep, bool, error) {
+	steps := []UTxOLineageStep{}
+	visited := make(map[string]bool)
+	visitedUTxOs := 0
+	frontier := start
+	for stepDepth := 1; stepDepth <= depth && len(frontier) > 0; stepDepth++ {
+		var nextFrontier []UTxORef
+		for _, utxo := range frontier {
+			if visitedUTxOs == MaxLineageUTxOs {
+				return steps, true, nil
+			}
+			visitedUTxOs++
+			txHash, err := next(utxo)
+			if err != nil {
+				return nil, false, err
+			}
+			if txHash == nil || visited[string(txHash)] {
+				continue
+			}
+			visited[string(txHash)] = true
+			tx, err := d.metadata.GetTxWithUTxOs(txn.Metadata(), txHash)
+			if err != nil {
+				return nil, false, err
+			}
+			if tx == nil {
+				// The transaction was not indexed
+				continue
+			}
+			if len(steps) == MaxLineageSteps {
+				return steps, true, nil
+			}
+			step := newLineageStep(tx, stepDepth)
+			steps = append(steps, step)
+			nextFrontier = append(nextFrontier, follow(step)...)
+		}
+		frontier = nextFrontier
+	}
+	return steps, false, nil
+}
+
+// newLineageStep lists the UTxOs a transaction consumed and produced: its inputs and
+// outputs if it passed phase-2 validation, its collateral and collateral return if not
+func newLineageStep(tx *models.Transaction, depth int) UTxOLineageStep {
+	step := UTxOLineageStep{
+		Depth:           depth,
+		TransactionHash: tx.TransactionHash,
+		BlockNumber:     tx.BlockNumber,
+		SlotNumber:      tx.SlotNumber,
+		IsValid:         tx.IsValid,
+		Consumed:        []UTxORef{},
+		Produced:        []UTxORef{},
+	}
+	if tx.IsValid {
+		for _, input := range tx.Inputs {
+			step.Consumed = append(step.Consumed, UTxORef{UTxOID: input.UTxOID, UTxOIDIndex: input.UTxOIDIndex})
+		}
+		for _, output := range tx.Outputs {
+			step.Produced = append(step.Produced, UTxORef{UTxOID: output.UTxOID, UTxOIDIndex: output.UTxOIDIndex})
+		}
+		return step
+	}
+	for _, collateral := range tx.Collateral {
+		step.Consumed = append(step.Consumed, UTxORef{UTxOID: collateral.UTxOID, UTxOIDIndex: collateral.UTxOIDIndex})
+	}
+	if tx.CollateralReturn != nil {
+		step.Produced = append(step.Produced, UTxORef{UTxOID: tx.CollateralReturn.UTxOID, UTxOIDIndex: tx.CollateralReturn.UTxOIDIndex})
+	}
+	return step
+}
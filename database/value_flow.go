@@ -0,0 +1,246 @@
+package database
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// Directions of a transaction relative to an address
+const (
+	DirectionSent     = "sent"
+	DirectionReceived = "received"
+	DirectionSelf     = "self"
+	DirectionContract = "contract"
+)
+
+// AssetDelta is the net change in the amount of an asset
+type AssetDelta struct {
+	PolicyId []byte
+	NameHex  []byte
+	Amount   *big.Int
+}
+
+// AddressValueFlow is the value a transaction consumed from and produced to an address
+type AddressValueFlow struct {
+	Address string
+	// Script is set when the payment part of the address is a script hash
+	Script bool
+	// Consumed and Produced are the lovelace the transaction consumed from and produced to
+	// the address, and Lovelace their difference
+	Consumed uint64
+	Produced uint64
+	Lovelace int64
+	// Assets are the net changes in native assets, without the assets whose amount did
+	// not change
+	Assets []AssetDelta
+}
+
+// ValueFlow is the value a transaction moved between addresses. Transactions that failed
+// phase-2 validation move their collateral to their collateral return instead of their
+// inputs to their outputs.
+type ValueFlow struct {
+	TransactionHash []byte
+	IsValid         bool
+	// Fee is the transaction fee, or the collateral lost when the transaction is not valid
+	Fee         uint64
+	Withdrawals uint64
+	// Complete is false when some consumed UTxO was not indexed, in which case the value
+	// it held is missing from the flow
+	Complete  bool
+	Addresses []AddressValueFlow
+	// ScriptsExecuted is set when the transaction ran Plutus scripts
+	ScriptsExecuted bool
+}
+
+// AddressTxSummary summarizes a transaction for one of the addresses it involves
+type AddressTxSummary struct {
+	Direction string
+	Lovelace  int64
+	Assets    []AssetDelta
+}
+
+// GetTxValueFlow retrieves the value flow of a transaction. It returns nil when the
+// transaction is not indexed.
+func (d *Database) GetTxValueFlow(txHash []byte, txn *Txn) (*ValueFlow, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	modelTx, err := d.metadata.GetTxByTxHash(txn.Metadata(), txHash)
+	if err != nil {
+		return nil, err
+	}
+	if modelTx == nil {
+		return nil, nil
+	}
+	return d.TxValueFlow(newTransaction(*modelTx), txn)
+}
+
+// TxValueFlow computes the value flow of a loaded transaction. Inputs the transaction
+// was indexed without, and collateral, are resolved against the indexed outputs.
+func (d *Database) TxValueFlow(tx Transaction, txn *Txn) (*ValueFlow, error) {
+	if txn == nil {
+		txn = d.Transaction(false)
+		defer txn.Commit() //nolint:errcheck
+	}
+	flow := &ValueFlow{
+		TransactionHash: tx.TransactionHash,
+		IsValid:         tx.IsValid,
+		Fee:             tx.Fee,
+		Complete:        true,
+		ScriptsExecuted: len(tx.Witness.Redeemers) > 0,
+	}
+	for _, amount := range tx.Withdrawals {
+		flow.Withdrawals += amount
+	}
+
+	var consumed, produced []models.TransactionOutput
+	if tx.IsValid {
+		for _, input := range tx.Inputs {
+			if len(input.Address) > 0 {
+				consumed = append(consumed, models.TransactionOutput{Address: input.Address, Amount: input.Amount, Asset: input.Asset})
+				continue
+			}
+			output, err := d.metadata.GetTxOutputByUTxO(txn.Metadata(), input.UTxOID, input.UTxOIDIndex)
+			if err != nil {
+				return nil, err
+			}
+			if output == nil {
+				flow.Complete = false
+				continue
+			}
+			consumed = append(consumed, *output)
+		}
+		produced = tx.Outputs
+	} else {
+		for _, collateral := range tx.Collateral {
+			output, err := d.metadata.GetTxOutputByUTxO(txn.Metadata(), collateral.UTxOID, collateral.UTxOIDIndex)
+			if err != nil {
+				return nil, err
+			}
+			if output == nil {
+				flow.Complete = false
+				continue
+			}
+			consumed = append(consumed, *output)
+		}
+		if tx.CollateralReturn != nil {
+			produced = []models.TransactionOutput{*tx.CollateralReturn}
+		}
+		flow.Fee = tx.TotalCollateral
+		flow.Withdrawals = 0
+	}
+	flow.Addresses = newAddressValueFlows(consumed, produced)
+	if !tx.IsValid && flow.Fee == 0 && flow.Complete {
+		// Transactions without a total collateral field lose their collateral less the return
+		for _, address := range flow.Addresses {
+			flow.Fee += address.Consumed - min(address.Consumed, address.Produced)
+		}
+	}
+	return flow, nil
+}
+
+// newAddressValueFlows nets the value consumed from and produced to each address, in
+// order of first appearance
+func newAddressValueFlows(consumed, produced []models.TransactionOutput) []AddressValueFlow {
+	flows := []AddressValueFlow{}
+	flowIndex := make(map[string]int)
+	assetDeltas := make(map[string]map[string]*AssetDelta)
+	add := func(utxo models.TransactionOutput, sign int64) {
+		address := string(utxo.Address)
+		i, ok := flowIndex[address]
+		if !ok {
+			i = len(flows)
+			flowIndex[address] = i
+			flows = append(flows, AddressValueFlow{Address: address, Script: isScriptAddress(address)})
+			assetDeltas[address] = make(map[string]*AssetDelta)
+		}
+		if sign < 0 {
+			flows[i].Consumed += utxo.Amount
+		} else {
+			flows[i].Produced += utxo.Amount
+		}
+		for _, asset := range utxo.Asset {
+			key := string(asset.PolicyId) + string(asset.NameHex)
+			delta, ok := assetDeltas[address][key]
+			if !ok {
+				delta = &AssetDelta{PolicyId: asset.PolicyId, NameHex: asset.NameHex, Amount: new(big.Int)}
+				assetDeltas[address][key] = delta
+			}
+			amount := new(big.Int).SetUint64(asset.Amount)
+			if sign < 0 {
+				delta.Amount.Sub(delta.Amount, amount)
+			} else {
+				delta.Amount.Add(delta.Amount, amount)
+			}
+		}
+	}
+	for _, utxo := range consumed {
+		add(utxo, -1)
+	}
+	for _, utxo := range produced {
+		add(utxo, 1)
+	}
+
+	for i := range flows {
+		flows[i].Lovelace = int64(flows[i].Produced) - int64(flows[i].Consumed)
+		flows[i].Assets = []AssetDelta{}
+		for _, delta := range assetDeltas[flows[i].Address] {
+			if delta.Amount.Sign() != 0 {
+				flows[i].Assets = append(flows[i].Assets, *delta)
+			}
+		}
+		sort.Slice(flows[i].Assets, func(a, b int) bool {
+			if c := bytes.Compare(flows[i].Assets[a].PolicyId, flows[i].Assets[b].PolicyId); c != 0 {
+				return c < 0
+			}
+			return bytes.Compare(flows[i].Assets[a].NameHex, flows[i].Assets[b].NameHex) < 0
+		})
+	}
+	return flows
+}
+
+// isScriptAddress reports whether the payment part of an address is a script hash
+func isScriptAddress(address string) bool {
+	parsed, err := lcommon.NewAddress(address)
+	if err != nil {
+		return false
+	}
+	return credential.PaymentScriptHash(parsed) != nil
+}
+
+// AddressSummary summarizes the transaction for an address. The direction is contract
+// when the transaction ran Plutus scripts or moved value to or from a script address,
+// self when the address is the only one the transaction moved value between, sent when
+// the transaction consumed value from the address and received otherwise.
+func (f *ValueFlow) AddressSummary(address string) AddressTxSummary {
+	summary := AddressTxSummary{Direction: DirectionReceived, Assets: []AssetDelta{}}
+	var flow *AddressValueFlow
+	contract := f.ScriptsExecuted
+	for i := range f.Addresses {
+		if f.Addresses[i].Address == address {
+			flow = &f.Addresses[i]
+		}
+		if f.Addresses[i].Script {
+			contract = true
+		}
+	}
+	if flow != nil {
+		summary.Lovelace = flow.Lovelace
+		summary.Assets = flow.Assets
+	}
+	switch {
+	case contract:
+		summary.Direction = DirectionContract
+	case flow != nil && len(f.Addresses) == 1:
+		summary.Direction = DirectionSelf
+	case flow != nil && flow.Consumed > 0:
+		summary.Direction = DirectionSent
+	}
+	return summary
+}
@@ -19,6 +19,7 @@ require (
 	github.com/gofiber/swagger v1.1.1
 	github.com/lmittmann/tint v1.1.1
 	github.com/swaggo/swag v1.16.4
+	golang.org/x/crypto v0.37.0
 )
 
 require (
@@ -74,7 +75,6 @@ require (
 	go.opentelemetry.io/otel v1.35.0 // indirect
 	go.opentelemetry.io/otel/metric v1.35.0 // indirect
 	go.opentelemetry.io/otel/trace v1.35.0 // indirect
-	golang.org/x/crypto v0.37.0 // indirect
 	golang.org/x/net v0.38.0 // indirect
 	golang.org/x/sync v0.13.0 // indirect
 	golang.org/x/sys v0.32.0 // indirect
@@ -0,0 +1,48 @@
+package account_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetUTxOsByStakeAddressHandler handles the request to get the unspent outputs of a stake account.
+//
+//	@Summary		Get UTxOs by Stake Address
+//	@Description	Retrieves the indexed outputs delegated to the stake credential that have not been spent by an indexed transaction, with pagination.
+//	@ID				getUTxOsByStakeAddress
+//	@Tags			Accounts
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			stake_address	path		string	true	"Bech32 stake address or hex encoded stake credential hash."
+//	@Param			limit			query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset			query		int		false	"Number of results to skip."	default(0)
+//	@Success		200				{array}		viewmodel.TransactionOutput	"Successfully retrieved UTxOs."
+//	@Failure		400				{object}	object{error=string}		"Invalid stake address or pagination parameters."
+//	@Failure		500				{object}	object{error=string}		"Internal server error."
+//	@Router			/accounts/{stake_address}/utxos [get]
+func GetUTxOsByStakeAddressHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		stakeCredential, err := credential.ParseStakeCredential(c.Params("stake_address"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		outputs, err := db.GetUnspentOutputsByStakeCredential(stakeCredential, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get UTxOs for stake address", "stake_address", c.Params("stake_address"), "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get UTxOs"})
+		}
+
+		return c.JSON(viewmodel.ConvertTransactionOutputsToViewModels(outputs))
+	}
+}
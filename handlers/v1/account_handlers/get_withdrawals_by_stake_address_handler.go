@@ -0,0 +1,48 @@
+package account_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetWithdrawalsByStakeAddressHandler handles the request to get the reward withdrawals of a stake account.
+//
+//	@Summary		Get Withdrawals by Stake Address
+//	@Description	Retrieves the reward withdrawals made from the stake credential, newest first, with pagination.
+//	@ID				getWithdrawalsByStakeAddress
+//	@Tags			Accounts
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			stake_address	path		string	true	"Bech32 stake address or hex encoded stake credential hash."
+//	@Param			limit			query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset			query		int		false	"Number of results to skip."	default(0)
+//	@Success		200				{array}		viewmodel.Withdrawal	"Successfully retrieved withdrawals."
+//	@Failure		400				{object}	object{error=string}	"Invalid stake address or pagination parameters."
+//	@Failure		500				{object}	object{error=string}	"Internal server error."
+//	@Router			/accounts/{stake_address}/withdrawals [get]
+func GetWithdrawalsByStakeAddressHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		stakeCredential, err := credential.ParseStakeCredential(c.Params("stake_address"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		withdrawals, err := db.GetWithdrawalsByStakeCredential(stakeCredential, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get withdrawals for stake address", "stake_address", c.Params("stake_address"), "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get withdrawals"})
+		}
+
+		return c.JSON(viewmodel.ConvertWithdrawalModelsToViewModels(withdrawals))
+	}
+}
@@ -1,9 +1,12 @@
 package address_handlers
 
 import (
+	"errors"
 	"log/slog"
 
 	database "github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/indexer/cache"
 	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
 	"github.com/gofiber/fiber/v2"
 	fiberLogger "github.com/gofiber/fiber/v2/log"
@@ -12,15 +15,16 @@ import (
 // AddAddressHandler handles the request to add a new address.
 //
 //	@Summary		Add Address
-//	@Description	Adds a new address to the indexer for monitoring transactions and UTxOs.
+//	@Description	Adds a new address to the watchlist for monitoring transactions and UTxOs, with an optional label, tags (groups), source, start slot and JSON metadata. Transactions before the start slot are not indexed. An address that was removed earlier can be added again.
 //	@ID				addAddress
 //	@Tags			Addresses
 //	@Security		ApiKeyAuth
 //	@Accept			json
 //	@Produce		json
-//	@Param			address	body		viewmodel.AddressRequest	true	"The address object containing the address string to be added."
+//	@Param			address	body		viewmodel.AddressRequest	true	"The watchlist entry to add."
 //	@Success		201		{object}	object{message=string}		"Successfully added address."
-//	@Failure		400		{object}	object{error=string}		"Invalid request body or missing address."
+//	@Failure		400		{object}	object{error=string}		"Invalid request body, missing address or metadata that is not a JSON object."
+//	@Failure		409		{object}	object{error=string}		"Address is already on the watchlist."
 //	@Failure		500		{object}	object{error=string}		"Internal server error."
 //	@Router			/addresses [post]
 func AddAddressHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
@@ -31,16 +35,21 @@ func AddAddressHandler(db *database.Database, logger *slog.Logger) fiber.Handler
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
 		}
 
-		if addressRequest.Address == "" {
-			fiberLogger.Error("address is required")
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Address is required"})
+		entry, err := viewmodel.ConvertAddressRequestToModel(*addressRequest, models.AddressSourceAPI)
+		if err != nil {
+			fiberLogger.Errorf("invalid address request: %v", err)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 		}
 
 		// Use a database transaction
 		txn := db.Transaction(true)
 		defer txn.Discard() // Ensure rollback on error
 
-		err := db.Metadata().AddAddress(txn.Metadata(), addressRequest.Address)
+		err = db.Metadata().AddAddress(txn.Metadata(), entry)
+		if errors.Is(err, models.ErrAddressAlreadyWatched) {
+			txn.Rollback()
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Address is already on the watchlist"})
+		}
 		if err != nil {
 			txn.Rollback()
 			fiberLogger.Errorf("failed to add address to database: %v", err)
@@ -52,7 +61,10 @@ func AddAddressHandler(db *database.Database, logger *slog.Logger) fiber.Handler
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to add address"})
 		}
 
-		fiberLogger.Infof("address added successfully: %s", addressRequest.Address)
+		// Start filtering on the new address right away
+		cache.GetRelevantDataCache().LoadCache()
+
+		fiberLogger.Infof("address added successfully: %s", entry.Address)
 		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Address added successfully"})
 	}
 }
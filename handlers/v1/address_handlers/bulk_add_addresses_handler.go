@@ -0,0 +1,126 @@
+package address_handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	database "github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/indexer/cache"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxBulkAddresses caps the number of watchlist entries a single bulk request may carry
+const maxBulkAddresses = 10000
+
+// isCSVRequest reports whether the request body (or uploaded file) is CSV rather than JSON.
+func isCSVRequest(c *fiber.Ctx) bool {
+	contentType := strings.ToLower(c.Get(fiber.HeaderContentType))
+	return strings.HasPrefix(contentType, "text/csv") || strings.HasPrefix(contentType, fiber.MIMEMultipartForm)
+}
+
+// csvBody returns the CSV payload, either the raw body or the multipart upload named "file".
+func csvBody(c *fiber.Ctx) ([]byte, error) {
+	if !strings.HasPrefix(strings.ToLower(c.Get(fiber.HeaderContentType)), fiber.MIMEMultipartForm) {
+		return c.Body(), nil
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, errors.New("multipart upload must contain a file field")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// BulkAddAddressesHandler handles the request to add several addresses to the watchlist at once.
+//
+//	@Summary		Bulk Add Addresses
+//	@Description	Adds several addresses to the watchlist in one transaction. The body is either a JSON array of watchlist entries or CSV (text/csv body, or a multipart upload in the "file" field) with a header row naming the columns address, label, tags (separated by ";"), source, start_slot and metadata. Addresses that are already watched are skipped.
+//	@ID				bulkAddAddresses
+//	@Tags			Addresses
+//	@Security		ApiKeyAuth
+//	@Accept			json,mpfd
+//	@Produce		json
+//	@Param			addresses	body		[]viewmodel.AddressRequest			true	"The watchlist entries to add."
+//	@Success		201			{object}	viewmodel.BulkAddAddressesResult	"Successfully added addresses."
+//	@Failure		400			{object}	object{error=string}				"Invalid request body or entry."
+//	@Failure		500			{object}	object{error=string}				"Internal server error."
+//	@Router			/addresses/bulk [post]
+func BulkAddAddressesHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var addressRequests []viewmodel.AddressRequest
+		if isCSVRequest(c) {
+			body, err := csvBody(c)
+			if err != nil {
+				logger.Error("failed to read csv upload", "error", err)
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			addressRequests, err = viewmodel.ParseAddressRequestsCSV(bytes.NewReader(body))
+			if err != nil {
+				logger.Error("failed to parse csv", "error", err)
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid CSV: %v", err)})
+			}
+		} else if err := c.BodyParser(&addressRequests); err != nil {
+			logger.Error("failed to parse request body", "error", err)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+
+		if len(addressRequests) == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "At least one address is required"})
+		}
+		if len(addressRequests) > maxBulkAddresses {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("At most %d addresses can be added at once", maxBulkAddresses)})
+		}
+
+		entries := make([]*models.Address, 0, len(addressRequests))
+		for i, addressRequest := range addressRequests {
+			entry, err := viewmodel.ConvertAddressRequestToModel(addressRequest, models.AddressSourceBulk)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("entry %d: %v", i, err)})
+			}
+			entries = append(entries, entry)
+		}
+
+		// Use a database transaction
+		txn := db.Transaction(true)
+		defer txn.Discard() // Ensure rollback on error
+
+		result := viewmodel.BulkAddAddressesResult{Skipped: []string{}}
+		for _, entry := range entries {
+			err := db.Metadata().AddAddress(txn.Metadata(), entry)
+			if errors.Is(err, models.ErrAddressAlreadyWatched) {
+				result.Skipped = append(result.Skipped, entry.Address)
+				continue
+			}
+			if err != nil {
+				txn.Rollback()
+				logger.Error("failed to add address to database", "address", entry.Address, "error", err)
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to add addresses"})
+			}
+			result.Added++
+		}
+
+		if err := txn.Commit(); err != nil {
+			logger.Error("failed to commit transaction", "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to add addresses"})
+		}
+
+		// Start filtering on the new addresses right away
+		cache.GetRelevantDataCache().LoadCache()
+
+		logger.Info("addresses added", "added", result.Added, "skipped", len(result.Skipped))
+		return c.Status(fiber.StatusCreated).JSON(result)
+	}
+}
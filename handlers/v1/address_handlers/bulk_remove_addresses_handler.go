@@ -0,0 +1,90 @@
+package address_handlers
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	database "github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/indexer/cache"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BulkRemoveAddressesHandler handles the request to remove several addresses from the watchlist at once.
+//
+//	@Summary		Bulk Remove Addresses
+//	@Description	Removes several addresses from the watchlist in one transaction. The body is either a JSON object with an addresses array or CSV (text/csv body, or a multipart upload in the "file" field) with an address column.
+//	@ID				bulkRemoveAddresses
+//	@Tags			Addresses
+//	@Security		ApiKeyAuth
+//	@Accept			json,mpfd
+//	@Produce		json
+//	@Param			addresses	body		viewmodel.BulkRemoveAddressesRequest	true	"The addresses to remove."
+//	@Success		200			{object}	object{removed=int64}					"Successfully removed addresses."
+//	@Failure		400			{object}	object{error=string}					"Invalid request body."
+//	@Failure		500			{object}	object{error=string}					"Internal server error."
+//	@Router			/addresses/bulk [delete]
+func BulkRemoveAddressesHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var addresses []string
+		if isCSVRequest(c) {
+			body, err := csvBody(c)
+			if err != nil {
+				logger.Error("failed to read csv upload", "error", err)
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+			}
+			addressRequests, err := viewmodel.ParseAddressRequestsCSV(bytes.NewReader(body))
+			if err != nil {
+				logger.Error("failed to parse csv", "error", err)
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid CSV: %v", err)})
+			}
+			for _, addressRequest := range addressRequests {
+				addresses = append(addresses, addressRequest.Address)
+			}
+		} else {
+			removeRequest := new(viewmodel.BulkRemoveAddressesRequest)
+			if err := c.BodyParser(removeRequest); err != nil {
+				logger.Error("failed to parse request body", "error", err)
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+			}
+			addresses = removeRequest.Addresses
+		}
+
+		var trimmed []string
+		for _, address := range addresses {
+			if address = strings.TrimSpace(address); address != "" {
+				trimmed = append(trimmed, address)
+			}
+		}
+		if len(trimmed) == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "At least one address is required"})
+		}
+		if len(trimmed) > maxBulkAddresses {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("At most %d addresses can be removed at once", maxBulkAddresses)})
+		}
+
+		// Use a database transaction
+		txn := db.Transaction(true)
+		defer txn.Discard() // Ensure rollback on error
+
+		removed, err := db.Metadata().RemoveAddresses(txn.Metadata(), trimmed)
+		if err != nil {
+			txn.Rollback()
+			logger.Error("failed to remove addresses from database", "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to remove addresses"})
+		}
+
+		if err := txn.Commit(); err != nil {
+			logger.Error("failed to commit transaction", "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to remove addresses"})
+		}
+
+		// Stop filtering on the removed addresses right away
+		cache.GetRelevantDataCache().LoadCache()
+
+		logger.Info("addresses removed", "removed", removed)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"removed": removed})
+	}
+}
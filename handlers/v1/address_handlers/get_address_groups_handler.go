@@ -0,0 +1,32 @@
+package address_handlers
+
+import (
+	"log/slog"
+
+	database "github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetAddressGroupsHandler handles the request to list the watchlist groups.
+//
+//	@Summary		List Address Groups
+//	@Description	Lists every tag (group) used on the address watchlist with the number of addresses in it.
+//	@ID				getAddressGroups
+//	@Tags			Addresses
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		models.AddressTagCount	"Successfully retrieved address groups."
+//	@Failure		500	{object}	object{error=string}	"Internal server error."
+//	@Router			/addresses/groups [get]
+func GetAddressGroupsHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tags, err := db.Metadata().GetAddressTags(nil)
+		if err != nil {
+			logger.Error("failed to get address groups", "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to retrieve address groups"})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(tags)
+	}
+}
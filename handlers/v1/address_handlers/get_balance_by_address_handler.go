@@ -0,0 +1,55 @@
+package address_handlers
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+)
+
+// GetBalanceByAddressHandler godoc
+// @Summary Get Balance by Address
+// @Description Retrieves the lovelace and native assets an address held in UTxOs, currently or at the end of a given slot.
+// @ID getBalanceByAddress
+// @Tags Addresses
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param address path string true "The address to retrieve the balance of."
+// @Param at_slot query uint64 false "Slot at the end of which to reconstruct the balance. Defaults to the current balance."
+// @Success 200 {object} viewmodel.AddressBalance "Successfully retrieved the balance."
+// @Failure 400 {object} object{error=string} "Invalid address or slot."
+// @Failure 500 {object} object{error=string} "Internal server error."
+// @Router /addresses/{address}/balance [get]
+func GetBalanceByAddressHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		address := c.Params("address")
+		if address == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "address path parameter is missing"})
+		}
+
+		// Slots are stored as signed integers, so the current balance is the balance at the last one
+		slot := uint64(math.MaxInt64)
+		var atSlot *uint64
+		if atSlotStr := c.Query("at_slot"); atSlotStr != "" {
+			parsed, err := strconv.ParseUint(atSlotStr, 10, 64)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "at_slot must be an unsigned integer"})
+			}
+			atSlot = &parsed
+			slot = min(parsed, slot)
+		}
+
+		balance, err := db.GetAddressBalance(address, slot, nil)
+		if err != nil {
+			logger.Error("failed to get balance by address", "address", address, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get balance"})
+		}
+
+		return c.JSON(viewmodel.ConvertAddressBalanceToViewModel(balance, atSlot))
+	}
+}
@@ -0,0 +1,56 @@
+package address_handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Andamio-Platform/andamio-indexer/config"
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+)
+
+// GetBalanceHistoryByAddressHandler godoc
+// @Summary Get Balance History by Address
+// @Description Retrieves the lovelace balance history of an address: for each epoch or UTC day in which its balance changed, the lovelace received and sent and the balance at the end of the bucket.
+// @ID getBalanceHistoryByAddress
+// @Tags Addresses
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param address path string true "The address to retrieve the balance history of."
+// @Param bucket query string false "Bucket size, epoch or day." default(epoch)
+// @Success 200 {array} viewmodel.BalancePoint "Successfully retrieved the balance history."
+// @Failure 400 {object} object{error=string} "Invalid address or bucket."
+// @Failure 404 {object} object{error=string} "No balance changes found."
+// @Failure 500 {object} object{error=string} "Internal server error."
+// @Router /addresses/{address}/balance-history [get]
+func GetBalanceHistoryByAddressHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	network := config.GetGlobalConfig().Network
+	params, known := network.ChainTimeParams()
+	return func(c *fiber.Ctx) error {
+		address := c.Params("address")
+		if address == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "address path parameter is missing"})
+		}
+		bucket := c.Query("bucket", database.BalanceBucketEpoch)
+		if bucket != database.BalanceBucketEpoch && bucket != database.BalanceBucketDay {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "bucket must be epoch or day"})
+		}
+		if !known {
+			logger.Error("no slot time parameters for network", "magic", network.Magic)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "slot times are unknown for the network"})
+		}
+
+		points, err := db.GetAddressBalanceHistory(address, bucket, params, nil)
+		if err != nil {
+			logger.Error("failed to get balance history by address", "address", address, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get balance history"})
+		}
+		if len(points) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No balance changes found for the address"})
+		}
+
+		return c.JSON(viewmodel.ConvertBalancePointsToViewModels(points))
+	}
+}
@@ -0,0 +1,44 @@
+package address_handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+)
+
+// GetStatsByAddressHandler godoc
+// @Summary Get Stats by Address
+// @Description Retrieves the activity stats of an address: first and last seen transactions, transaction count, lovelace received and sent, fees paid, distinct assets held and distinct counterparties. The stats are kept up to date as transactions are indexed.
+// @ID getStatsByAddress
+// @Tags Addresses
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param address path string true "The address to retrieve the stats of."
+// @Success 200 {object} viewmodel.AddressStats "Successfully retrieved the stats."
+// @Failure 400 {object} object{error=string} "Invalid address."
+// @Failure 404 {object} object{error=string} "No indexed transaction involves the address."
+// @Failure 500 {object} object{error=string} "Internal server error."
+// @Router /addresses/{address}/stats [get]
+func GetStatsByAddressHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		address := c.Params("address")
+		if address == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "address path parameter is missing"})
+		}
+
+		stats, err := db.Metadata().GetAddressStats(nil, []byte(address))
+		if err != nil {
+			logger.Error("failed to get stats by address", "address", address, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get stats"})
+		}
+		if stats == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No transactions found for the address"})
+		}
+
+		return c.JSON(viewmodel.ConvertAddressStatsModelToViewModel(stats))
+	}
+}
@@ -0,0 +1,57 @@
+package address_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetTransactionsByAddressGroupHandler handles the request to get transactions for every address in a group.
+//
+//	@Summary		Get Transactions by Address Group
+//	@Description	Retrieves transactions in which any address of a watchlist group appears as input or output, with pagination.
+//	@ID				getTransactionsByAddressGroup
+//	@Tags			Addresses
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			tag		path		string	true	"The group (tag) to retrieve transactions for."
+//	@Param			limit	query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset	query		int		false	"Number of results to skip."	default(0)
+//	@Success		200		{array}		viewmodel.Transaction	"Successfully retrieved transactions."
+//	@Failure		400		{object}	object{error=string}	"Invalid pagination parameters."
+//	@Failure		404		{object}	object{error=string}	"Group not found or no transactions found."
+//	@Failure		500		{object}	object{error=string}	"Internal server error."
+//	@Router			/addresses/groups/{tag}/transactions [get]
+func GetTransactionsByAddressGroupHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tag := c.Params("tag")
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		addresses, err := db.Metadata().GetAddressesByTag(nil, tag)
+		if err != nil {
+			logger.Error("failed to get addresses for group", "tag", tag, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get transactions"})
+		}
+		if len(addresses) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No addresses found in the group"})
+		}
+
+		transactions, err := db.GetTxsByAnyAddresses(addresses, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get transactions for group", "tag", tag, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get transactions"})
+		}
+		if len(transactions) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No transactions found for the group"})
+		}
+
+		return c.JSON(viewmodel.ConvertTransactionsToViewModels(transactions))
+	}
+}
@@ -1,7 +1,6 @@
 package address_handlers
 
 import (
-	"encoding/hex"
 	"strconv"
 
 	"github.com/Andamio-Platform/andamio-indexer/database"
@@ -13,7 +12,7 @@ import (
 // GetTransactionsByAddressHandler handles the request to get transactions for a specific address.
 //
 //	@Summary		Get Transactions by Address
-//	@Description	Retrieves transactions associated with a specific address with pagination.
+//	@Description	Retrieves transactions associated with a specific address with pagination. With summary set, each transaction carries its direction for the address (sent, received, self or contract) and the net lovelace and asset change of the address.
 //	@ID				getTransactionsByAddress
 //	@Tags			Addresses
 //	@Security		ApiKeyAuth
@@ -22,7 +21,8 @@ import (
 //	@Param			address	path		string	true	"The address to retrieve transactions for."
 //	@Param			limit	query		int		false	"Maximum number of results to return."	default(100)
 //	@Param			offset	query		int		false	"Number of results to skip."	default(0)
-//	@Success		200		{array}		viewmodel.Transaction	"Successfully retrieved transactions."
+//	@Param			summary	query		bool	false	"Include a per-transaction summary for the address."	default(false)
+//	@Success		200		{array}		viewmodel.AddressTransaction	"Successfully retrieved transactions."
 //	@Failure		400		{object}	object{error=string}		"Invalid address or pagination parameters."
 //	@Failure		404		{object}	object{error=string}		"Address not found or no transactions found."
 //	@Failure		500		{object}	object{error=string}		"Internal server error."
@@ -50,6 +50,8 @@ func GetTransactionsByAddressHandler(db *database.Database) fiber.Handler {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid offset parameter"})
 		}
 
+		summary := c.QueryBool("summary", false)
+
 		// Use the database function with pagination
 		transactions, err := db.GetTxsByAnyAddress(address, limit, offset, nil)
 		if err != nil {
@@ -63,24 +65,18 @@ func GetTransactionsByAddressHandler(db *database.Database) fiber.Handler {
 		}
 
 		// Convert database models to view models
-		transactionViewModels := []viewmodel.Transaction{}
+		transactionViewModels := []viewmodel.AddressTransaction{}
 		for _, tx := range transactions {
-			transactionViewModels = append(transactionViewModels, viewmodel.Transaction{
-				TransactionHash: hex.EncodeToString(tx.TransactionHash),
-				BlockNumber:     tx.BlockNumber,
-				SlotNumber:      tx.SlotNumber,
-				Inputs:          viewmodel.ConvertTransactionInputsToViewModels(tx.Inputs),
-				Outputs:         viewmodel.ConvertTransactionOutputsToViewModels(tx.Outputs),
-				Fee:             tx.Fee,
-				TTL:             tx.TTL,
-				BlockHash:       string(tx.BlockHash),
-				Metadata:        hex.EncodeToString(tx.Metadata),
-				ReferenceInputs: viewmodel.ConvertSimpleUTxOModelsToViewModels(tx.ReferenceInputs),
-				Withdrawals:     tx.Withdrawals,
-				Certificates:    viewmodel.ConvertByteSliceSliceToStringSlice(tx.Certificates),
-				Witness:         viewmodel.ConvertWitnessModelToViewModel(tx.Witness),
-				TransactionCBOR: hex.EncodeToString(tx.TransactionCBOR),
-			})
+			transactionViewModel := viewmodel.AddressTransaction{Transaction: viewmodel.ConvertTransactionToViewModel(tx)}
+			if summary {
+				flow, err := db.TxValueFlow(tx, nil)
+				if err != nil {
+					fiberLogger.Errorf("Failed to get value flow of transaction %x: %v", tx.TransactionHash, err)
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get transaction summaries"})
+				}
+				transactionViewModel.Summary = viewmodel.ConvertAddressTxSummaryToViewModel(flow.AddressSummary(address))
+			}
+			transactionViewModels = append(transactionViewModels, transactionViewModel)
 		}
 
 		return c.JSON(transactionViewModels)
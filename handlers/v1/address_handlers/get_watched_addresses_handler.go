@@ -0,0 +1,45 @@
+package address_handlers
+
+import (
+	"log/slog"
+
+	database "github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetWatchedAddressesHandler handles the request to list the address watchlist.
+//
+//	@Summary		List Watched Addresses
+//	@Description	Lists the address watchlist with pagination, optionally filtered by tag (group), label and source.
+//	@ID				getWatchedAddresses
+//	@Tags			Addresses
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			tag		query		string	false	"Only return addresses in this group."
+//	@Param			label	query		string	false	"Only return addresses whose label contains this text."
+//	@Param			source	query		string	false	"Only return addresses added from this source."
+//	@Param			limit	query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset	query		int		false	"Number of results to skip."	default(0)
+//	@Success		200		{array}		viewmodel.WatchedAddress	"Successfully retrieved watched addresses."
+//	@Failure		400		{object}	object{error=string}		"Invalid pagination parameters."
+//	@Failure		500		{object}	object{error=string}		"Internal server error."
+//	@Router			/addresses [get]
+func GetWatchedAddressesHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		entries, err := db.Metadata().GetAddressEntries(nil, c.Query("tag"), c.Query("label"), c.Query("source"), limit, offset)
+		if err != nil {
+			logger.Error("failed to get watched addresses", "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to retrieve watched addresses"})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(viewmodel.ConvertAddressModelsToWatchedAddresses(entries))
+	}
+}
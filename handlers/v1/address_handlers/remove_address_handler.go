@@ -4,6 +4,7 @@ import (
 	"log/slog"
 
 	database "github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/indexer/cache"
 	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
 	"github.com/gofiber/fiber/v2"
 	fiberLogger "github.com/gofiber/fiber/v2/log"
@@ -52,6 +53,9 @@ func RemoveAddressHandler(db *database.Database, logger *slog.Logger) fiber.Hand
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to remove address"})
 		}
 
+		// Stop filtering on the removed address right away
+		cache.GetRelevantDataCache().LoadCache()
+
 		fiberLogger.Infof("address removed successfully: %s", addressRequest.Address)
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Address removed successfully"})
 	}
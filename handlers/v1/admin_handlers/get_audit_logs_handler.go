@@ -0,0 +1,48 @@
+package admin_handlers
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+)
+
+// GetAuditLogsHandler godoc
+// @Summary Get Audit Log
+// @Description Retrieves the audit log of changes made through admin endpoints, newest first, with support for pagination. Only the first 4096 bytes of each request body are recorded.
+// @ID getAuditLogs
+// @Tags Admin
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param api_key_id query int false "Only return entries made with this API key ID."
+// @Param limit query int false "Maximum number of results to return." default(100)
+// @Param offset query int false "Number of results to skip." default(0)
+// @Success 200 {array} viewmodel.AuditLog "Successfully retrieved audit log."
+// @Failure 400 {object} object{error=string} "Invalid pagination parameters."
+// @Failure 401 {object} object{error=string} "Missing or invalid API key."
+// @Failure 403 {object} object{error=string} "API key is not an admin key."
+// @Failure 500 {object} object{error=string} "Internal server error."
+// @Router /admin/audit-log [get]
+func GetAuditLogsHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		apiKeyID := c.QueryInt("api_key_id", 0)
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+
+		if apiKeyID < 0 || limit < 0 || offset < 0 {
+			logger.Error("invalid query parameters", "api_key_id", apiKeyID, "limit", limit, "offset", offset)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid query parameters"})
+		}
+
+		entries, err := db.Metadata().GetAuditLogs(nil, uint(apiKeyID), limit, offset)
+		if err != nil {
+			logger.Error("failed to get audit log", "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to retrieve audit log"})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(viewmodel.ConvertAuditLogModelsToViewModels(entries))
+	}
+}
@@ -0,0 +1,50 @@
+package asset_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetAssetHistoryByAssetFingerprintHandler handles the GET /api/v1/indexer/assets/fingerprint/{asset_fingerprint}/history endpoint.
+// @Summary		Get Asset History by Asset Fingerprint
+// @Description	Retrieves the transfers of an asset, oldest first: for each transaction that moved, minted or burned it, the addresses it came from and went to, the quantity moved and whether it was minted or burned.
+// @ID				getAssetHistoryByAssetFingerprint
+// @Tags			Assets
+// @Security		ApiKeyAuth
+// @Accept			json
+// @Produce		json
+// @Param			asset_fingerprint	path		string	true	"The asset fingerprint to retrieve the history for."
+// @Param			limit				query		int		false	"Maximum number of results to return."	default(100)
+// @Param			offset				query		int		false	"Number of results to skip."	default(0)
+// @Success		200					{array}		viewmodel.AssetTransfer	"Successfully retrieved the asset history."
+// @Failure		400					{object}	object{error=string}		"Missing asset fingerprint or invalid pagination parameters."
+// @Failure		404					{object}	object{error=string}		"No transfers found."
+// @Failure		500					{object}	object{error=string}		"Internal server error."
+// @Router			/assets/fingerprint/{asset_fingerprint}/history [get]
+func GetAssetHistoryByAssetFingerprintHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		assetFingerprint := c.Params("asset_fingerprint")
+		if assetFingerprint == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "asset_fingerprint path parameter is missing"})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		transfers, err := db.GetAssetHistory([]byte(assetFingerprint), limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get asset history by asset fingerprint", "asset_fingerprint", assetFingerprint, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get asset history"})
+		}
+		if len(transfers) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "no transfers found for the given asset fingerprint"})
+		}
+
+		return c.JSON(viewmodel.ConvertAssetTransfersToViewModels(transfers))
+	}
+}
@@ -0,0 +1,50 @@
+package asset_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetAssetsByPolicyIdHandler handles the GET /api/v1/indexer/assets/policy/{policyId}/assets endpoint.
+// @Summary		Get Assets by Policy ID
+// @Description	Lists the asset names under a policy held in unspent outputs, with the quantity held and the number of holding addresses.
+// @ID				getAssetsByPolicyId
+// @Tags			Assets
+// @Security		ApiKeyAuth
+// @Accept			json
+// @Produce		json
+// @Param			policyId	path		string	true	"The policy ID to list the assets of (hex-encoded)."
+// @Param			limit		query		int		false	"Maximum number of results to return."	default(100)
+// @Param			offset		query		int		false	"Number of results to skip."	default(0)
+// @Success		200		{array}		viewmodel.PolicyAsset	"Successfully retrieved the assets."
+// @Failure		400		{object}	object{error=string}		"Invalid policy ID or pagination parameters."
+// @Failure		404		{object}	object{error=string}		"No assets found."
+// @Failure		500		{object}	object{error=string}		"Internal server error."
+// @Router			/assets/policy/{policyId}/assets [get]
+func GetAssetsByPolicyIdHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		request := viewmodel.PolicyRequest{PolicyID: c.Params("policyId")}
+		if err := request.IsValid(); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		policyAssets, err := db.Metadata().GetPolicyAssets(nil, []byte(request.PolicyID), limit, offset)
+		if err != nil {
+			logger.Error("failed to get assets by policy ID", "policyId", request.PolicyID, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get assets"})
+		}
+		if len(policyAssets) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "no assets found for the given policy ID"})
+		}
+
+		return c.JSON(viewmodel.ConvertPolicyAssetModelsToViewModels(request.PolicyID, policyAssets))
+	}
+}
@@ -0,0 +1,50 @@
+package asset_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetHoldersByAssetFingerprintHandler handles the GET /api/v1/indexer/assets/fingerprint/{asset_fingerprint}/holders endpoint.
+// @Summary		Get Holders by Asset Fingerprint
+// @Description	Retrieves the addresses currently holding an asset in unspent outputs, largest holders first.
+// @ID				getHoldersByAssetFingerprint
+// @Tags			Assets
+// @Security		ApiKeyAuth
+// @Accept			json
+// @Produce		json
+// @Param			asset_fingerprint	path		string	true	"The asset fingerprint to retrieve the holders of."
+// @Param			limit				query		int		false	"Maximum number of results to return."	default(100)
+// @Param			offset				query		int		false	"Number of results to skip."	default(0)
+// @Success		200					{array}		viewmodel.AssetHolder	"Successfully retrieved the holders."
+// @Failure		400					{object}	object{error=string}		"Missing asset fingerprint or invalid pagination parameters."
+// @Failure		404					{object}	object{error=string}		"No holders found."
+// @Failure		500					{object}	object{error=string}		"Internal server error."
+// @Router			/assets/fingerprint/{asset_fingerprint}/holders [get]
+func GetHoldersByAssetFingerprintHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		assetFingerprint := c.Params("asset_fingerprint")
+		if assetFingerprint == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "asset_fingerprint path parameter is missing"})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		holders, err := db.Metadata().GetAssetHolders(nil, []byte(assetFingerprint), limit, offset)
+		if err != nil {
+			logger.Error("failed to get holders by asset fingerprint", "asset_fingerprint", assetFingerprint, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get holders"})
+		}
+		if len(holders) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "no holders found for the given asset fingerprint"})
+		}
+
+		return c.JSON(viewmodel.ConvertAssetHolderModelsToViewModels(holders))
+	}
+}
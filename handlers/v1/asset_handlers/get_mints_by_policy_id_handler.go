@@ -0,0 +1,46 @@
+package asset_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetMintsByPolicyIdHandler handles the GET /api/v1/indexer/assets/policy/{policyId}/mints endpoint.
+// @Summary		Get Mint History by Policy ID
+// @Description	Retrieves the mints and burns of a policy in indexed transactions, newest first, with the mint redeemer where the policy is a Plutus script. Burns have a negative quantity.
+// @ID				getMintsByPolicyId
+// @Tags			Assets
+// @Security		ApiKeyAuth
+// @Accept			json
+// @Produce		json
+// @Param			policyId	path		string	true	"The policy ID to retrieve mints for (hex-encoded)."
+// @Param			limit	query		int		false	"Maximum number of results to return."	default(100)
+// @Param			offset	query		int		false	"Number of results to skip."	default(0)
+// @Success		200		{array}		viewmodel.Mint	"Successfully retrieved mints."
+// @Failure		400		{object}	object{error=string}		"Invalid policy ID or pagination parameters."
+// @Failure		500		{object}	object{error=string}		"Internal server error."
+// @Router			/assets/policy/{policyId}/mints [get]
+func GetMintsByPolicyIdHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		request := viewmodel.PolicyRequest{PolicyID: c.Params("policyId")}
+		if err := request.IsValid(); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		mints, err := db.Metadata().GetMintsByPolicyId(nil, []byte(request.PolicyID), limit, offset)
+		if err != nil {
+			logger.Error("failed to get mints by policy ID", "policyId", request.PolicyID, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get mints"})
+		}
+
+		return c.JSON(viewmodel.ConvertMintModelsToViewModels(mints))
+	}
+}
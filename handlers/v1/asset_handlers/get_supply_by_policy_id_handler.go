@@ -0,0 +1,39 @@
+package asset_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetSupplyByPolicyIdHandler handles the GET /api/v1/indexer/assets/policy/{policyId}/supply endpoint.
+// @Summary		Get Supply by Policy ID
+// @Description	Retrieves the circulating supply of each asset name under a policy, computed from the mints and burns in indexed transactions.
+// @ID				getSupplyByPolicyId
+// @Tags			Assets
+// @Security		ApiKeyAuth
+// @Accept			json
+// @Produce		json
+// @Param			policyId	path		string	true	"The policy ID to retrieve the supply for (hex-encoded)."
+// @Success		200		{array}		viewmodel.AssetSupply	"Successfully retrieved the supply."
+// @Failure		400		{object}	object{error=string}		"Invalid policy ID."
+// @Failure		500		{object}	object{error=string}		"Internal server error."
+// @Router			/assets/policy/{policyId}/supply [get]
+func GetSupplyByPolicyIdHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		request := viewmodel.PolicyRequest{PolicyID: c.Params("policyId")}
+		if err := request.IsValid(); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		supplies, err := db.Metadata().GetAssetSupplyByPolicyId(nil, []byte(request.PolicyID))
+		if err != nil {
+			logger.Error("failed to get supply by policy ID", "policyId", request.PolicyID, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get supply"})
+		}
+
+		return c.JSON(viewmodel.ConvertAssetSupplyModelsToViewModels(request.PolicyID, supplies))
+	}
+}
@@ -0,0 +1,46 @@
+package asset_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetTokenMetadataByAssetFingerprintHandler handles the GET /api/v1/indexer/assets/fingerprint/{asset_fingerprint}/metadata endpoint.
+// @Summary		Get Token Metadata by Asset Fingerprint
+// @Description	Retrieves the latest CIP-25 or CIP-68 metadata resolved for an asset. CIP-68 user tokens (labels 222, 333 and 444) resolve to the datum of their reference token (label 100).
+// @ID				getTokenMetadataByAssetFingerprint
+// @Tags			Assets
+// @Security		ApiKeyAuth
+// @Accept			json
+// @Produce		json
+// @Param			asset_fingerprint	path		string	true	"The asset fingerprint to retrieve metadata for."
+// @Success		200					{object}	viewmodel.TokenMetadata	"Successfully retrieved the token metadata."
+// @Failure		400					{object}	object{error=string}		"Missing asset fingerprint."
+// @Failure		404					{object}	object{error=string}		"Asset not found or no metadata resolved."
+// @Failure		500					{object}	object{error=string}		"Internal server error."
+// @Router			/assets/fingerprint/{asset_fingerprint}/metadata [get]
+func GetTokenMetadataByAssetFingerprintHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		assetFingerprint := c.Params("asset_fingerprint")
+		if assetFingerprint == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "asset_fingerprint path parameter is missing"})
+		}
+
+		asset, err := db.GetTokenMetadataByFingerprint([]byte(assetFingerprint), nil)
+		if err != nil {
+			logger.Error("failed to get token metadata by asset fingerprint", "asset_fingerprint", assetFingerprint, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get token metadata"})
+		}
+		if asset == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "asset not found"})
+		}
+		if asset.Metadata == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "no token metadata found for the given asset fingerprint"})
+		}
+
+		return c.JSON(viewmodel.ConvertTokenMetadataToViewModel(assetFingerprint, asset))
+	}
+}
@@ -1,7 +1,6 @@
 package asset_handlers
 
 import (
-	"encoding/hex"
 	"strconv"
 
 	"github.com/Andamio-Platform/andamio-indexer/database"
@@ -50,22 +49,7 @@ func GetTransactionsByTokenNameHandler(db *database.Database) fiber.Handler {
 
 		transactionViewModels := []viewmodel.Transaction{}
 		for _, tx := range transactions {
-			transactionViewModels = append(transactionViewModels, viewmodel.Transaction{
-				TransactionHash: hex.EncodeToString(tx.TransactionHash),
-				BlockNumber:     tx.BlockNumber,
-				SlotNumber:      tx.SlotNumber,
-				Inputs:          viewmodel.ConvertTransactionInputsToViewModels(tx.Inputs),
-				Outputs:         viewmodel.ConvertTransactionOutputsToViewModels(tx.Outputs),
-				Fee:             tx.Fee,
-				TTL:             tx.TTL,
-				BlockHash:       string(tx.BlockHash),
-				Metadata:        hex.EncodeToString(tx.Metadata),
-				ReferenceInputs: viewmodel.ConvertSimpleUTxOModelsToViewModels(tx.ReferenceInputs),
-				Withdrawals:     tx.Withdrawals,
-				Certificates:    viewmodel.ConvertByteSliceSliceToStringSlice(tx.Certificates),
-				Witness:         viewmodel.ConvertWitnessModelToViewModel(tx.Witness),
-				TransactionCBOR: hex.EncodeToString(tx.TransactionCBOR),
-			})
+			transactionViewModels = append(transactionViewModels, viewmodel.ConvertTransactionToViewModel(tx))
 		}
 
 		return c.JSON(transactionViewModels)
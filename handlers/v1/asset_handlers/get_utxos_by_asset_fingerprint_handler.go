@@ -6,12 +6,13 @@ import (
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/middleware"
 	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
 )
 
 // GetUTxOsByAssetFingerprintHandler godoc
 // @Summary Get UTxOs by Asset Fingerprint
-// @Description Retrieve a list of UTxOs containing a specific asset fingerprint, with support for pagination.
+// @Description Retrieve the unspent outputs holding a specific asset fingerprint and the inputs of valid transactions that spent it, with support for pagination.
 // @ID getUTxOsByAssetFingerprint
 // @Tags Assets
 // @Security ApiKeyAuth
@@ -66,6 +67,7 @@ func GetUTxOsByAssetFingerprintHandler(db *database.Database, logger *slog.Logge
 			Outputs: outputViewModels,
 		}
 
+		middleware.SetRowCount(c, len(inputs)+len(outputs))
 		return c.Status(fiber.StatusOK).JSON(transactionUTxOs)
 	}
 }
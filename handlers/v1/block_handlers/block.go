@@ -0,0 +1,30 @@
+package block_handlers
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+)
+
+// blockHashSize is the size of a block hash
+const blockHashSize = 32
+
+// errInvalidBlock is returned for a block that is neither a block number nor a block hash
+var errInvalidBlock = errors.New("block must be a block number or a hex encoded 32 byte hash")
+
+// getBlock retrieves a block by its number or its hex encoded hash, or nil when it was not seen
+func getBlock(db *database.Database, numberOrHash string) (*models.Block, error) {
+	if blockNumber, err := strconv.ParseUint(numberOrHash, 10, 64); err == nil {
+		return db.Metadata().GetBlockByNumber(nil, blockNumber)
+	}
+	blockHash := strings.ToLower(numberOrHash)
+	if decoded, err := hex.DecodeString(blockHash); err != nil || len(decoded) != blockHashSize {
+		return nil, errInvalidBlock
+	}
+	// Block hashes are stored hex encoded
+	return db.Metadata().GetBlockByHash(nil, []byte(blockHash))
+}
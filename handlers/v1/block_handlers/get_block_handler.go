@@ -0,0 +1,43 @@
+package block_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetBlockHandler handles the request to get a block by its number or hash.
+//
+//	@Summary		Get Block
+//	@Description	Retrieves a block seen on chain sync by its number or its hex encoded hash.
+//	@ID				getBlock
+//	@Tags			Blocks
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			number_or_hash	path		string	true	"Block number or hex encoded block hash."
+//	@Success		200				{object}	viewmodel.Block			"Successfully retrieved the block."
+//	@Failure		400				{object}	object{error=string}	"Invalid block number or hash."
+//	@Failure		404				{object}	object{error=string}	"Block not found."
+//	@Failure		500				{object}	object{error=string}	"Internal server error."
+//	@Router			/blocks/{number_or_hash} [get]
+func GetBlockHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		numberOrHash := c.Params("number_or_hash")
+		block, err := getBlock(db, numberOrHash)
+		if err == errInvalidBlock {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		if err != nil {
+			logger.Error("failed to get block", "block", numberOrHash, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get block"})
+		}
+		if block == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Block not found"})
+		}
+
+		return c.JSON(viewmodel.ConvertBlockModelToViewModel(block))
+	}
+}
@@ -0,0 +1,37 @@
+package block_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetLatestBlockHandler handles the request to get the latest block.
+//
+//	@Summary		Get Latest Block
+//	@Description	Retrieves the most recent block seen on chain sync, whether or not any of its transactions was indexed.
+//	@ID				getLatestChainBlock
+//	@Tags			Blocks
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	viewmodel.Block			"Successfully retrieved the latest block."
+//	@Failure		404	{object}	object{error=string}	"No block seen yet."
+//	@Failure		500	{object}	object{error=string}	"Internal server error."
+//	@Router			/blocks/latest [get]
+func GetLatestBlockHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		block, err := db.Metadata().GetLatestBlock(nil)
+		if err != nil {
+			logger.Error("failed to get latest block", "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get latest block"})
+		}
+		if block == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No block seen yet"})
+		}
+
+		return c.JSON(viewmodel.ConvertBlockModelToViewModel(block))
+	}
+}
@@ -0,0 +1,65 @@
+package block_handlers
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetTransactionsByBlockHandler handles the request to get the indexed transactions of a block.
+//
+//	@Summary		Get Transactions by Block
+//	@Description	Retrieves the indexed transactions of a block with pagination. A block seen on chain sync without relevant transactions has none.
+//	@ID				getTransactionsByBlock
+//	@Tags			Blocks
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			number	path		int		true	"Block number."
+//	@Param			limit	query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset	query		int		false	"Number of results to skip."			default(0)
+//	@Success		200		{array}		viewmodel.Transaction	"Successfully retrieved transactions."
+//	@Failure		400		{object}	object{error=string}	"Invalid block number or pagination parameters."
+//	@Failure		404		{object}	object{error=string}	"Block not found."
+//	@Failure		500		{object}	object{error=string}	"Internal server error."
+//	@Router			/blocks/{number}/transactions [get]
+func GetTransactionsByBlockHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		blockNumber, err := strconv.ParseUint(c.Params("number"), 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid block number"})
+		}
+
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		transactions, err := db.GetTxsByBlockNumber(blockNumber, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get transactions by block", "block_number", blockNumber, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get transactions"})
+		}
+		if len(transactions) == 0 {
+			// Blocks without relevant transactions are only known from the blocks table
+			block, err := db.Metadata().GetBlockByNumber(nil, blockNumber)
+			if err != nil {
+				logger.Error("failed to get block", "block_number", blockNumber, "error", err)
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get block"})
+			}
+			if block == nil {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Block not found"})
+			}
+		}
+
+		transactionViewModels := []viewmodel.Transaction{}
+		for _, tx := range transactions {
+			transactionViewModels = append(transactionViewModels, viewmodel.ConvertTransactionToViewModel(tx))
+		}
+		return c.JSON(transactionViewModels)
+	}
+}
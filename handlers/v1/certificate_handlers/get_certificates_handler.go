@@ -0,0 +1,99 @@
+package certificate_handlers
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strings"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// certificateTypeDelegation selects all certificate types that delegate stake or votes
+const certificateTypeDelegation = "delegation"
+
+// GetCertificatesHandler handles the request to query decoded certificates.
+//
+//	@Summary		Get Certificates
+//	@Description	Retrieves decoded certificates by the stake credential, pool or DRep they act on and/or by type, newest first, with pagination.
+//	@ID				getCertificates
+//	@Tags			Certificates
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			stake_credential	query		string	false	"Bech32 stake address or hex encoded stake credential hash."
+//	@Param			pool				query		string	false	"Hex encoded pool key hash."
+//	@Param			drep				query		string	false	"Hex encoded DRep credential hash."
+//	@Param			type				query		string	false	"Comma separated certificate types, or delegation for all delegation types."
+//	@Param			limit				query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset				query		int		false	"Number of results to skip."	default(0)
+//	@Success		200					{array}		viewmodel.Certificate	"Successfully retrieved certificates."
+//	@Failure		400					{object}	object{error=string}	"Missing or invalid filters, or invalid pagination parameters."
+//	@Failure		404					{object}	object{error=string}	"No certificates found."
+//	@Failure		500					{object}	object{error=string}	"Internal server error."
+//	@Router			/certificates [get]
+func GetCertificatesHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		stakeCredentialStr := strings.ToLower(c.Query("stake_credential"))
+		poolHex := strings.ToLower(c.Query("pool"))
+		drepHex := strings.ToLower(c.Query("drep"))
+		typeStr := strings.ToLower(c.Query("type"))
+		if stakeCredentialStr == "" && poolHex == "" && drepHex == "" && typeStr == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "stake_credential, pool, drep or type is required"})
+		}
+
+		var stakeCredential, poolKeyHash, drepCredential []byte
+		if stakeCredentialStr != "" {
+			var err error
+			stakeCredential, err = credential.ParseStakeCredential(stakeCredentialStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "stake_credential " + err.Error()})
+			}
+		}
+		if poolHex != "" {
+			if !credential.IsCredentialHash(poolHex) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "pool must be a hex encoded 28 byte hash"})
+			}
+			poolKeyHash, _ = hex.DecodeString(poolHex)
+		}
+		if drepHex != "" {
+			if !credential.IsCredentialHash(drepHex) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "drep must be a hex encoded 28 byte hash"})
+			}
+			drepCredential, _ = hex.DecodeString(drepHex)
+		}
+		var certTypes []string
+		if typeStr != "" {
+			for _, certType := range strings.Split(typeStr, ",") {
+				certType = strings.TrimSpace(certType)
+				switch {
+				case certType == certificateTypeDelegation:
+					certTypes = append(certTypes, models.CertificateDelegationTypes...)
+				case models.IsCertificateType(certType):
+					certTypes = append(certTypes, certType)
+				default:
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "unknown certificate type: " + certType})
+				}
+			}
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		certificates, err := db.GetCertificates(stakeCredential, poolKeyHash, drepCredential, certTypes, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get certificates", "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get certificates"})
+		}
+		if len(certificates) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No certificates found"})
+		}
+
+		return c.JSON(viewmodel.ConvertCertificatesToViewModels(certificates))
+	}
+}
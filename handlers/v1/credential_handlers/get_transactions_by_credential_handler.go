@@ -0,0 +1,52 @@
+package credential_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetTransactionsByCredentialHandler handles the request to get transactions for a payment credential.
+//
+//	@Summary		Get Transactions by Payment Credential
+//	@Description	Retrieves transactions in which any address with the payment key hash or script hash appears as input or output, whatever its stake part, with pagination.
+//	@ID				getTransactionsByCredential
+//	@Tags			Credentials
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			credential	path		string	true	"Hex encoded payment key hash or script hash, or an address to take the payment part from."
+//	@Param			limit		query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset		query		int		false	"Number of results to skip."	default(0)
+//	@Success		200			{array}		viewmodel.Transaction	"Successfully retrieved transactions."
+//	@Failure		400			{object}	object{error=string}	"Invalid credential or pagination parameters."
+//	@Failure		404			{object}	object{error=string}	"No transactions found."
+//	@Failure		500			{object}	object{error=string}	"Internal server error."
+//	@Router			/credentials/{credential}/transactions [get]
+func GetTransactionsByCredentialHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		paymentCredential, err := credential.ParsePaymentCredential(c.Params("credential"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		transactions, err := db.GetTxsByPaymentCredential(paymentCredential, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get transactions for credential", "credential", c.Params("credential"), "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get transactions"})
+		}
+		if len(transactions) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No transactions found for the credential"})
+		}
+
+		return c.JSON(viewmodel.ConvertTransactionsToViewModels(transactions))
+	}
+}
@@ -0,0 +1,48 @@
+package credential_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetUTxOsByCredentialHandler handles the request to get the unspent outputs locked by a payment credential.
+//
+//	@Summary		Get UTxOs by Payment Credential
+//	@Description	Retrieves the indexed outputs locked by the payment key hash or script hash, whatever their stake part, that have not been spent by an indexed transaction, with pagination.
+//	@ID				getUTxOsByCredential
+//	@Tags			Credentials
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			credential	path		string	true	"Hex encoded payment key hash or script hash, or an address to take the payment part from."
+//	@Param			limit		query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset		query		int		false	"Number of results to skip."	default(0)
+//	@Success		200			{array}		viewmodel.TransactionOutput	"Successfully retrieved UTxOs."
+//	@Failure		400			{object}	object{error=string}		"Invalid credential or pagination parameters."
+//	@Failure		500			{object}	object{error=string}		"Internal server error."
+//	@Router			/credentials/{credential}/utxos [get]
+func GetUTxOsByCredentialHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		paymentCredential, err := credential.ParsePaymentCredential(c.Params("credential"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		outputs, err := db.GetUnspentOutputsByPaymentCredential(paymentCredential, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get UTxOs for credential", "credential", c.Params("credential"), "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get UTxOs"})
+		}
+
+		return c.JSON(viewmodel.ConvertTransactionOutputsToViewModels(outputs))
+	}
+}
@@ -0,0 +1,50 @@
+package datum_handlers
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strings"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// datumHashSize is the size of a Blake2b-256 datum hash
+const datumHashSize = 32
+
+// GetDatumByHashHandler handles the request to get a datum by its hash.
+//
+//	@Summary		Get Datum by Hash
+//	@Description	Retrieves a datum by its hash. Datums are stored once per hash, from inline output datums and from the witness sets that resolve hash-only outputs.
+//	@ID				getDatumByHash
+//	@Tags			Datums
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			datum_hash	path		string	true	"Hex encoded datum hash."
+//	@Success		200			{object}	viewmodel.DatumByHash	"Successfully retrieved datum."
+//	@Failure		400			{object}	object{error=string}	"Invalid datum hash."
+//	@Failure		404			{object}	object{error=string}	"Datum not found."
+//	@Failure		500			{object}	object{error=string}	"Internal server error."
+//	@Router			/datums/{datum_hash} [get]
+func GetDatumByHashHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		datumHashHex := strings.ToLower(c.Params("datum_hash"))
+		datumHash, err := hex.DecodeString(datumHashHex)
+		if err != nil || len(datumHash) != datumHashSize {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "datum_hash must be a hex encoded 32 byte hash"})
+		}
+
+		datum, err := db.GetDatumByHash(datumHash, nil)
+		if err != nil {
+			logger.Error("failed to get datum", "datum_hash", datumHashHex, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get datum"})
+		}
+		if datum == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Datum not found"})
+		}
+
+		return c.JSON(viewmodel.ConvertDatumByHashToViewModel(datum))
+	}
+}
@@ -0,0 +1,51 @@
+package fingerprint_handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AddFingerprintHandler godoc
+// @Summary Add Fingerprint
+// @Description Adds a asset fingerprint to the watchlist. The running filter starts indexing transactions that move matching assets right away.
+// @ID addFingerprint
+// @Tags Fingerprints
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param fingerprint body viewmodel.FingerprintRequest true "The asset fingerprint to add."
+// @Success 201 {object} object{message=string} "Successfully added asset fingerprint."
+// @Failure 400 {object} object{error=string} "Invalid request body or asset fingerprint."
+// @Failure 409 {object} object{error=string} "Asset fingerprint is already on the watchlist."
+// @Failure 500 {object} object{error=string} "Internal server error."
+// @Router /fingerprints [post]
+func AddFingerprintHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		request := new(viewmodel.FingerprintRequest)
+		if err := c.BodyParser(request); err != nil {
+			logger.Error("failed to parse request body", "error", err)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+		if err := request.IsValid(); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		err := db.Metadata().AddFingerprint(nil, &models.Fingerprint{Fingerprint: request.Fingerprint, Label: request.Label})
+		if errors.Is(err, models.ErrFingerprintAlreadyWatched) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Asset fingerprint is already on the watchlist"})
+		}
+		if err != nil {
+			logger.Error("failed to add asset fingerprint", "fingerprint", request.Fingerprint, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to add asset fingerprint"})
+		}
+		invalidateFingerprintsCache()
+
+		logger.Info("asset fingerprint added", "fingerprint", request.Fingerprint)
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Asset fingerprint added successfully"})
+	}
+}
@@ -0,0 +1,83 @@
+package fingerprint_handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Andamio-Platform/andamio-indexer/config"
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/indexer/cache"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+const testFingerprint = "asset13n25uv0yaf5kus35fm2k86cqy60z58d9xmde92"
+
+func TestFingerprintHandlers(t *testing.T) {
+	db, err := database.New(nil, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() {
+		db.Close() //nolint:errcheck
+	})
+	if config.GlobalConfig == nil {
+		config.GlobalConfig = &config.Config{}
+	}
+	database.SetGlobalDB(db)
+	app := fiber.New()
+	app.Get("/fingerprints", GetFingerprintsHandler(db, slog.Default()))
+	app.Post("/fingerprints", AddFingerprintHandler(db, slog.Default()))
+	app.Delete("/fingerprints/:fingerprint", RemoveFingerprintHandler(db, slog.Default()))
+	request := func(method string, path string, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return resp.StatusCode
+	}
+
+	for _, body := range []string{`{"fingerprint": ""}`, `{"fingerprint": "asset1invalid"}`, `{"fingerprint":`} {
+		if status := request(fiber.MethodPost, "/fingerprints", body); status != fiber.StatusBadRequest {
+			t.Fatalf("expected status %d for %s, got %d", fiber.StatusBadRequest, body, status)
+		}
+	}
+	// Fingerprints are matched in lower case
+	body := `{"fingerprint": " ` + strings.ToUpper(testFingerprint) + `", "label": "test"}`
+	if status := request(fiber.MethodPost, "/fingerprints", body); status != fiber.StatusCreated {
+		t.Fatalf("expected status %d, got %d", fiber.StatusCreated, status)
+	}
+	if !cache.GetRelevantDataCache().IsWatchedFingerprint(testFingerprint) {
+		t.Fatalf("expected the added fingerprint to be watched")
+	}
+	if status := request(fiber.MethodPost, "/fingerprints", body); status != fiber.StatusConflict {
+		t.Fatalf("expected status %d, got %d", fiber.StatusConflict, status)
+	}
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/fingerprints", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var fingerprints []viewmodel.WatchedFingerprint
+	if err := json.NewDecoder(resp.Body).Decode(&fingerprints); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(fingerprints) != 1 || fingerprints[0].Fingerprint != testFingerprint || fingerprints[0].Label != "test" {
+		t.Fatalf("unexpected fingerprints: %+v", fingerprints)
+	}
+
+	if status := request(fiber.MethodDelete, "/fingerprints/"+strings.ToUpper(testFingerprint), ""); status != fiber.StatusOK {
+		t.Fatalf("expected status %d, got %d", fiber.StatusOK, status)
+	}
+	if cache.GetRelevantDataCache().IsWatchedFingerprint(testFingerprint) {
+		t.Fatalf("expected the removed fingerprint not to be watched")
+	}
+	if status := request(fiber.MethodDelete, "/fingerprints/"+testFingerprint, ""); status != fiber.StatusNotFound {
+		t.Fatalf("expected status %d, got %d", fiber.StatusNotFound, status)
+	}
+}
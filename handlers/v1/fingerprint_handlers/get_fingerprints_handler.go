@@ -0,0 +1,38 @@
+package fingerprint_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/indexer/cache"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetFingerprintsHandler godoc
+// @Summary List Watched Fingerprints
+// @Description Lists the asset fingerprints added to the watchlist at runtime. Asset fingerprints from the config are always watched and are not listed.
+// @ID getWatchedFingerprints
+// @Tags Fingerprints
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Success 200 {array} viewmodel.WatchedFingerprint "Successfully retrieved watched asset fingerprints."
+// @Failure 500 {object} object{error=string} "Internal server error."
+// @Router /fingerprints [get]
+func GetFingerprintsHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		entries, err := db.Metadata().GetFingerprints(nil)
+		if err != nil {
+			logger.Error("failed to get watched asset fingerprints", "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to retrieve watched asset fingerprints"})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(viewmodel.ConvertFingerprintModelsToViewModels(entries))
+	}
+}
+
+// invalidateFingerprintsCache makes the running filter pick up watchlist changes right away
+func invalidateFingerprintsCache() {
+	cache.GetRelevantDataCache().LoadCache()
+}
@@ -0,0 +1,43 @@
+package fingerprint_handlers
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// RemoveFingerprintHandler godoc
+// @Summary Remove Fingerprint
+// @Description Removes a asset fingerprint from the watchlist. Asset fingerprints from the config cannot be removed.
+// @ID removeFingerprint
+// @Tags Fingerprints
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param fingerprint path string true "The asset fingerprint to remove."
+// @Success 200 {object} object{message=string} "Successfully removed asset fingerprint."
+// @Failure 404 {object} object{error=string} "Asset fingerprint is not on the watchlist."
+// @Failure 500 {object} object{error=string} "Internal server error."
+// @Router /fingerprints/{fingerprint} [delete]
+func RemoveFingerprintHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		fingerprint := strings.ToLower(c.Params("fingerprint"))
+
+		err := db.Metadata().RemoveFingerprint(nil, fingerprint)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Asset fingerprint is not on the watchlist"})
+		}
+		if err != nil {
+			logger.Error("failed to remove asset fingerprint", "fingerprint", fingerprint, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to remove asset fingerprint"})
+		}
+		invalidateFingerprintsCache()
+
+		logger.Info("asset fingerprint removed", "fingerprint", fingerprint)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Asset fingerprint removed successfully"})
+	}
+}
@@ -0,0 +1,57 @@
+package governance_handlers
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetGovernanceProposalsHandler handles the request to list governance proposals.
+//
+//	@Summary		Get Governance Proposals
+//	@Description	Retrieves governance actions proposed by indexed transactions, newest first, optionally only those whose deposit returns to a stake credential, with pagination.
+//	@ID				getGovernanceProposals
+//	@Tags			Governance
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			credential	query		string	false	"Bech32 stake address or hex encoded stake credential hash of the deposit return account."
+//	@Param			limit		query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset		query		int		false	"Number of results to skip."	default(0)
+//	@Success		200			{array}		viewmodel.GovernanceProposal	"Successfully retrieved governance proposals."
+//	@Failure		400			{object}	object{error=string}			"Invalid credential or pagination parameters."
+//	@Failure		404			{object}	object{error=string}			"No governance proposals found."
+//	@Failure		500			{object}	object{error=string}			"Internal server error."
+//	@Router			/governance/proposals [get]
+func GetGovernanceProposalsHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var rewardCredential []byte
+		if credentialStr := c.Query("credential"); credentialStr != "" {
+			var err error
+			rewardCredential, err = credential.ParseStakeCredential(strings.ToLower(credentialStr))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "credential " + err.Error()})
+			}
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		proposals, err := db.GetGovernanceProposals(rewardCredential, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get governance proposals", "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get governance proposals"})
+		}
+		if len(proposals) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No governance proposals found"})
+		}
+
+		return c.JSON(viewmodel.ConvertGovernanceProposalsToViewModels(proposals))
+	}
+}
@@ -0,0 +1,58 @@
+package governance_handlers
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetVotesByProposalHandler handles the request to get the votes cast on a governance action.
+//
+//	@Summary		Get Votes by Governance Action
+//	@Description	Retrieves the votes indexed transactions cast on the governance action identified by its proposal transaction hash and index, with pagination.
+//	@ID				getVotesByProposal
+//	@Tags			Governance
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			tx_hash	path		string	true	"Hex encoded hash of the proposing transaction."
+//	@Param			index	path		int		true	"Index of the proposal within the transaction."
+//	@Param			limit	query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset	query		int		false	"Number of results to skip."	default(0)
+//	@Success		200		{array}		viewmodel.GovernanceVote	"Successfully retrieved votes."
+//	@Failure		400		{object}	object{error=string}		"Invalid governance action or pagination parameters."
+//	@Failure		404		{object}	object{error=string}		"No votes found."
+//	@Failure		500		{object}	object{error=string}		"Internal server error."
+//	@Router			/governance/proposals/{tx_hash}/{index}/votes [get]
+func GetVotesByProposalHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		txHash, err := hex.DecodeString(c.Params("tx_hash"))
+		if err != nil || len(txHash) != 32 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "tx_hash must be a hex encoded 32 byte hash"})
+		}
+		index, err := strconv.ParseUint(c.Params("index"), 10, 32)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid proposal index"})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		votes, err := db.GetGovernanceVotes(nil, txHash, uint32(index), limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get votes for governance action", "tx_hash", c.Params("tx_hash"), "index", index, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get votes"})
+		}
+		if len(votes) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No votes found for the governance action"})
+		}
+
+		return c.JSON(viewmodel.ConvertGovernanceVotesToViewModels(votes))
+	}
+}
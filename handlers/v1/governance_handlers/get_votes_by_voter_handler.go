@@ -0,0 +1,55 @@
+package governance_handlers
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strings"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetVotesByVoterHandler handles the request to get the votes cast by a voter.
+//
+//	@Summary		Get Votes by Voter
+//	@Description	Retrieves the votes cast by a committee member, DRep or stake pool, identified by its key hash, script hash or pool id hash, newest first, with pagination.
+//	@ID				getVotesByVoter
+//	@Tags			Governance
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			voter	query		string	true	"Hex encoded 28 byte voter credential hash."
+//	@Param			limit	query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset	query		int		false	"Number of results to skip."	default(0)
+//	@Success		200		{array}		viewmodel.GovernanceVote	"Successfully retrieved votes."
+//	@Failure		400		{object}	object{error=string}		"Invalid voter or pagination parameters."
+//	@Failure		404		{object}	object{error=string}		"No votes found."
+//	@Failure		500		{object}	object{error=string}		"Internal server error."
+//	@Router			/governance/votes [get]
+func GetVotesByVoterHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		voterHex := strings.ToLower(c.Query("voter"))
+		if !credential.IsCredentialHash(voterHex) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "voter must be a hex encoded 28 byte hash"})
+		}
+		voterHash, _ := hex.DecodeString(voterHex)
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		votes, err := db.GetGovernanceVotes(voterHash, nil, 0, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get votes for voter", "voter", voterHex, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get votes"})
+		}
+		if len(votes) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No votes found for the voter"})
+		}
+
+		return c.JSON(viewmodel.ConvertGovernanceVotesToViewModels(votes))
+	}
+}
@@ -0,0 +1,52 @@
+package metadata_handlers
+
+import (
+	"log/slog"
+	"strconv"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetTransactionsByMetadataLabelHandler handles the request to get transactions by metadata label.
+//
+//	@Summary		Get Transactions by Metadata Label
+//	@Description	Retrieves transactions carrying metadata under the label, newest first, with pagination. Their metadata is returned decoded in the no-schema and detailed-schema JSON forms.
+//	@ID				getTransactionsByMetadataLabel
+//	@Tags			Metadata
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			label	path		int		true	"Metadata label, e.g. 674."
+//	@Param			limit	query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset	query		int		false	"Number of results to skip."	default(0)
+//	@Success		200		{array}		viewmodel.Transaction	"Successfully retrieved transactions."
+//	@Failure		400		{object}	object{error=string}	"Invalid label or pagination parameters."
+//	@Failure		404		{object}	object{error=string}	"No transactions found."
+//	@Failure		500		{object}	object{error=string}	"Internal server error."
+//	@Router			/metadata/labels/{label}/transactions [get]
+func GetTransactionsByMetadataLabelHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		label, err := strconv.ParseUint(c.Params("label"), 10, 64)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "label must be an unsigned integer"})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		transactions, err := db.GetTxsByMetadataLabel(label, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get transactions for metadata label", "label", label, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get transactions"})
+		}
+		if len(transactions) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No transactions found for the metadata label"})
+		}
+
+		return c.JSON(viewmodel.ConvertTransactionsToViewModels(transactions))
+	}
+}
@@ -4,14 +4,12 @@ import (
 	"log/slog"
 
 	"github.com/Andamio-Platform/andamio-indexer/database"
-	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
 	"github.com/gofiber/fiber/v2"
-	"gorm.io/gorm"
 )
 
 // GetLatestBlockHandler godoc
 // @Summary Get Latest Indexed Block
-// @Description Retrieves the block number and slot number of the most recently indexed block.
+// @Description Retrieves the block number and slot number of the most recent block seen on chain sync.
 // @ID getLatestBlock
 // @Tags Metrics
 // @Security ApiKeyAuth
@@ -22,32 +20,24 @@ import (
 // @Router /metrics/latest-block [get]
 func GetLatestBlockHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		var latestTx models.Transaction
-		result := db.Metadata().DB().Model(&models.Transaction{}).
-			Order("block_number DESC, slot_number DESC").
-			Limit(1).
-			First(&latestTx)
-
-		if result.Error != nil {
-			if result.Error == gorm.ErrRecordNotFound {
-				// No transactions found, return 0 for block and slot
-				return c.Status(fiber.StatusOK).JSON(fiber.Map{
-					"block_number": 0,
-					"slot_number":  0,
-				})
-			}
-			logger.Error("Error getting latest block", "error", result.Error)
+		latestBlock, err := db.Metadata().GetLatestBlock(nil)
+		if err != nil {
+			logger.Error("Error getting latest block", "error", err)
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": "Failed to get latest block information",
 			})
 		}
-
-		blockNumber := latestTx.BlockNumber
-		slotNumber := latestTx.SlotNumber
+		if latestBlock == nil {
+			// No blocks seen yet, return 0 for block and slot
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"block_number": 0,
+				"slot_number":  0,
+			})
+		}
 
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"block_number": blockNumber,
-			"slot_number":  slotNumber,
+			"block_number": latestBlock.BlockNumber,
+			"slot_number":  latestBlock.SlotNumber,
 		})
 	}
 }
\ No newline at end of file
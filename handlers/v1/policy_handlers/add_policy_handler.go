@@ -0,0 +1,51 @@
+package policy_handlers
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AddPolicyHandler godoc
+// @Summary Add Policy
+// @Description Adds a policy ID to the watchlist. The running filter starts indexing transactions that move matching assets right away.
+// @ID addPolicy
+// @Tags Policies
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param policy body viewmodel.PolicyRequest true "The policy ID to add."
+// @Success 201 {object} object{message=string} "Successfully added policy ID."
+// @Failure 400 {object} object{error=string} "Invalid request body or policy ID."
+// @Failure 409 {object} object{error=string} "Policy ID is already on the watchlist."
+// @Failure 500 {object} object{error=string} "Internal server error."
+// @Router /policies [post]
+func AddPolicyHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		request := new(viewmodel.PolicyRequest)
+		if err := c.BodyParser(request); err != nil {
+			logger.Error("failed to parse request body", "error", err)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+		if err := request.IsValid(); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		err := db.Metadata().AddPolicy(nil, &models.Policy{PolicyID: request.PolicyID, Label: request.Label})
+		if errors.Is(err, models.ErrPolicyAlreadyWatched) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Policy ID is already on the watchlist"})
+		}
+		if err != nil {
+			logger.Error("failed to add policy ID", "policy_id", request.PolicyID, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to add policy ID"})
+		}
+		invalidatePoliciesCache()
+
+		logger.Info("policy ID added", "policy_id", request.PolicyID)
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Policy ID added successfully"})
+	}
+}
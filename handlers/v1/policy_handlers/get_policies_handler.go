@@ -0,0 +1,38 @@
+package policy_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/indexer/cache"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetPoliciesHandler godoc
+// @Summary List Watched Policies
+// @Description Lists the policy IDs added to the watchlist at runtime. Policy IDs from the config are always watched and are not listed.
+// @ID getWatchedPolicies
+// @Tags Policies
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Success 200 {array} viewmodel.WatchedPolicy "Successfully retrieved watched policy IDs."
+// @Failure 500 {object} object{error=string} "Internal server error."
+// @Router /policies [get]
+func GetPoliciesHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		entries, err := db.Metadata().GetPolicies(nil)
+		if err != nil {
+			logger.Error("failed to get watched policy IDs", "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to retrieve watched policy IDs"})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(viewmodel.ConvertPolicyModelsToViewModels(entries))
+	}
+}
+
+// invalidatePoliciesCache makes the running filter pick up watchlist changes right away
+func invalidatePoliciesCache() {
+	cache.GetRelevantDataCache().LoadCache()
+}
@@ -0,0 +1,83 @@
+package policy_handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Andamio-Platform/andamio-indexer/config"
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/indexer/cache"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+const testPolicyId = "c37b1b5dc0669f1d3c61a6fddb2e8fde96be87b881c60bce8e8d542f"
+
+func TestPolicyHandlers(t *testing.T) {
+	db, err := database.New(nil, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() {
+		db.Close() //nolint:errcheck
+	})
+	if config.GlobalConfig == nil {
+		config.GlobalConfig = &config.Config{}
+	}
+	database.SetGlobalDB(db)
+	app := fiber.New()
+	app.Get("/policies", GetPoliciesHandler(db, slog.Default()))
+	app.Post("/policies", AddPolicyHandler(db, slog.Default()))
+	app.Delete("/policies/:policy_id", RemovePolicyHandler(db, slog.Default()))
+	request := func(method string, path string, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return resp.StatusCode
+	}
+
+	for _, body := range []string{`{"policy_id": ""}`, `{"policy_id": "c37b1b5d"}`, `{"policy_id":`} {
+		if status := request(fiber.MethodPost, "/policies", body); status != fiber.StatusBadRequest {
+			t.Fatalf("expected status %d for %s, got %d", fiber.StatusBadRequest, body, status)
+		}
+	}
+	// Policy IDs are matched in lower case
+	body := `{"policy_id": " ` + strings.ToUpper(testPolicyId) + `", "label": "test"}`
+	if status := request(fiber.MethodPost, "/policies", body); status != fiber.StatusCreated {
+		t.Fatalf("expected status %d, got %d", fiber.StatusCreated, status)
+	}
+	if !cache.GetRelevantDataCache().IsWatchedPolicy(testPolicyId) {
+		t.Fatalf("expected the added policy ID to be watched")
+	}
+	if status := request(fiber.MethodPost, "/policies", body); status != fiber.StatusConflict {
+		t.Fatalf("expected status %d, got %d", fiber.StatusConflict, status)
+	}
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/policies", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var policies []viewmodel.WatchedPolicy
+	if err := json.NewDecoder(resp.Body).Decode(&policies); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(policies) != 1 || policies[0].PolicyID != testPolicyId || policies[0].Label != "test" {
+		t.Fatalf("unexpected policies: %+v", policies)
+	}
+
+	if status := request(fiber.MethodDelete, "/policies/"+strings.ToUpper(testPolicyId), ""); status != fiber.StatusOK {
+		t.Fatalf("expected status %d, got %d", fiber.StatusOK, status)
+	}
+	if cache.GetRelevantDataCache().IsWatchedPolicy(testPolicyId) {
+		t.Fatalf("expected the removed policy ID not to be watched")
+	}
+	if status := request(fiber.MethodDelete, "/policies/"+testPolicyId, ""); status != fiber.StatusNotFound {
+		t.Fatalf("expected status %d, got %d", fiber.StatusNotFound, status)
+	}
+}
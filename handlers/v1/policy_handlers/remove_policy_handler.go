@@ -0,0 +1,43 @@
+package policy_handlers
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// RemovePolicyHandler godoc
+// @Summary Remove Policy
+// @Description Removes a policy ID from the watchlist. Policy IDs from the config cannot be removed.
+// @ID removePolicy
+// @Tags Policies
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param policy_id path string true "The policy ID to remove."
+// @Success 200 {object} object{message=string} "Successfully removed policy ID."
+// @Failure 404 {object} object{error=string} "Policy ID is not on the watchlist."
+// @Failure 500 {object} object{error=string} "Internal server error."
+// @Router /policies/{policy_id} [delete]
+func RemovePolicyHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		policyID := strings.ToLower(c.Params("policy_id"))
+
+		err := db.Metadata().RemovePolicy(nil, policyID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Policy ID is not on the watchlist"})
+		}
+		if err != nil {
+			logger.Error("failed to remove policy ID", "policy_id", policyID, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to remove policy ID"})
+		}
+		invalidatePoliciesCache()
+
+		logger.Info("policy ID removed", "policy_id", policyID)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Policy ID removed successfully"})
+	}
+}
@@ -0,0 +1,73 @@
+package redeemer_handlers
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+)
+
+// GetRedeemersHandler godoc
+// @Summary Get Redeemers by Script Hash or Purpose
+// @Description Retrieve redeemers passed to a script and/or with a purpose, with their execution units and resolved purpose, newest first.
+// @ID getRedeemers
+// @Tags Redeemers
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param script_hash query string false "Hex encoded hash of the script the redeemer is passed to."
+// @Param purpose query string false "Redeemer purpose: spend, mint, cert, reward, vote or propose."
+// @Param limit query int false "Maximum number of results to return." default(100)
+// @Param offset query int false "Number of results to skip." default(0)
+// @Success 200 {array} viewmodel.Redeemer "Successfully retrieved redeemers."
+// @Failure 400 {object} object{error=string} "Missing or invalid filters, or invalid pagination parameters."
+// @Failure 500 {object} object{error=string} "Internal server error."
+// @Router /redeemers [get]
+func GetRedeemersHandler(db *database.Database, log *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scriptHashHex := strings.ToLower(c.Query("script_hash"))
+		purpose := strings.ToLower(c.Query("purpose"))
+		if scriptHashHex == "" && purpose == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "script_hash or purpose is required"})
+		}
+		var scriptHash []byte
+		if scriptHashHex != "" {
+			if !credential.IsCredentialHash(scriptHashHex) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "script_hash must be a hex encoded 28 byte hash"})
+			}
+			scriptHash, _ = hex.DecodeString(scriptHashHex)
+		}
+		if purpose != "" && !isRedeemerPurpose(purpose) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "purpose must be one of spend, mint, cert, reward, vote or propose"})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		redeemers, err := db.GetRedeemers(scriptHash, purpose, limit, offset, nil)
+		if err != nil {
+			log.Error("failed to get redeemers", "script_hash", scriptHashHex, "purpose", purpose, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+
+		return c.JSON(viewmodel.ConvertRedeemersToViewModels(redeemers))
+	}
+}
+
+// isRedeemerPurpose reports whether the string is a known redeemer purpose
+func isRedeemerPurpose(purpose string) bool {
+	for tag := uint8(0); models.RedeemerPurpose(tag) != ""; tag++ {
+		if models.RedeemerPurpose(tag) == purpose {
+			return true
+		}
+	}
+	return false
+}
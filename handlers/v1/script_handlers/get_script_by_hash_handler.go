@@ -0,0 +1,64 @@
+package script_handlers
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strings"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/middleware"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetScriptByHashHandler handles the request to get a script from the script registry.
+//
+//	@Summary		Get Script by Hash
+//	@Description	Retrieves a native or Plutus script by its hash, with the transaction it was first seen in and the unspent outputs carrying it as a reference script.
+//	@ID				getScriptByHash
+//	@Tags			Scripts
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			script_hash	path		string	true	"Hex encoded script hash."
+//	@Param			limit		query		int		false	"Maximum number of reference UTxOs to return."	default(100)
+//	@Param			offset		query		int		false	"Number of reference UTxOs to skip."	default(0)
+//	@Success		200			{object}	viewmodel.Script		"Successfully retrieved script."
+//	@Failure		400			{object}	object{error=string}	"Invalid script hash or pagination parameters."
+//	@Failure		404			{object}	object{error=string}	"Script not found."
+//	@Failure		500			{object}	object{error=string}	"Internal server error."
+//	@Router			/scripts/{script_hash} [get]
+func GetScriptByHashHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scriptHashHex := strings.ToLower(c.Params("script_hash"))
+		if !credential.IsCredentialHash(scriptHashHex) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "script_hash must be a hex encoded 28 byte hash"})
+		}
+		scriptHash, _ := hex.DecodeString(scriptHashHex)
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		script, err := db.GetScriptByHash(scriptHash, nil)
+		if err != nil {
+			logger.Error("failed to get script", "script_hash", scriptHashHex, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get script"})
+		}
+		if script == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Script not found"})
+		}
+
+		referenceUTxOs, err := db.GetReferenceUTxOsByScriptHash(scriptHash, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get reference UTxOs for script", "script_hash", scriptHashHex, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get reference UTxOs"})
+		}
+
+		// The script and each reference UTxO count against the quota
+		middleware.SetRowCount(c, 1+len(referenceUTxOs))
+		return c.JSON(viewmodel.ConvertScriptModelToViewModel(*script, referenceUTxOs))
+	}
+}
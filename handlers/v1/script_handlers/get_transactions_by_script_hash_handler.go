@@ -0,0 +1,55 @@
+package script_handlers
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strings"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetTransactionsByScriptHashHandler handles the request to get transactions that executed a script.
+//
+//	@Summary		Get Transactions by Script Hash
+//	@Description	Retrieves transactions that ran the script to spend, mint or withdraw, whether it was supplied in the witness set or by a reference input, with pagination.
+//	@ID				getTransactionsByScriptHash
+//	@Tags			Scripts
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			script_hash	path		string	true	"Hex encoded script hash."
+//	@Param			limit		query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset		query		int		false	"Number of results to skip."	default(0)
+//	@Success		200			{array}		viewmodel.Transaction	"Successfully retrieved transactions."
+//	@Failure		400			{object}	object{error=string}	"Invalid script hash or pagination parameters."
+//	@Failure		404			{object}	object{error=string}	"No transactions found."
+//	@Failure		500			{object}	object{error=string}	"Internal server error."
+//	@Router			/scripts/{script_hash}/transactions [get]
+func GetTransactionsByScriptHashHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scriptHashHex := strings.ToLower(c.Params("script_hash"))
+		if !credential.IsCredentialHash(scriptHashHex) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "script_hash must be a hex encoded 28 byte hash"})
+		}
+		scriptHash, _ := hex.DecodeString(scriptHashHex)
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		transactions, err := db.GetTxsByScriptHash(scriptHash, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get transactions for script", "script_hash", scriptHashHex, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get transactions"})
+		}
+		if len(transactions) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No transactions found for the script"})
+		}
+
+		return c.JSON(viewmodel.ConvertTransactionsToViewModels(transactions))
+	}
+}
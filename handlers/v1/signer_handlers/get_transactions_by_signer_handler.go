@@ -0,0 +1,55 @@
+package signer_handlers
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strings"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetTransactionsBySignerHandler handles the request to get transactions signed by a verification key.
+//
+//	@Summary		Get Transactions by Signer
+//	@Description	Retrieves transactions carrying a vkey or bootstrap witness for the key hash, including native-script mints it co-signed, with pagination.
+//	@ID				getTransactionsBySigner
+//	@Tags			Signers
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			key_hash	path		string	true	"Hex encoded verification key hash."
+//	@Param			limit		query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset		query		int		false	"Number of results to skip."	default(0)
+//	@Success		200			{array}		viewmodel.Transaction	"Successfully retrieved transactions."
+//	@Failure		400			{object}	object{error=string}	"Invalid key hash or pagination parameters."
+//	@Failure		404			{object}	object{error=string}	"No transactions found."
+//	@Failure		500			{object}	object{error=string}	"Internal server error."
+//	@Router			/signers/{key_hash}/transactions [get]
+func GetTransactionsBySignerHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		keyHashHex := strings.ToLower(c.Params("key_hash"))
+		if !credential.IsCredentialHash(keyHashHex) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "key_hash must be a hex encoded 28 byte hash"})
+		}
+		keyHash, _ := hex.DecodeString(keyHashHex)
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		transactions, err := db.GetTxsBySigner(keyHash, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get transactions for signer", "key_hash", keyHashHex, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get transactions"})
+		}
+		if len(transactions) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No transactions found for the signer"})
+		}
+
+		return c.JSON(viewmodel.ConvertTransactionsToViewModels(transactions))
+	}
+}
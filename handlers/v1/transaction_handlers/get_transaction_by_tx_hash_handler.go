@@ -50,22 +50,7 @@ func GetTransactionByTxHashHandler(db *database.Database) fiber.Handler { // Use
 		}
 
 		// Convert database model to view model
-		transactionViewModel := viewmodel.Transaction{
-			TransactionHash: hex.EncodeToString(tx.TransactionHash),
-			BlockNumber:     tx.BlockNumber,
-			SlotNumber:      tx.SlotNumber,
-			Inputs:          viewmodel.ConvertTransactionInputsToViewModels(tx.Inputs),
-			Outputs:         viewmodel.ConvertTransactionOutputsToViewModels(tx.Outputs),
-			Fee:             tx.Fee,
-			TTL:             tx.TTL,
-			BlockHash:       string(tx.BlockHash),
-			Metadata:        hex.EncodeToString(tx.Metadata),
-			ReferenceInputs: viewmodel.ConvertSimpleUTxOModelsToViewModels(tx.ReferenceInputs),
-			Withdrawals:     tx.Withdrawals,
-			Certificates:    viewmodel.ConvertByteSliceSliceToStringSlice(tx.Certificates),
-			Witness:         viewmodel.ConvertWitnessModelToViewModel(tx.Witness),
-			TransactionCBOR: hex.EncodeToString(tx.TransactionCBOR),
-		}
+		transactionViewModel := viewmodel.ConvertTransactionToViewModel(*tx)
 
 		return c.Status(fiber.StatusOK).JSON(transactionViewModel) // Use fiber JSON
 	}
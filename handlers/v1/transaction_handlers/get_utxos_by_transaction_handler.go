@@ -49,6 +49,11 @@ func GetUTxOsByTransactionHandler(db *database.Database) fiber.Handler { // Use
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Transaction not found"})
 		}
 
+		// The outputs are returned apart from the transaction, so they carry its block time
+		for i := range transaction.Outputs {
+			transaction.Outputs[i].SlotNumber = transaction.SlotNumber
+		}
+
 		// Convert database models to view models
 		transactionUTxOs := viewmodel.TransactionUTxOs{
 			Inputs:  viewmodel.ConvertTransactionInputsToViewModels(transaction.Inputs),
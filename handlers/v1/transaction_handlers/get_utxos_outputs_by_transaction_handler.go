@@ -39,6 +39,11 @@ func GetUTxOsOutputsByTransactionHandler(db *database.Database) fiber.Handler {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Transaction not found"})
 		}
 
+		// The outputs are returned apart from the transaction, so they carry its block time
+		for i := range transaction.Outputs {
+			transaction.Outputs[i].SlotNumber = transaction.SlotNumber
+		}
+
 		// Convert database models to view models
 		outputViewModels := viewmodel.ConvertTransactionOutputsToViewModels(transaction.Outputs)
 
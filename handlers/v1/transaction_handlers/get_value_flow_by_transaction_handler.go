@@ -0,0 +1,46 @@
+package transaction_handlers
+
+import (
+	"encoding/hex"
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetValueFlowByTransactionHandler handles the request to get the value flow of a transaction.
+//
+//	@Summary		Get Value Flow by Transaction Hash
+//	@Description	Retrieves the lovelace and native assets a transaction consumed from and produced to each address, with the net change per address. Transactions that failed phase-2 validation move their collateral to their collateral return.
+//	@ID				getValueFlowByTransaction
+//	@Tags			Transactions
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			tx_hash	path		string	true	"The transaction hash (hex-encoded) to retrieve the value flow for."
+//	@Success		200		{object}	viewmodel.ValueFlow		"Successfully retrieved value flow."
+//	@Failure		400		{object}	object{error=string}	"Invalid transaction hash."
+//	@Failure		404		{object}	object{error=string}	"Transaction not found."
+//	@Failure		500		{object}	object{error=string}	"Internal server error."
+//	@Router			/transactions/{tx_hash}/value-flow [get]
+func GetValueFlowByTransactionHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		txHashStr := c.Params("tx_hash")
+		txHash, err := hex.DecodeString(txHashStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid transaction hash"})
+		}
+
+		flow, err := db.GetTxValueFlow(txHash, nil)
+		if err != nil {
+			logger.Error("failed to get transaction value flow", "tx_hash", txHashStr, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get value flow"})
+		}
+		if flow == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Transaction not found"})
+		}
+
+		return c.JSON(viewmodel.ConvertValueFlowToViewModel(flow))
+	}
+}
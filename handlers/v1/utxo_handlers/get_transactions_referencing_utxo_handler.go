@@ -0,0 +1,52 @@
+package utxo_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetTransactionsReferencingUTxOHandler handles the request to get the transactions that referenced a UTxO.
+//
+//	@Summary		Get Transactions Referencing a UTxO
+//	@Description	Retrieves transactions that read the UTxO as a reference input, newest first, with pagination.
+//	@ID				getTransactionsReferencingUTxO
+//	@Tags			UTxOs
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			tx_hash	path		string	true	"Hex encoded hash of the transaction that produced the UTxO."
+//	@Param			index	path		int		true	"Output index of the UTxO."
+//	@Param			limit	query		int		false	"Maximum number of results to return."	default(100)
+//	@Param			offset	query		int		false	"Number of results to skip."	default(0)
+//	@Success		200		{array}		viewmodel.Transaction	"Successfully retrieved transactions."
+//	@Failure		400		{object}	object{error=string}	"Invalid UTxO or pagination parameters."
+//	@Failure		404		{object}	object{error=string}	"No transactions found."
+//	@Failure		500		{object}	object{error=string}	"Internal server error."
+//	@Router			/utxos/{tx_hash}/{index}/referenced-by [get]
+func GetTransactionsReferencingUTxOHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		txHash, index, err := parseUTxO(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		limit := c.QueryInt("limit", 100)
+		offset := c.QueryInt("offset", 0)
+		if limit < 0 || offset < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid pagination parameters"})
+		}
+
+		transactions, err := db.GetTxsReferencingUTxO(txHash, index, limit, offset, nil)
+		if err != nil {
+			logger.Error("failed to get transactions referencing UTxO", "tx_hash", c.Params("tx_hash"), "index", index, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get transactions"})
+		}
+		if len(transactions) == 0 {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "No transactions found referencing the UTxO"})
+		}
+
+		return c.JSON(viewmodel.ConvertTransactionsToViewModels(transactions))
+	}
+}
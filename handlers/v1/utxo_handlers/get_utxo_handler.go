@@ -0,0 +1,45 @@
+package utxo_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetUTxOHandler handles the request to get a UTxO.
+//
+//	@Summary		Get UTxO
+//	@Description	Retrieves an indexed UTxO with its assets and datum, the transaction that created it and, if it has been spent, the transaction that spent it.
+//	@ID				getUTxO
+//	@Tags			UTxOs
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			tx_hash	path		string	true	"Hex encoded hash of the transaction that produced the UTxO."
+//	@Param			index	path		int		true	"Output index of the UTxO."
+//	@Success		200		{object}	viewmodel.UTxO			"Successfully retrieved UTxO."
+//	@Failure		400		{object}	object{error=string}	"Invalid UTxO."
+//	@Failure		404		{object}	object{error=string}	"UTxO not found."
+//	@Failure		500		{object}	object{error=string}	"Internal server error."
+//	@Router			/utxos/{tx_hash}/{index} [get]
+func GetUTxOHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		txHash, index, err := parseUTxO(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		utxo, err := db.GetUTxO(txHash, index, nil)
+		if err != nil {
+			logger.Error("failed to get UTxO", "tx_hash", c.Params("tx_hash"), "index", index, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get UTxO"})
+		}
+		if utxo == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "UTxO not found"})
+		}
+
+		return c.JSON(viewmodel.ConvertUTxOToViewModel(utxo))
+	}
+}
@@ -0,0 +1,62 @@
+package utxo_handlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/middleware"
+	"github.com/Andamio-Platform/andamio-indexer/viewmodel"
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultLineageDepth is the lineage depth used when none is requested
+const defaultLineageDepth = 3
+
+// GetUTxOLineageHandler handles the request to get the spend chain around a UTxO.
+//
+//	@Summary		Get UTxO Lineage
+//	@Description	Walks the spend chain of a UTxO backward, through the transactions that created it and the UTxOs they consumed, and forward, through the transactions that spent it and the UTxOs they produced, up to the given depth. Only indexed transactions are part of the chain. Each direction walks at most 100 transactions and 1000 UTxOs, and the lineage is marked truncated when a walk stopped there.
+//	@ID				getUTxOLineage
+//	@Tags			UTxOs
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			tx_hash	path		string	true	"Hex encoded hash of the transaction that produced the UTxO."
+//	@Param			index	path		int		true	"Output index of the UTxO."
+//	@Param			depth	query		int		false	"Number of transactions to walk in each direction, at most 10."	default(3)
+//	@Success		200		{object}	viewmodel.UTxOLineage	"Successfully retrieved UTxO lineage."
+//	@Failure		400		{object}	object{error=string}	"Invalid UTxO or depth."
+//	@Failure		404		{object}	object{error=string}	"UTxO not found."
+//	@Failure		500		{object}	object{error=string}	"Internal server error."
+//	@Router			/utxos/{tx_hash}/{index}/lineage [get]
+func GetUTxOLineageHandler(db *database.Database, logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		txHash, index, err := parseUTxO(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		depth := c.QueryInt("depth", defaultLineageDepth)
+		if depth < 1 || depth > database.MaxLineageDepth {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "depth must be between 1 and 10"})
+		}
+
+		utxo, err := db.GetUTxO(txHash, index, nil)
+		if err != nil {
+			logger.Error("failed to get UTxO", "tx_hash", c.Params("tx_hash"), "index", index, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get UTxO"})
+		}
+		if utxo == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "UTxO not found"})
+		}
+
+		lineage, err := db.GetUTxOLineage(txHash, index, depth, nil)
+		if err != nil {
+			logger.Error("failed to get UTxO lineage", "tx_hash", c.Params("tx_hash"), "index", index, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to get UTxO lineage"})
+		}
+
+		// The UTxO and each transaction walked count against the quota
+		middleware.SetRowCount(c, 1+len(lineage.Ancestors)+len(lineage.Descendants))
+		return c.JSON(viewmodel.ConvertUTxOLineageToViewModel(txHash, index, depth, lineage))
+	}
+}
@@ -0,0 +1,26 @@
+package utxo_handlers
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// txHashSize is the size of a transaction hash
+const txHashSize = 32
+
+// parseUTxO parses the tx_hash and index path parameters of a UTxO route
+func parseUTxO(c *fiber.Ctx) ([]byte, uint32, error) {
+	txHash, err := hex.DecodeString(strings.ToLower(c.Params("tx_hash")))
+	if err != nil || len(txHash) != txHashSize {
+		return nil, 0, errors.New("tx_hash must be a hex encoded 32 byte hash")
+	}
+	index, err := strconv.ParseUint(c.Params("index"), 10, 32)
+	if err != nil {
+		return nil, 0, errors.New("index must be an unsigned integer")
+	}
+	return txHash, uint32(index), nil
+}
@@ -1,18 +1,31 @@
 package cache
 
 import (
+	"encoding/hex"
+	"strings"
 	"sync"
 
 	"github.com/Andamio-Platform/andamio-indexer/config"
 	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
 	fiberLogger "github.com/gofiber/fiber/v2/log"
 )
 
-// RelevantDataCache holds the addresses and policies to filter transactions
+// RelevantDataCache holds the addresses, policies and asset fingerprints to filter transactions
 type RelevantDataCache struct {
-	Addresses []string
-	Policies  []string
-	mu        sync.RWMutex
+	Addresses    []string
+	Policies     []string
+	Fingerprints []string
+	// startSlots maps each watched address to the slot indexing starts at
+	startSlots map[string]uint64
+	// stakeCredentials maps the credential hash of each watched stake address to its start slot
+	stakeCredentials map[string]uint64
+	// paymentCredentials maps each watched payment key hash or script hash to its start slot
+	paymentCredentials map[string]uint64
+	policySet          map[string]bool
+	fingerprints       map[string]bool
+	mu                 sync.RWMutex
 }
 
 var globalCache *RelevantDataCache
@@ -37,21 +50,98 @@ func (c *RelevantDataCache) LoadCache() {
 
 	// Load addresses from database
 	globalDB := database.GetGlobalDB()
-	dbAddresses, err := globalDB.GetAllAddresses()
+	dbStartSlots, err := globalDB.GetAddressStartSlots()
 	if err != nil {
 		fiberLogger.Error("failed to retrieve addresses from database for cache: %v", err)
 		// Continue with config addresses even if database read fails
 	}
 
-	// Combine database and config addresses
-	c.Addresses = append(dbAddresses, cfg.Andamio.GetAllAndamioAddresses()...)
+	// Combine database and config addresses. Config addresses are always watched from the start.
+	c.startSlots = make(map[string]uint64, len(dbStartSlots))
+	for addr, startSlot := range dbStartSlots {
+		c.startSlots[addr] = startSlot
+	}
+	for _, addr := range cfg.Andamio.GetAllAndamioAddresses() {
+		c.startSlots[addr] = 0
+	}
+	c.Addresses = make([]string, 0, len(c.startSlots))
+	c.stakeCredentials = make(map[string]uint64)
+	c.paymentCredentials = make(map[string]uint64)
+	for addr, startSlot := range c.startSlots {
+		c.Addresses = append(c.Addresses, addr)
+		// Watching a key hash or script hash watches every address with that payment part
+		if credential.IsCredentialHash(addr) {
+			paymentCredential, _ := hex.DecodeString(addr)
+			c.paymentCredentials[string(paymentCredential)] = startSlot
+			continue
+		}
+		// Watching a stake address watches every address delegated to its credential
+		if credential.IsStakeAddress(addr) {
+			stakeCredential, err := credential.ParseStakeCredential(addr)
+			if err != nil {
+				fiberLogger.Errorf("failed to parse watched stake address %s: %v", addr, err)
+				continue
+			}
+			c.stakeCredentials[string(stakeCredential)] = startSlot
+		}
+	}
 
-	// Load policies from config
-	c.Policies = cfg.Andamio.GetAllAndamioPolicies()
+	// Combine config and database policies
+	dbPolicies, err := globalDB.GetWatchedPolicyIDs()
+	if err != nil {
+		fiberLogger.Errorf("failed to retrieve policies from database for cache: %v", err)
+	}
+	c.policySet = make(map[string]bool)
+	c.Policies = nil
+	for _, policy := range append(cfg.Andamio.GetAllAndamioPolicies(), dbPolicies...) {
+		policy = strings.ToLower(policy)
+		if policy == "" || c.policySet[policy] {
+			continue
+		}
+		c.policySet[policy] = true
+		c.Policies = append(c.Policies, policy)
+	}
+
+	// Combine config and database asset fingerprints. Config assets are given as policy.assetname units.
+	dbFingerprints, err := globalDB.GetWatchedFingerprints()
+	if err != nil {
+		fiberLogger.Errorf("failed to retrieve fingerprints from database for cache: %v", err)
+	}
+	c.fingerprints = make(map[string]bool)
+	c.Fingerprints = nil
+	for _, asset := range append(cfg.Andamio.GetAllAndamioAssetFingerprints(), dbFingerprints...) {
+		fingerprint, ok := assetFingerprint(asset)
+		if !ok || c.fingerprints[fingerprint] {
+			continue
+		}
+		c.fingerprints[fingerprint] = true
+		c.Fingerprints = append(c.Fingerprints, fingerprint)
+	}
 
 	fiberLogger.Info("Relevant data cache loaded successfully")
 }
 
+// assetFingerprint returns the CIP-14 fingerprint for an asset given either as a fingerprint
+// or as a hex encoded policy.assetname unit
+func assetFingerprint(asset string) (string, bool) {
+	if strings.HasPrefix(asset, "asset1") {
+		return asset, true
+	}
+	policyHex, nameHex, found := strings.Cut(asset, ".")
+	if !found {
+		return "", false
+	}
+	policyId, err := hex.DecodeString(policyHex)
+	if err != nil || len(policyId) != 28 {
+		return "", false
+	}
+	assetName, err := hex.DecodeString(nameHex)
+	if err != nil {
+		return "", false
+	}
+	return lcommon.NewAssetFingerprint(policyId, assetName).String(), true
+}
+
 // GetAddresses returns the cached addresses
 func (c *RelevantDataCache) GetAddresses() []string {
 	c.mu.RLock()
@@ -59,9 +149,76 @@ func (c *RelevantDataCache) GetAddresses() []string {
 	return c.Addresses
 }
 
+// IsWatchedAddress reports whether transactions touching the address at the given slot should be indexed
+func (c *RelevantDataCache) IsWatchedAddress(address string, slot uint64) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	startSlot, ok := c.startSlots[address]
+	return ok && slot >= startSlot
+}
+
+// IsWatchedStakeCredential reports whether transactions touching addresses delegated to the
+// stake credential at the given slot should be indexed
+func (c *RelevantDataCache) IsWatchedStakeCredential(stakeCredential []byte, slot uint64) bool {
+	if len(stakeCredential) == 0 {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	startSlot, ok := c.stakeCredentials[string(stakeCredential)]
+	return ok && slot >= startSlot
+}
+
+// IsWatchedPaymentCredential reports whether transactions touching addresses with the payment
+// key hash or script hash at the given slot should be indexed
+func (c *RelevantDataCache) IsWatchedPaymentCredential(paymentCredential []byte, slot uint64) bool {
+	if len(paymentCredential) == 0 {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	startSlot, ok := c.paymentCredentials[string(paymentCredential)]
+	return ok && slot >= startSlot
+}
+
+// IsWatchedCredential reports whether the key hash or script hash is watched as either a
+// payment or a stake credential at the given slot. Governance credentials (DReps, committee
+// members, stake pools and proposal return accounts) are matched this way.
+func (c *RelevantDataCache) IsWatchedCredential(cred []byte, slot uint64) bool {
+	return c.IsWatchedPaymentCredential(cred, slot) || c.IsWatchedStakeCredential(cred, slot)
+}
+
+// IsWatchedPolicy reports whether transactions moving assets of the policy should be indexed
+func (c *RelevantDataCache) IsWatchedPolicy(policyId string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.policySet[policyId]
+}
+
+// IsWatchedFingerprint reports whether transactions moving the asset should be indexed
+func (c *RelevantDataCache) IsWatchedFingerprint(fingerprint string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.fingerprints[fingerprint]
+}
+
+// HasWatchedFingerprints reports whether any asset fingerprint is watched
+func (c *RelevantDataCache) HasWatchedFingerprints() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.fingerprints) > 0
+}
+
 // GetPolicies returns the cached policies
 func (c *RelevantDataCache) GetPolicies() []string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return c.Policies
-}
\ No newline at end of file
+}
+
+// GetFingerprints returns the cached asset fingerprints
+func (c *RelevantDataCache) GetFingerprints() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Fingerprints
+}
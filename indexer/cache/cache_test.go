@@ -0,0 +1,46 @@
+package cache
+
+import "testing"
+
+func TestAssetFingerprint(t *testing.T) {
+	// Test vectors from CIP-14
+	testDefs := []struct {
+		asset       string
+		fingerprint string
+		ok          bool
+	}{
+		{asset: "asset1rjklcrnsdzqp65wjgrg55sy9723kw09mlgvlc3", fingerprint: "asset1rjklcrnsdzqp65wjgrg55sy9723kw09mlgvlc3", ok: true},
+		{asset: "7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc373.", fingerprint: "asset1rjklcrnsdzqp65wjgrg55sy9723kw09mlgvlc3", ok: true},
+		{asset: "7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc373.504154415445", fingerprint: "asset13n25uv0yaf5kus35fm2k86cqy60z58d9xmde92", ok: true},
+		// A unit without the dot separating the asset name
+		{asset: "7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc373504154415445"},
+		// A policy ID that is not 28 bytes
+		{asset: "7eae28af.504154415445"},
+		{asset: "zz.504154415445"},
+		{asset: "7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc373.zz"},
+		{asset: ""},
+	}
+	for _, testDef := range testDefs {
+		fingerprint, ok := assetFingerprint(testDef.asset)
+		if ok != testDef.ok || fingerprint != testDef.fingerprint {
+			t.Fatalf("unexpected fingerprint for %q: got %q, %t, expected %q, %t", testDef.asset, fingerprint, ok, testDef.fingerprint, testDef.ok)
+		}
+	}
+}
+
+func TestWatchedFingerprints(t *testing.T) {
+	c := &RelevantDataCache{}
+	if c.HasWatchedFingerprints() || c.IsWatchedFingerprint("asset1rjklcrnsdzqp65wjgrg55sy9723kw09mlgvlc3") {
+		t.Fatalf("expected no watched fingerprints")
+	}
+	c.fingerprints = map[string]bool{"asset1rjklcrnsdzqp65wjgrg55sy9723kw09mlgvlc3": true}
+	if !c.HasWatchedFingerprints() {
+		t.Fatalf("expected watched fingerprints")
+	}
+	if !c.IsWatchedFingerprint("asset1rjklcrnsdzqp65wjgrg55sy9723kw09mlgvlc3") {
+		t.Fatalf("expected the fingerprint to be watched")
+	}
+	if c.IsWatchedFingerprint("asset13n25uv0yaf5kus35fm2k86cqy60z58d9xmde92") {
+		t.Fatalf("expected the fingerprint not to be watched")
+	}
+}
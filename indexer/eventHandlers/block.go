@@ -0,0 +1,41 @@
+package eventHandlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/config"
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	input_chainsync "github.com/blinklabs-io/adder/input/chainsync"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// BlockEvent saves a block seen on chain sync. The block time is left unset when the chain
+// time parameters of the network are not known.
+func BlockEvent(logger *slog.Logger, eventBlock input_chainsync.BlockEvent, eventCtx input_chainsync.BlockContext, txn *database.Txn) error {
+	logger.Debug("Processing block event",
+		"blockHash", eventBlock.BlockHash,
+		"blockNumber", eventCtx.BlockNumber,
+		"slotNumber", eventCtx.SlotNumber,
+	)
+
+	block := &models.Block{
+		BlockHash:   []byte(eventBlock.BlockHash),
+		BlockNumber: eventCtx.BlockNumber,
+		SlotNumber:  eventCtx.SlotNumber,
+		Size:        eventBlock.BlockBodySize,
+		TxCount:     eventBlock.TransactionCount,
+	}
+	if params, ok := config.GetGlobalConfig().Network.ChainTimeParams(); ok {
+		block.BlockTime = params.SlotToTime(eventCtx.SlotNumber)
+	}
+	if eventBlock.Block != nil {
+		block.Era = eventBlock.Block.Era().Name
+		// Byron blocks have no pool issuer
+		if issuer := eventBlock.Block.IssuerVkey(); issuer != (lcommon.IssuerVkey{}) {
+			block.Issuer = issuer.PoolId()
+		}
+	}
+
+	return txn.DB().Metadata().SetBlock(txn.Metadata(), block)
+}
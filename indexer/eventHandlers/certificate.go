@@ -0,0 +1,194 @@
+package eventHandlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/database/types"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// poolRelay is the JSON form of a pool registration relay
+type poolRelay struct {
+	Type     int     `json:"type"`
+	Port     *uint32 `json:"port,omitempty"`
+	Ipv4     string  `json:"ipv4,omitempty"`
+	Ipv6     string  `json:"ipv6,omitempty"`
+	Hostname *string `json:"hostname,omitempty"`
+}
+
+// convertCertificates decodes the certificates of a transaction, in body order. The
+// transaction hash, block and slot are set when the transaction is saved.
+func convertCertificates(logger *slog.Logger, certificates []lcommon.Certificate) []models.Certificate {
+	var converted []models.Certificate
+	for i, cert := range certificates {
+		certificate := models.Certificate{
+			CertIndex: uint32(i),
+			Cbor:      cert.Cbor(),
+		}
+		switch c := cert.(type) {
+		case *lcommon.StakeRegistrationCertificate:
+			certificate.Type = models.CertificateTypeStakeRegistration
+			setStakeCredential(&certificate, &c.StakeRegistration)
+		case *lcommon.StakeDeregistrationCertificate:
+			certificate.Type = models.CertificateTypeStakeDeregistration
+			setStakeCredential(&certificate, &c.StakeDeregistration)
+		case *lcommon.StakeDelegationCertificate:
+			certificate.Type = models.CertificateTypeStakeDelegation
+			setStakeCredential(&certificate, c.StakeCredential)
+			certificate.PoolKeyHash = c.PoolKeyHash.Bytes()
+		case *lcommon.PoolRegistrationCertificate:
+			certificate.Type = models.CertificateTypePoolRegistration
+			setPoolParams(logger, &certificate, c)
+		case *lcommon.PoolRetirementCertificate:
+			certificate.Type = models.CertificateTypePoolRetirement
+			certificate.PoolKeyHash = c.PoolKeyHash.Bytes()
+			certificate.Epoch = c.Epoch
+		case *lcommon.GenesisKeyDelegationCertificate:
+			certificate.Type = models.CertificateTypeGenesisKeyDelegation
+			certificate.VrfKeyHash = c.VrfKeyHash.Bytes()
+		case *lcommon.MoveInstantaneousRewardsCertificate:
+			certificate.Type = models.CertificateTypeMoveInstantaneousRewards
+			amount := c.Reward.OtherPot
+			for _, reward := range c.Reward.Rewards {
+				amount += reward
+			}
+			certificate.Amount = int64(amount)
+		case *lcommon.RegistrationCertificate:
+			certificate.Type = models.CertificateTypeRegistration
+			setStakeCredential(&certificate, &c.StakeCredential)
+			certificate.Amount = c.Amount
+		case *lcommon.DeregistrationCertificate:
+			certificate.Type = models.CertificateTypeDeregistration
+			setStakeCredential(&certificate, &c.StakeCredential)
+			certificate.Amount = c.Amount
+		case *lcommon.VoteDelegationCertificate:
+			certificate.Type = models.CertificateTypeVoteDelegation
+			setStakeCredential(&certificate, &c.StakeCredential)
+			setDrep(&certificate, c.Drep)
+		case *lcommon.StakeVoteDelegationCertificate:
+			certificate.Type = models.CertificateTypeStakeVoteDelegation
+			setStakeCredential(&certificate, &c.StakeCredential)
+			certificate.PoolKeyHash = bytes.Clone(c.PoolKeyHash)
+			setDrep(&certificate, c.Drep)
+		case *lcommon.StakeRegistrationDelegationCertificate:
+			certificate.Type = models.CertificateTypeStakeRegistrationDelegation
+			setStakeCredential(&certificate, &c.StakeCredential)
+			certificate.PoolKeyHash = bytes.Clone(c.PoolKeyHash)
+			certificate.Amount = c.Amount
+		case *lcommon.VoteRegistrationDelegationCertificate:
+			certificate.Type = models.CertificateTypeVoteRegistrationDelegation
+			setStakeCredential(&certificate, &c.StakeCredential)
+			setDrep(&certificate, c.Drep)
+			certificate.Amount = c.Amount
+		case *lcommon.StakeVoteRegistrationDelegationCertificate:
+			certificate.Type = models.CertificateTypeStakeVoteRegistrationDelegation
+			setStakeCredential(&certificate, &c.StakeCredential)
+			certificate.PoolKeyHash = c.PoolKeyHash.Bytes()
+			setDrep(&certificate, c.Drep)
+			certificate.Amount = c.Amount
+		case *lcommon.AuthCommitteeHotCertificate:
+			certificate.Type = models.CertificateTypeAuthCommitteeHot
+			certificate.CommitteeColdCredential = c.ColdCredential.Credential.Bytes()
+			certificate.CommitteeHotCredential = c.HostCredential.Credential.Bytes()
+		case *lcommon.ResignCommitteeColdCertificate:
+			certificate.Type = models.CertificateTypeResignCommitteeCold
+			certificate.CommitteeColdCredential = c.ColdCredential.Credential.Bytes()
+			setAnchor(&certificate, c.Anchor)
+		case *lcommon.RegistrationDrepCertificate:
+			certificate.Type = models.CertificateTypeDrepRegistration
+			setDrepCredential(&certificate, &c.DrepCredential)
+			certificate.Amount = c.Amount
+			setAnchor(&certificate, c.Anchor)
+		case *lcommon.DeregistrationDrepCertificate:
+			certificate.Type = models.CertificateTypeDrepDeregistration
+			setDrepCredential(&certificate, &c.DrepCredential)
+			certificate.Amount = c.Amount
+		case *lcommon.UpdateDrepCertificate:
+			certificate.Type = models.CertificateTypeDrepUpdate
+			setDrepCredential(&certificate, &c.DrepCredential)
+			setAnchor(&certificate, c.Anchor)
+		default:
+			logger.Warn("Unknown certificate type.", "certIndex", i)
+		}
+		converted = append(converted, certificate)
+	}
+	return converted
+}
+
+// setStakeCredential sets the stake credential of a certificate and its type
+func setStakeCredential(certificate *models.Certificate, cred *lcommon.Credential) {
+	if cred == nil {
+		return
+	}
+	credType := uint8(cred.CredType)
+	certificate.StakeCredential = cred.Credential.Bytes()
+	certificate.StakeCredentialType = &credType
+}
+
+// setDrep sets the DRep a certificate delegates voting power to
+func setDrep(certificate *models.Certificate, drep lcommon.Drep) {
+	drepType := uint8(drep.Type)
+	certificate.DrepType = &drepType
+	certificate.DrepCredential = bytes.Clone(drep.Credential)
+}
+
+// setDrepCredential sets the DRep a DRep certificate acts on. Credential types map onto
+// the DRep key hash and script hash types.
+func setDrepCredential(certificate *models.Certificate, cred *lcommon.Credential) {
+	drepType := uint8(cred.CredType)
+	certificate.DrepType = &drepType
+	certificate.DrepCredential = cred.Credential.Bytes()
+}
+
+// setAnchor sets the metadata anchor of a certificate, if any
+func setAnchor(certificate *models.Certificate, anchor *lcommon.GovAnchor) {
+	if anchor == nil {
+		return
+	}
+	certificate.AnchorUrl = anchor.Url
+	certificate.AnchorDataHash = bytes.Clone(anchor.DataHash[:])
+}
+
+// setPoolParams sets the parameters of a pool registration certificate
+func setPoolParams(logger *slog.Logger, certificate *models.Certificate, c *lcommon.PoolRegistrationCertificate) {
+	certificate.PoolKeyHash = c.Operator.Bytes()
+	certificate.VrfKeyHash = c.VrfKeyHash.Bytes()
+	certificate.Pledge = c.Pledge
+	certificate.Cost = c.Cost
+	if c.Margin.Rat != nil {
+		certificate.Margin = c.Margin.String()
+	}
+	certificate.PoolRewardAccount = c.RewardAccount.Bytes()
+	var owners types.ByteSliceSlice
+	for _, owner := range c.PoolOwners {
+		owners = append(owners, owner.Bytes())
+	}
+	certificate.PoolOwners = owners
+	relays := []poolRelay{}
+	for _, relay := range c.Relays {
+		converted := poolRelay{
+			Type:     relay.Type,
+			Port:     relay.Port,
+			Hostname: relay.Hostname,
+		}
+		if relay.Ipv4 != nil {
+			converted.Ipv4 = relay.Ipv4.String()
+		}
+		if relay.Ipv6 != nil {
+			converted.Ipv6 = relay.Ipv6.String()
+		}
+		relays = append(relays, converted)
+	}
+	relaysJson, err := json.Marshal(relays)
+	if err != nil {
+		logger.Warn("Failed to encode pool relays.", "error", err)
+	}
+	certificate.PoolRelays = string(relaysJson)
+	if c.PoolMetadata != nil {
+		certificate.PoolMetadataUrl = c.PoolMetadata.Url
+		certificate.PoolMetadataHash = c.PoolMetadata.Hash.Bytes()
+	}
+}
@@ -0,0 +1,38 @@
+package eventHandlers
+
+import (
+	"bytes"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// convertDatum links the UTxO at utxoId#utxoIndex to the datum of its output, if any.
+// Inline datums are hashed and carried along; outputs holding only a datum hash are
+// resolved when the datum shows up in a witness set.
+func convertDatum(output lcommon.TransactionOutput, utxoId []byte, utxoIndex uint32) *models.UTxODatum {
+	if datum := output.Datum(); datum != nil {
+		datumCbor := datum.Cbor()
+		datumHash := lcommon.Blake2b256Hash(datumCbor).Bytes()
+		return &models.UTxODatum{
+			UTxOID:      utxoId,
+			UTxOIDIndex: utxoIndex,
+			DatumHash:   datumHash,
+			Inline:      true,
+			Datum: &models.Datum{
+				DatumHash: datumHash,
+				DatumCbor: datumCbor,
+			},
+		}
+	}
+	// Babbage outputs without a datum return an empty hash rather than nil
+	datumHash := output.DatumHash()
+	if datumHash == nil || bytes.Equal(datumHash.Bytes(), make([]byte, lcommon.Blake2b256Size)) {
+		return nil
+	}
+	return &models.UTxODatum{
+		UTxOID:      utxoId,
+		UTxOIDIndex: utxoIndex,
+		DatumHash:   datumHash.Bytes(),
+	}
+}
@@ -0,0 +1,144 @@
+package eventHandlers
+
+import (
+	"bytes"
+	"log/slog"
+	"sort"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// convertProposals converts the proposal procedures of a transaction, in body order
+func convertProposals(logger *slog.Logger, tx lcommon.Transaction) []models.GovernanceProposal {
+	var proposals []models.GovernanceProposal
+	for i, proposal := range tx.ProposalProcedures() {
+		_, rewardCredential := credential.FromAddress(proposal.RewardAccount)
+		converted := models.GovernanceProposal{
+			ProposalIndex:    uint32(i),
+			Deposit:          proposal.Deposit,
+			RewardAccount:    proposal.RewardAccount.String(),
+			RewardCredential: rewardCredential,
+			ActionType:       uint8(proposal.GovAction.Type),
+			PolicyHash:       govActionPolicyHash(proposal.GovAction.Action),
+			AnchorUrl:        proposal.Anchor.Url,
+			AnchorDataHash:   bytes.Clone(proposal.Anchor.DataHash[:]),
+		}
+		if prevActionId := govActionPrevActionId(proposal.GovAction.Action); prevActionId != nil {
+			prevActionIndex := prevActionId.GovActionIdx
+			converted.PrevActionTxHash = bytes.Clone(prevActionId.TransactionId[:])
+			converted.PrevActionIndex = &prevActionIndex
+		}
+		actionCbor, err := cbor.Encode(&proposal.GovAction)
+		if err != nil {
+			logger.Warn("Failed to encode governance action.", "proposalIndex", i, "error", err)
+		}
+		converted.ActionCbor = actionCbor
+		proposals = append(proposals, converted)
+	}
+	return proposals
+}
+
+// convertVotes flattens the voting procedures of a transaction into one vote per voter and
+// governance action, ordered by voter then action
+func convertVotes(tx lcommon.Transaction) []models.GovernanceVote {
+	var votes []models.GovernanceVote
+	for _, voter := range sortedVoters(tx.VotingProcedures()) {
+		procedures := tx.VotingProcedures()[voter]
+		actionIds := make([]*lcommon.GovActionId, 0, len(procedures))
+		for actionId := range procedures {
+			actionIds = append(actionIds, actionId)
+		}
+		sort.Slice(actionIds, func(i, j int) bool {
+			if c := bytes.Compare(actionIds[i].TransactionId[:], actionIds[j].TransactionId[:]); c != 0 {
+				return c < 0
+			}
+			return actionIds[i].GovActionIdx < actionIds[j].GovActionIdx
+		})
+		for _, actionId := range actionIds {
+			procedure := procedures[actionId]
+			vote := models.GovernanceVote{
+				VoterType:       voter.Type,
+				VoterHash:       bytes.Clone(voter.Hash[:]),
+				GovActionTxHash: bytes.Clone(actionId.TransactionId[:]),
+				GovActionIndex:  actionId.GovActionIdx,
+				Vote:            procedure.Vote,
+			}
+			if procedure.Anchor != nil {
+				vote.AnchorUrl = procedure.Anchor.Url
+				vote.AnchorDataHash = bytes.Clone(procedure.Anchor.DataHash[:])
+			}
+			votes = append(votes, vote)
+		}
+	}
+	return votes
+}
+
+// sortedVoters returns the voters of the voting procedures in ledger order, which voting
+// redeemer indexes refer to: committee members, then DReps, then stake pools, with script
+// credentials before key hashes within each role
+func sortedVoters(procedures lcommon.VotingProcedures) []*lcommon.Voter {
+	voters := make([]*lcommon.Voter, 0, len(procedures))
+	for voter := range procedures {
+		voters = append(voters, voter)
+	}
+	voterRank := func(voter *lcommon.Voter) int {
+		switch voter.Type {
+		case lcommon.VoterTypeConstitutionalCommitteeHotScriptHash:
+			return 0
+		case lcommon.VoterTypeConstitutionalCommitteeHotKeyHash:
+			return 1
+		case lcommon.VoterTypeDRepScriptHash:
+			return 2
+		case lcommon.VoterTypeDRepKeyHash:
+			return 3
+		}
+		return 4
+	}
+	sort.Slice(voters, func(i, j int) bool {
+		if ri, rj := voterRank(voters[i]), voterRank(voters[j]); ri != rj {
+			return ri < rj
+		}
+		return bytes.Compare(voters[i].Hash[:], voters[j].Hash[:]) < 0
+	})
+	return voters
+}
+
+// voterScriptHash returns the script hash of a committee or DRep script voter, or nil
+func voterScriptHash(voter *lcommon.Voter) []byte {
+	switch voter.Type {
+	case lcommon.VoterTypeConstitutionalCommitteeHotScriptHash, lcommon.VoterTypeDRepScriptHash:
+		return bytes.Clone(voter.Hash[:])
+	}
+	return nil
+}
+
+// govActionPolicyHash returns the guardrail script hash of a governance action, if any
+func govActionPolicyHash(action lcommon.GovAction) []byte {
+	switch a := action.(type) {
+	case *lcommon.ParameterChangeGovAction:
+		return a.PolicyHash
+	case *lcommon.TreasuryWithdrawalGovAction:
+		return a.PolicyHash
+	}
+	return nil
+}
+
+// govActionPrevActionId returns the previous governance action a proposal builds on, if any
+func govActionPrevActionId(action lcommon.GovAction) *lcommon.GovActionId {
+	switch a := action.(type) {
+	case *lcommon.ParameterChangeGovAction:
+		return a.ActionId
+	case *lcommon.HardForkInitiationGovAction:
+		return a.ActionId
+	case *lcommon.NoConfidenceGovAction:
+		return a.ActionId
+	case *lcommon.UpdateCommitteeGovAction:
+		return a.ActionId
+	case *lcommon.NewConstitutionGovAction:
+		return a.ActionId
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package eventHandlers
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// convertMints flattens a transaction's mint field into one entry per asset. Mint redeemers
+// are indexed by the position of the policy ID in the sorted list of minted policies, so
+// policies with a redeemer at that index are linked to it.
+func convertMints(mint *lcommon.MultiAsset[lcommon.MultiAssetTypeMint], redeemers lcommon.TransactionWitnessRedeemers) []models.Mint {
+	if mint == nil {
+		return nil
+	}
+	policies := mint.Policies()
+	sort.Slice(policies, func(i, j int) bool {
+		return bytes.Compare(policies[i].Bytes(), policies[j].Bytes()) < 0
+	})
+
+	mintRedeemers := make(map[uint]bool)
+	if redeemers != nil {
+		for _, index := range redeemers.Indexes(lcommon.RedeemerTagMint) {
+			mintRedeemers[uint(index)] = true
+		}
+	}
+
+	var mints []models.Mint
+	for policyIndex, policyId := range policies {
+		var redeemerIndex *uint
+		if mintRedeemers[uint(policyIndex)] {
+			index := uint(policyIndex)
+			redeemerIndex = &index
+		}
+		assetNames := mint.Assets(policyId)
+		sort.Slice(assetNames, func(i, j int) bool {
+			return bytes.Compare(assetNames[i], assetNames[j]) < 0
+		})
+		for _, assetName := range assetNames {
+			mints = append(mints, models.Mint{
+				PolicyId:      []byte(policyId.String()),
+				NameHex:       []byte(hex.EncodeToString(assetName)),
+				Name:          assetName,
+				Fingerprint:   []byte(lcommon.NewAssetFingerprint(policyId.Bytes(), assetName).String()),
+				Quantity:      mint.Asset(policyId, assetName),
+				RedeemerIndex: redeemerIndex,
+			})
+		}
+	}
+	return mints
+}
@@ -0,0 +1,184 @@
+package eventHandlers
+
+import (
+	"bytes"
+	"log/slog"
+	"sort"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+var redeemerTags = []lcommon.RedeemerTag{
+	lcommon.RedeemerTagSpend,
+	lcommon.RedeemerTagMint,
+	lcommon.RedeemerTagCert,
+	lcommon.RedeemerTagReward,
+	lcommon.RedeemerTagVoting,
+	lcommon.RedeemerTagProposing,
+}
+
+// convertRedeemers converts the redeemers of a transaction with their execution units and
+// resolves each to its purpose. Redeemer indexes point into the inputs sorted by UTxO, the
+// minted policies sorted bytewise, the certificates in body order, the reward accounts in
+// ledger order, the voters in ledger order and the proposals in body order.
+// resolvedInputs holds the outputs spent by inputs, in the same order.
+func convertRedeemers(logger *slog.Logger, tx lcommon.Transaction, inputs []lcommon.TransactionInput, resolvedInputs []lcommon.TransactionOutput) []models.Redeemer {
+	witnesses := tx.Witnesses()
+	if witnesses == nil || witnesses.Redeemers() == nil {
+		return nil
+	}
+	redeemersInterface := witnesses.Redeemers()
+
+	type spentInput struct {
+		input  lcommon.TransactionInput
+		output lcommon.TransactionOutput
+	}
+	var spentInputs []spentInput
+	for i, input := range inputs {
+		var output lcommon.TransactionOutput
+		if i < len(resolvedInputs) {
+			output = resolvedInputs[i]
+		}
+		spentInputs = append(spentInputs, spentInput{input: input, output: output})
+	}
+	sort.Slice(spentInputs, func(i, j int) bool {
+		if c := bytes.Compare(spentInputs[i].input.Id().Bytes(), spentInputs[j].input.Id().Bytes()); c != 0 {
+			return c < 0
+		}
+		return spentInputs[i].input.Index() < spentInputs[j].input.Index()
+	})
+
+	var policies []lcommon.Blake2b224
+	if mint := tx.AssetMint(); mint != nil {
+		policies = mint.Policies()
+		sort.Slice(policies, func(i, j int) bool {
+			return bytes.Compare(policies[i].Bytes(), policies[j].Bytes()) < 0
+		})
+	}
+
+	certificates := tx.Certificates()
+
+	rewardAccounts := sortedRewardAccounts(tx.Withdrawals())
+	voters := sortedVoters(tx.VotingProcedures())
+	proposals := tx.ProposalProcedures()
+
+	var redeemers []models.Redeemer
+	for _, tag := range redeemerTags {
+		indexes := redeemersInterface.Indexes(tag)
+		logger.Debug("Processing redeemers for tag", "tag", tag, "count", len(indexes))
+		for _, index := range indexes {
+			redeemerValue, exUnits := redeemersInterface.Value(index, tag)
+			redeemer := models.Redeemer{
+				Index:        index,
+				Tag:          uint8(tag),
+				Cbor:         redeemerValue.Cbor(),
+				ExUnitsMem:   exUnits.Memory,
+				ExUnitsSteps: exUnits.Steps,
+				Purpose:      models.RedeemerPurpose(uint8(tag)),
+			}
+			switch tag {
+			case lcommon.RedeemerTagSpend:
+				if int(index) < len(spentInputs) {
+					spent := spentInputs[index]
+					utxoIndex := spent.input.Index()
+					redeemer.UTxOID = spent.input.Id().Bytes()
+					redeemer.UTxOIDIndex = &utxoIndex
+					if spent.output != nil {
+						redeemer.ScriptHash = credential.PaymentScriptHash(spent.output.Address())
+					}
+				}
+			case lcommon.RedeemerTagMint:
+				if int(index) < len(policies) {
+					redeemer.ScriptHash = policies[index].Bytes()
+				}
+			case lcommon.RedeemerTagCert:
+				if int(index) < len(certificates) {
+					certIndex := index
+					redeemer.CertificateIndex = &certIndex
+					redeemer.ScriptHash = certificateScriptHash(certificates[index])
+				}
+			case lcommon.RedeemerTagReward:
+				if int(index) < len(rewardAccounts) {
+					redeemer.RewardAccount = rewardAccounts[index].String()
+					redeemer.ScriptHash = credential.StakeScriptHash(rewardAccounts[index])
+				}
+			case lcommon.RedeemerTagVoting:
+				if int(index) < len(voters) {
+					redeemer.ScriptHash = voterScriptHash(voters[index])
+				}
+			case lcommon.RedeemerTagProposing:
+				if int(index) < len(proposals) {
+					redeemer.ScriptHash = govActionPolicyHash(proposals[index].GovAction.Action)
+				}
+			}
+			redeemers = append(redeemers, redeemer)
+			logger.Debug("Converted redeemer", "index", index, "tag", tag, "purpose", redeemer.Purpose)
+		}
+	}
+	return redeemers
+}
+
+// sortedRewardAccounts returns the reward accounts of withdrawals in ledger order: script
+// credentials before key credentials, each sorted by hash
+func sortedRewardAccounts(withdrawals map[*lcommon.Address]uint64) []lcommon.Address {
+	rewardAccounts := make([]lcommon.Address, 0, len(withdrawals))
+	for rewardAccount := range withdrawals {
+		if rewardAccount != nil {
+			rewardAccounts = append(rewardAccounts, *rewardAccount)
+		}
+	}
+	isScript := func(rewardAccount lcommon.Address) bool {
+		return rewardAccount.Type() == lcommon.AddressTypeNoneScript
+	}
+	sort.Slice(rewardAccounts, func(i, j int) bool {
+		if si, sj := isScript(rewardAccounts[i]), isScript(rewardAccounts[j]); si != sj {
+			return si
+		}
+		return bytes.Compare(rewardAccounts[i].StakeKeyHash().Bytes(), rewardAccounts[j].StakeKeyHash().Bytes()) < 0
+	})
+	return rewardAccounts
+}
+
+// certificateScriptHash returns the script hash of the credential a certificate acts on,
+// or nil when that credential is a key hash or the certificate has none
+func certificateScriptHash(cert lcommon.Certificate) []byte {
+	var cred *lcommon.Credential
+	switch c := cert.(type) {
+	case *lcommon.StakeRegistrationCertificate:
+		cred = &c.StakeRegistration
+	case *lcommon.StakeDeregistrationCertificate:
+		cred = &c.StakeDeregistration
+	case *lcommon.StakeDelegationCertificate:
+		cred = c.StakeCredential
+	case *lcommon.RegistrationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.DeregistrationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.VoteDelegationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.StakeVoteDelegationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.StakeRegistrationDelegationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.VoteRegistrationDelegationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.StakeVoteRegistrationDelegationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.AuthCommitteeHotCertificate:
+		cred = &c.ColdCredential
+	case *lcommon.ResignCommitteeColdCertificate:
+		cred = &c.ColdCredential
+	case *lcommon.RegistrationDrepCertificate:
+		cred = &c.DrepCredential
+	case *lcommon.DeregistrationDrepCertificate:
+		cred = &c.DrepCredential
+	case *lcommon.UpdateDrepCertificate:
+		cred = &c.DrepCredential
+	}
+	if cred == nil || cred.CredType != lcommon.CredentialTypeScriptHash {
+		return nil
+	}
+	return cred.Credential.Bytes()
+}
@@ -0,0 +1,82 @@
+package eventHandlers
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/config"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/Andamio-Platform/andamio-indexer/internal/kupo"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// convertReferenceInputs resolves the reference inputs of a transaction through the Kupo
+// instance the chain sync input resolves spent inputs with. Reference inputs Kupo has not
+// indexed are kept unresolved.
+func convertReferenceInputs(logger *slog.Logger, refInputs []lcommon.TransactionInput, txHash []byte) ([]models.SimpleUTxO, error) {
+	var kupoClient *kupo.Client
+	if kupoUrl := config.GetGlobalConfig().Network.LocalKupoEndpoint; kupoUrl != "" {
+		kupoClient = kupo.New(kupoUrl)
+	}
+	var referenceInputs []models.SimpleUTxO
+	for _, refInput := range refInputs {
+		utxoId := refInput.Id().Bytes()
+		utxoIndex := refInput.Index()
+		logger.Debug("Processing reference input", "utxoId", fmt.Sprintf("%x", utxoId), "utxoIndex", utxoIndex)
+		referenceInput := models.SimpleUTxO{
+			TransactionHash: txHash,
+			UTxOID:          utxoId,
+			UTxOIDIndex:     utxoIndex,
+		}
+		if kupoClient != nil {
+			utxo, err := kupoClient.GetUTxO(context.Background(), utxoId, utxoIndex)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve reference input %x#%d: %w", utxoId, utxoIndex, err)
+			}
+			if utxo != nil {
+				resolveReferenceInput(&referenceInput, utxo)
+			}
+		}
+		referenceInputs = append(referenceInputs, referenceInput)
+	}
+	return referenceInputs, nil
+}
+
+// resolveReferenceInput fills in a reference input from the UTxO it reads
+func resolveReferenceInput(referenceInput *models.SimpleUTxO, utxo *kupo.UTxO) {
+	paymentCredential, stakeCredential, _ := credential.FromAddressString(utxo.Address)
+	referenceInput.Resolved = true
+	referenceInput.Address = []byte(utxo.Address)
+	referenceInput.PaymentCredential = paymentCredential
+	referenceInput.StakeCredential = stakeCredential
+	referenceInput.Amount = utxo.Amount
+	referenceInput.ReferenceScriptHash = utxo.ScriptHash
+	for _, asset := range utxo.Assets {
+		referenceInput.Asset = append(referenceInput.Asset, models.Asset{
+			UTxOID:      referenceInput.UTxOID,
+			UTxOIDIndex: referenceInput.UTxOIDIndex,
+			Name:        asset.Name,
+			NameHex:     []byte(hex.EncodeToString(asset.Name)),
+			PolicyId:    []byte(hex.EncodeToString(asset.PolicyId)),
+			Fingerprint: []byte(lcommon.NewAssetFingerprint(asset.PolicyId, asset.Name).String()),
+			Amount:      asset.Amount,
+		})
+	}
+	if len(utxo.DatumHash) > 0 {
+		referenceInput.Datum = &models.UTxODatum{
+			UTxOID:      referenceInput.UTxOID,
+			UTxOIDIndex: referenceInput.UTxOIDIndex,
+			DatumHash:   utxo.DatumHash,
+			Inline:      utxo.InlineDatum,
+		}
+		if utxo.Datum != nil {
+			referenceInput.Datum.Datum = &models.Datum{
+				DatumHash: utxo.DatumHash,
+				DatumCbor: utxo.Datum,
+			}
+		}
+	}
+}
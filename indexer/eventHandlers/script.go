@@ -0,0 +1,159 @@
+package eventHandlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// outputScriptRefKey is the post-Alonzo output map key of the script reference
+const outputScriptRefKey = 3
+
+// scriptHash returns the hash of a script, prefixed with its language tag
+func scriptHash(language uint8, script []byte) []byte {
+	return lcommon.Blake2b224Hash(append([]byte{language}, script...)).Bytes()
+}
+
+// newScript builds a script registry entry. The first-seen fields are set when the
+// transaction is saved.
+func newScript(language uint8, script []byte) models.Script {
+	return models.Script{
+		ScriptHash: scriptHash(language, script),
+		Language:   language,
+		Cbor:       script,
+		Size:       len(script),
+	}
+}
+
+// referenceScript decodes the script reference of an output, given its CBOR. Legacy
+// (array) outputs and outputs without a script reference return false.
+func referenceScript(outputCbor []byte) (models.Script, bool) {
+	var outputMap map[uint64]cbor.RawMessage
+	if _, err := cbor.Decode(outputCbor, &outputMap); err != nil {
+		return models.Script{}, false
+	}
+	rawScriptRef, ok := outputMap[outputScriptRefKey]
+	if !ok {
+		return models.Script{}, false
+	}
+	// script_ref = #6.24(bytes .cbor [language, script])
+	var scriptRefTag cbor.RawTag
+	if _, err := cbor.Decode(rawScriptRef, &scriptRefTag); err != nil {
+		return models.Script{}, false
+	}
+	var scriptRefCbor []byte
+	if _, err := cbor.Decode(scriptRefTag.Content, &scriptRefCbor); err != nil {
+		return models.Script{}, false
+	}
+	var scriptRef []cbor.RawMessage
+	if _, err := cbor.Decode(scriptRefCbor, &scriptRef); err != nil || len(scriptRef) != 2 {
+		return models.Script{}, false
+	}
+	var language uint8
+	if _, err := cbor.Decode(scriptRef[0], &language); err != nil {
+		return models.Script{}, false
+	}
+	if language == models.ScriptLanguageNative {
+		return newScript(language, scriptRef[1]), true
+	}
+	var script []byte
+	if _, err := cbor.Decode(scriptRef[1], &script); err != nil {
+		return models.Script{}, false
+	}
+	return newScript(language, script), true
+}
+
+// referenceScriptHash returns the hash of the script reference of an output, if any
+func referenceScriptHash(outputCbor []byte) []byte {
+	if script, ok := referenceScript(outputCbor); ok {
+		return script.ScriptHash
+	}
+	return nil
+}
+
+// convertScripts collects the scripts a transaction carries, in its witness set or as
+// output script references, and the hashes of the scripts it executed. Scripts run to
+// spend script-locked inputs, mint under a policy, withdraw from a script stake address,
+// certify a script credential, vote as a script voter or guard a proposal, whether they
+// are supplied in the witness set or by a reference input. The ledger rejects witness
+// scripts that are not needed, so all of them count as executed.
+func convertScripts(logger *slog.Logger, tx lcommon.Transaction, resolvedInputs []lcommon.TransactionOutput, nativeScripts []models.NativeScript) ([]models.Script, [][]byte) {
+	var scripts []models.Script
+	seenScripts := make(map[string]bool)
+	addScript := func(script models.Script) {
+		if !seenScripts[string(script.ScriptHash)] {
+			seenScripts[string(script.ScriptHash)] = true
+			scripts = append(scripts, script)
+		}
+	}
+	var executed [][]byte
+	seenExecuted := make(map[string]bool)
+	addExecuted := func(hash []byte) {
+		if len(hash) > 0 && !seenExecuted[string(hash)] {
+			seenExecuted[string(hash)] = true
+			executed = append(executed, hash)
+		}
+	}
+
+	for _, nativeScript := range nativeScripts {
+		addScript(models.Script{
+			ScriptHash: nativeScript.ScriptHash,
+			Language:   models.ScriptLanguageNative,
+			Cbor:       nativeScript.Cbor,
+			Size:       len(nativeScript.Cbor),
+		})
+	}
+	if witnesses := tx.Witnesses(); witnesses != nil {
+		for _, script := range witnesses.PlutusV1Scripts() {
+			addScript(newScript(models.ScriptLanguagePlutusV1, script))
+		}
+		for _, script := range witnesses.PlutusV2Scripts() {
+			addScript(newScript(models.ScriptLanguagePlutusV2, script))
+		}
+		for _, script := range witnesses.PlutusV3Scripts() {
+			addScript(newScript(models.ScriptLanguagePlutusV3, script))
+		}
+	}
+	for _, script := range scripts {
+		addExecuted(script.ScriptHash)
+	}
+
+	outputs := tx.Outputs()
+	if collateralReturn := tx.CollateralReturn(); collateralReturn != nil {
+		outputs = append(outputs, collateralReturn)
+	}
+	for _, output := range outputs {
+		if script, ok := referenceScript(output.Cbor()); ok {
+			addScript(script)
+		}
+	}
+
+	for _, resolvedInput := range resolvedInputs {
+		addExecuted(credential.PaymentScriptHash(resolvedInput.Address()))
+	}
+	if mint := tx.AssetMint(); mint != nil {
+		for _, policyId := range mint.Policies() {
+			addExecuted(policyId.Bytes())
+		}
+	}
+	for stakeAddress := range tx.Withdrawals() {
+		if stakeAddress != nil {
+			addExecuted(credential.StakeScriptHash(*stakeAddress))
+		}
+	}
+	for _, certificate := range tx.Certificates() {
+		addExecuted(certificateScriptHash(certificate))
+	}
+	for _, voter := range sortedVoters(tx.VotingProcedures()) {
+		addExecuted(voterScriptHash(voter))
+	}
+	for _, proposal := range tx.ProposalProcedures() {
+		addExecuted(govActionPolicyHash(proposal.GovAction.Action))
+	}
+
+	logger.Debug("Processed transaction scripts.", "scripts", len(scripts), "executed", len(executed))
+	return scripts, executed
+}
@@ -0,0 +1,109 @@
+package eventHandlers
+
+import (
+	"bytes"
+	"encoding/hex"
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/txmetadata"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// convertTokenMetadata resolves the token metadata a transaction sets: the CIP-25 metadata
+// of the assets it mints and the CIP-68 metadata held in the inline datums of the
+// reference tokens it outputs. Failed transactions set none. The transaction hash and
+// slot are set when the transaction is saved.
+func convertTokenMetadata(logger *slog.Logger, tx lcommon.Transaction, metadata []byte, mints []models.Mint) []models.TokenMetadata {
+	if !tx.IsValid() {
+		return nil
+	}
+	var tokenMetadata []models.TokenMetadata
+
+	// CIP-25 metadata only applies to the assets minted alongside it
+	minted := make(map[string]models.Mint)
+	for _, mint := range mints {
+		if mint.Quantity > 0 {
+			minted[string(mint.PolicyId)+string(mint.NameHex)] = mint
+		}
+	}
+	entries, err := txmetadata.Entries(metadata)
+	if err != nil {
+		logger.Warn("Failed to decode transaction metadata.", "error", err)
+	}
+	for _, entry := range entries {
+		if entry.Label != txmetadata.CIP25Label || len(minted) == 0 {
+			continue
+		}
+		tokens, err := txmetadata.CIP25(entry.Cbor)
+		if err != nil {
+			logger.Warn("Failed to decode CIP-25 metadata.", "error", err)
+			continue
+		}
+		for _, token := range tokens {
+			mint, ok := minted[hex.EncodeToString(token.PolicyId)+hex.EncodeToString(token.AssetName)]
+			if !ok {
+				continue
+			}
+			tokenMetadata = append(tokenMetadata, models.TokenMetadata{
+				PolicyId:    mint.PolicyId,
+				NameHex:     mint.NameHex,
+				Fingerprint: mint.Fingerprint,
+				Standard:    models.TokenMetadataStandardCIP25,
+				Version:     token.Version,
+				Metadata:    string(token.Json),
+				Cbor:        token.Cbor,
+			})
+		}
+	}
+
+	// CIP-68 metadata is the inline datum of the output holding the reference token
+	referencePrefix := txmetadata.CIP67Prefix(txmetadata.CIP68ReferenceTokenLabel)
+	for i, output := range tx.Outputs() {
+		assets := output.Assets()
+		if assets == nil {
+			continue
+		}
+		for _, policyId := range assets.Policies() {
+			for _, assetName := range assets.Assets(policyId) {
+				if !bytes.HasPrefix(assetName, referencePrefix) {
+					continue
+				}
+				datum := output.Datum()
+				if datum == nil {
+					logger.Warn("CIP-68 reference token output has no inline datum.", "outputIndex", i)
+					continue
+				}
+				metadataJson, version, err := txmetadata.CIP68(datum.Cbor())
+				if err != nil {
+					logger.Warn("Failed to decode CIP-68 datum.", "outputIndex", i, "error", err)
+					continue
+				}
+				outputIndex := uint32(i)
+				tokenMetadata = append(tokenMetadata, models.TokenMetadata{
+					PolicyId:    []byte(policyId.String()),
+					NameHex:     []byte(hex.EncodeToString(assetName)),
+					Fingerprint: []byte(lcommon.NewAssetFingerprint(policyId.Bytes(), assetName).String()),
+					Standard:    models.TokenMetadataStandardCIP68,
+					Version:     version,
+					Metadata:    string(metadataJson),
+					Cbor:        datum.Cbor(),
+					OutputIndex: &outputIndex,
+				})
+			}
+		}
+	}
+	// An asset keeps one metadata record, the last one the transaction sets
+	seen := make(map[string]int)
+	var deduplicated []models.TokenMetadata
+	for _, record := range tokenMetadata {
+		key := string(record.PolicyId) + string(record.NameHex)
+		if index, ok := seen[key]; ok {
+			deduplicated[index] = record
+			continue
+		}
+		seen[key] = len(deduplicated)
+		deduplicated = append(deduplicated, record)
+	}
+	return deduplicated
+}
@@ -0,0 +1,79 @@
+package eventHandlers
+
+import (
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+// txBodyNetworkIdKey is the transaction body map key of the network ID
+const txBodyNetworkIdKey = 15
+
+// convertTxBody collects the body fields that are not stored with the inputs and outputs
+func convertTxBody(logger *slog.Logger, tx ledger.Transaction, txHash []byte) (database.TxBody, error) {
+	body := database.TxBody{
+		ValidityIntervalStart: tx.ValidityIntervalStart(),
+		TotalCollateral:       tx.TotalCollateral(),
+		NetworkId:             txNetworkId(tx.Cbor()),
+		IsValid:               tx.IsValid(),
+		Proposals:             convertProposals(logger, tx),
+		Votes:                 convertVotes(tx),
+		CurrentTreasuryValue:  tx.CurrentTreasuryValue(),
+		TreasuryDonation:      tx.Donation(),
+	}
+
+	for _, input := range tx.Collateral() {
+		body.Collateral = append(body.Collateral, models.CollateralInput{
+			TransactionHash: txHash,
+			UTxOID:          input.Id().Bytes(),
+			UTxOIDIndex:     input.Index(),
+		})
+	}
+
+	// The collateral return is produced after the regular outputs
+	if collateralReturn := tx.CollateralReturn(); collateralReturn != nil {
+		output, err := convertOutput(logger, collateralReturn, txHash, uint32(len(tx.Outputs())))
+		if err != nil {
+			return body, err
+		}
+		body.CollateralReturn = &output
+	}
+
+	for _, signer := range tx.RequiredSigners() {
+		body.RequiredSigners = append(body.RequiredSigners, signer.Bytes())
+	}
+
+	if scriptDataHash := tx.ScriptDataHash(); scriptDataHash != nil {
+		body.ScriptDataHash = scriptDataHash.Bytes()
+	}
+
+	if !body.IsValid {
+		logger.Info("Transaction failed phase-2 validation, collateral is consumed.", "collateralCount", len(body.Collateral))
+	}
+	return body, nil
+}
+
+// txNetworkId reads the optional network ID from the transaction body. The ledger types
+// don't tell an absent network ID apart from testnet (0), so the body map is checked for the key.
+func txNetworkId(txCbor []byte) *uint8 {
+	var txArray []cbor.RawMessage
+	if _, err := cbor.Decode(txCbor, &txArray); err != nil || len(txArray) == 0 {
+		return nil
+	}
+	var bodyMap map[uint64]cbor.RawMessage
+	if _, err := cbor.Decode(txArray[0], &bodyMap); err != nil {
+		return nil
+	}
+	rawNetworkId, ok := bodyMap[txBodyNetworkIdKey]
+	if !ok {
+		return nil
+	}
+	var networkId uint8
+	if _, err := cbor.Decode(rawNetworkId, &networkId); err != nil {
+		return nil
+	}
+	return &networkId
+}
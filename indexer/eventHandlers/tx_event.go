@@ -10,6 +10,7 @@ import (
 	"github.com/Andamio-Platform/andamio-indexer/database"
 	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
 	"github.com/Andamio-Platform/andamio-indexer/database/types"
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
 	input_chainsync "github.com/blinklabs-io/adder/input/chainsync"
 	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
 	fiberLogger "github.com/gofiber/fiber/v2/log"
@@ -92,27 +93,20 @@ func TxEvent(logger *slog.Logger, eventTx input_chainsync.TransactionEvent, even
 		}
 
 		// Convert datum
-		var inputDatum models.Datum
-		if resolvedInput.Datum() != nil || resolvedInput.DatumHash() != nil {
-			logger.Debug("Processing input datum.", "inputIndex", i, "datumHash", fmt.Sprintf("%x", resolvedInput.DatumHash().Bytes()))
-			inputDatum = models.Datum{
-				UTxOID:      inputIdHash,
-				UTxOIDIndex: inputIdIndex,
-				DatumHash:   resolvedInput.DatumHash().Bytes(),
-				DatumCbor:   resolvedInput.Datum().Cbor(),
-			}
-			logger.Debug("Converted input datum.", "inputIndex", i)
-		}
+		inputDatum := convertDatum(resolvedInput, inputIdHash, inputIdIndex)
 
+		inputPaymentCredential, inputStakeCredential := credential.FromAddress(resolvedInput.Address())
 		inputs = append(inputs, models.TransactionInput{
-			TransactionHash: eventTx.Transaction.Hash().Bytes(),
-			UTxOID:          inputIdHash,
-			UTxOIDIndex:     inputIdIndex,
-			Address:         []byte(resolvedInput.Address().String()),
-			Amount:          resolvedInput.Amount(),
-			Asset:           inputAssets,
-			Datum:           inputDatum,
-			Cbor:            resolvedInput.Cbor(),
+			TransactionHash:   eventTx.Transaction.Hash().Bytes(),
+			UTxOID:            inputIdHash,
+			UTxOIDIndex:       inputIdIndex,
+			Address:           []byte(resolvedInput.Address().String()),
+			PaymentCredential: inputPaymentCredential,
+			StakeCredential:   inputStakeCredential,
+			Amount:            resolvedInput.Amount(),
+			Asset:             inputAssets,
+			Datum:             inputDatum,
+			Cbor:              resolvedInput.Cbor(),
 		})
 		logger.Debug("Appended input to list.", "inputIndex", i)
 	}
@@ -124,85 +118,20 @@ func TxEvent(logger *slog.Logger, eventTx input_chainsync.TransactionEvent, even
 	logger.Debug("Processing transaction outputs.", "count", len(eventTx.Outputs))
 	for i, output := range eventTx.Outputs {
 		logger.Debug("Processing output", "index", i)
-
-		outputIdIndex := uint32(i)
-
-		// Convert assets
-		var outputAssets []models.Asset
-		if output.Assets() != nil {
-			logger.Debug("Processing output assets.", "outputIndex", i)
-			assetData, err := output.Assets().MarshalJSON()
-			if err != nil {
-				logger.Error("failed to marshal output assets to JSON", "error", err)
-				return fmt.Errorf("failed to unmarshal transaction body to JSON: %v", err)
-			}
-			var assets []Asset
-			err = json.Unmarshal(assetData, &assets)
-			if err != nil {
-				fiberLogger.Error("failed to unmarshal transaction body to JSON: %v", err)
-
-			}
-			for _, asset := range assets {
-				outputAssets = append(outputAssets, models.Asset{
-					UTxOID:      txHash,
-					UTxOIDIndex: outputIdIndex,
-					Name:        []byte(asset.Name),
-					NameHex:     []byte(asset.NameHex),
-					PolicyId:    []byte(asset.PolicyId),
-					Fingerprint: []byte(asset.Fingerprint),
-					Amount:      uint64(asset.Amount),
-				})
-				logger.Debug("Converted output asset", "outputIndex", i, "fingerprint", asset.Fingerprint, "amount", asset.Amount, "fingerprint_string_value", asset.Fingerprint)
-			}
-			logger.Debug("Finished processing output assets.", "outputIndex", i, "count", len(outputAssets))
+		convertedOutput, err := convertOutput(logger, output, txHash, uint32(i))
+		if err != nil {
+			return err
 		}
-
-		// Convert datum
-		var outputDatum models.Datum
-		if output == nil {
-			logger.Error("Transaction output is nil", "outputIndex", i)
-		} else if output.DatumHash() != nil && output.Datum() != nil { // Only create Datum if both DatumHash and Datum are not nil
-			var datumHashBytes []byte
-			if output.DatumHash() != nil {
-				datumHashBytes = output.DatumHash().Bytes()
-			}
-			var datumCborBytes []byte
-			if output.Datum() != nil {
-				datumCborBytes = output.Datum().Cbor()
-			}
-			logger.Debug("Processing output datum.", "outputIndex", i, "datumHash", fmt.Sprintf("%x", datumHashBytes))
-			outputDatum = models.Datum{
-				UTxOID:      txHash,
-				UTxOIDIndex: outputIdIndex,
-				DatumHash:   datumHashBytes,
-				DatumCbor:   datumCborBytes,
-			}
-			logger.Debug("Converted output datum.", "outputIndex", i)
-		}
-
-		outputs = append(outputs, models.TransactionOutput{
-			UTxOID:      txHash,
-			UTxOIDIndex: outputIdIndex,
-			Address:     []byte(output.Address().String()),
-			Amount:      output.Amount(),
-			Asset:       outputAssets,
-			Datum:       outputDatum,
-			Cbor:        output.Cbor(),
-		})
+		outputs = append(outputs, convertedOutput)
 		logger.Debug("Appended output to list.", "outputIndex", i)
 	}
 	logger.Debug("Finished processing transaction outputs.", "count", len(outputs))
 	// Process and convert eventTx.ReferenceInputs to []models.SimpleUTxO
-	var referenceInputs []models.SimpleUTxO
 	logger.Debug("Processing transaction reference inputs.", "count", len(eventTx.ReferenceInputs))
-	for _, refInput := range eventTx.ReferenceInputs {
-		logger.Debug("Processing reference input", "utxoId", fmt.Sprintf("%x", refInput.Id().Bytes()), "utxoIndex", refInput.Index())
-		referenceInputs = append(referenceInputs, models.SimpleUTxO{
-			TransactionHash: txHash,
-			UTxOID:          refInput.Id().Bytes(),
-			UTxOIDIndex:     refInput.Index(),
-		})
-		logger.Debug("Appended reference input to list.")
+	referenceInputs, err := convertReferenceInputs(logger, eventTx.ReferenceInputs, txHash)
+	if err != nil {
+		logger.Error("failed to resolve reference inputs", "txHash", fmt.Sprintf("%x", txHash), "error", err)
+		return err
 	}
 	logger.Debug("Finished processing transaction reference inputs.", "count", len(referenceInputs))
 
@@ -210,28 +139,8 @@ func TxEvent(logger *slog.Logger, eventTx input_chainsync.TransactionEvent, even
 	var witness models.Witness
 	logger.Debug("Processing transaction witnesses.")
 	// Convert Redeemers
-	var redeemers []models.Redeemer
-	redeemersInterface := eventTx.Witnesses.Redeemers()
-	redeemerTags := []lcommon.RedeemerTag{
-		lcommon.RedeemerTagSpend,
-		lcommon.RedeemerTagMint,
-		lcommon.RedeemerTagCert,
-		lcommon.RedeemerTagReward,
-	}
 	logger.Debug("Processing redeemers.")
-	for _, tag := range redeemerTags {
-		indexes := redeemersInterface.Indexes(tag)
-		logger.Debug("Processing redeemers for tag", "tag", tag, "count", len(indexes))
-		for _, index := range indexes {
-			redeemerValue, _ := redeemersInterface.Value(uint(index), tag)
-			redeemers = append(redeemers, models.Redeemer{
-				Index: uint(index),
-				Tag:   uint8(tag), // Convert uint tag to byte slice
-				Cbor:  redeemerValue.Cbor(),
-			})
-			logger.Debug("Converted redeemer", "index", index, "tag", tag)
-		}
-	}
+	redeemers := convertRedeemers(logger, eventTx.Transaction, eventTx.Inputs, eventTx.ResolvedInputs)
 	logger.Debug("Finished processing redeemers.", "count", len(redeemers))
 
 	var plutusData [][]byte
@@ -249,23 +158,42 @@ func TxEvent(logger *slog.Logger, eventTx input_chainsync.TransactionEvent, even
 		PlutusV2Scripts: types.ByteSliceSlice(eventTx.Witnesses.PlutusV2Scripts()),
 		PlutusV3Scripts: types.ByteSliceSlice(eventTx.Witnesses.PlutusV3Scripts()),
 		Redeemers:       redeemers, // Use the converted redeemers
+		VkeyWitnesses:   convertVkeyWitnesses(eventTx.Witnesses, txHash),
+		NativeScripts:   convertNativeScripts(logger, eventTx.Transaction.Cbor(), txHash),
 	}
 	logger.Debug("Witness data processed.")
 
-	// Process and convert eventTx.Certificates to [][]byte
-	var certificates [][]byte
+	scripts, executedScripts := convertScripts(logger, eventTx.Transaction, eventTx.ResolvedInputs, witness.NativeScripts)
+
+	// Decode eventTx.Certificates
 	logger.Debug("Processing transaction certificates.", "count", len(eventTx.Certificates))
-	for _, cert := range eventTx.Certificates {
-		certificates = append(certificates, cert.Cbor())
-		logger.Debug("Converted certificate.")
-	}
+	certificates := convertCertificates(logger, eventTx.Certificates)
 	logger.Debug("Finished processing transaction certificates.", "count", len(certificates))
 
+	// Process the mint field, linking each policy to its mint redeemer
+	mints := convertMints(eventTx.Transaction.AssetMint(), eventTx.Witnesses.Redeemers())
+	logger.Debug("Finished processing mints.", "count", len(mints))
+
+	// Resolve the CIP-25 and CIP-68 token metadata the transaction sets
+	var metadata []byte
+	if eventTx.Metadata != nil {
+		metadata = eventTx.Metadata.Cbor()
+	}
+	tokenMetadata := convertTokenMetadata(logger, eventTx.Transaction, metadata, mints)
+	logger.Debug("Finished processing token metadata.", "count", len(tokenMetadata))
+
+	// Process the remaining body fields: validity start, collateral, required signers, etc.
+	body, err := convertTxBody(logger, eventTx.Transaction, txHash)
+	if err != nil {
+		logger.Error("failed to convert transaction body", "txHash", fmt.Sprintf("%x", txHash), "error", err)
+		return err
+	}
+
 	logger.Info("Saving transaction to database.", "txHash", fmt.Sprintf("%x", txHash))
 
 	// Extract and store unique addresses from inputs and outputs
 
-	err := txn.DB().NewTx(
+	err = txn.DB().NewTx(
 		[]byte(eventTx.BlockHash),
 		eventCtx.BlockNumber,
 		eventCtx.SlotNumber,
@@ -287,6 +215,11 @@ func TxEvent(logger *slog.Logger, eventTx input_chainsync.TransactionEvent, even
 		eventTx.Withdrawals,
 		witness,
 		certificates,
+		mints,
+		scripts,
+		executedScripts,
+		tokenMetadata,
+		body,
 		eventTx.Transaction.Cbor(),
 		txn,
 	)
@@ -300,3 +233,58 @@ func TxEvent(logger *slog.Logger, eventTx input_chainsync.TransactionEvent, even
 	return nil
 
 }
+
+// convertOutput converts a ledger transaction output produced at txHash#outputIdIndex
+func convertOutput(logger *slog.Logger, output lcommon.TransactionOutput, txHash []byte, outputIdIndex uint32) (models.TransactionOutput, error) {
+	// Convert assets
+	var outputAssets []models.Asset
+	if output.Assets() != nil {
+		logger.Debug("Processing output assets.", "outputIndex", outputIdIndex)
+		assetData, err := output.Assets().MarshalJSON()
+		if err != nil {
+			logger.Error("failed to marshal output assets to JSON", "error", err)
+			return models.TransactionOutput{}, fmt.Errorf("failed to unmarshal transaction body to JSON: %v", err)
+		}
+		var assets []Asset
+		err = json.Unmarshal(assetData, &assets)
+		if err != nil {
+			fiberLogger.Error("failed to unmarshal transaction body to JSON: %v", err)
+
+		}
+		for _, asset := range assets {
+			outputAssets = append(outputAssets, models.Asset{
+				UTxOID:      txHash,
+				UTxOIDIndex: outputIdIndex,
+				Name:        []byte(asset.Name),
+				NameHex:     []byte(asset.NameHex),
+				PolicyId:    []byte(asset.PolicyId),
+				Fingerprint: []byte(asset.Fingerprint),
+				Amount:      uint64(asset.Amount),
+			})
+			logger.Debug("Converted output asset", "outputIndex", outputIdIndex, "fingerprint", asset.Fingerprint, "amount", asset.Amount, "fingerprint_string_value", asset.Fingerprint)
+		}
+		logger.Debug("Finished processing output assets.", "outputIndex", outputIdIndex, "count", len(outputAssets))
+	}
+
+	// Convert datum
+	var outputDatum *models.UTxODatum
+	if output == nil {
+		logger.Error("Transaction output is nil", "outputIndex", outputIdIndex)
+	} else {
+		outputDatum = convertDatum(output, txHash, outputIdIndex)
+	}
+
+	outputPaymentCredential, outputStakeCredential := credential.FromAddress(output.Address())
+	return models.TransactionOutput{
+		UTxOID:              txHash,
+		UTxOIDIndex:         outputIdIndex,
+		Address:             []byte(output.Address().String()),
+		PaymentCredential:   outputPaymentCredential,
+		StakeCredential:     outputStakeCredential,
+		Amount:              output.Amount(),
+		Asset:               outputAssets,
+		Datum:               outputDatum,
+		Cbor:                output.Cbor(),
+		ReferenceScriptHash: referenceScriptHash(output.Cbor()),
+	}, nil
+}
@@ -0,0 +1,89 @@
+package eventHandlers
+
+import (
+	"bytes"
+	"log/slog"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+	"golang.org/x/crypto/sha3"
+)
+
+// witnessSetNativeScriptsKey is the witness set map key of the native scripts
+const witnessSetNativeScriptsKey = 1
+
+// cborSetTag is the CBOR tag 258 prefix Conway uses for witness set entries encoded as sets
+var cborSetTag = []byte{0xd9, 0x01, 0x02}
+
+// bootstrapKeyHashPrefix is the CBOR header of the Byron address spending data
+// (the array [0, [0, xpub]]) up to the 64-byte extended public key
+var bootstrapKeyHashPrefix = []byte{0x83, 0x00, 0x82, 0x00, 0x58, 0x40}
+
+// convertVkeyWitnesses converts the vkey and bootstrap witnesses of a witness set,
+// hashing each key the way the ledger does for required signers
+func convertVkeyWitnesses(witnesses lcommon.TransactionWitnessSet, txHash []byte) []models.VkeyWitness {
+	var vkeyWitnesses []models.VkeyWitness
+	for _, w := range witnesses.Vkey() {
+		vkeyWitnesses = append(vkeyWitnesses, models.VkeyWitness{
+			TransactionHash: txHash,
+			KeyHash:         lcommon.Blake2b224Hash(w.Vkey).Bytes(),
+			Vkey:            w.Vkey,
+			Signature:       w.Signature,
+		})
+	}
+	for _, w := range witnesses.Bootstrap() {
+		vkeyWitnesses = append(vkeyWitnesses, models.VkeyWitness{
+			TransactionHash: txHash,
+			KeyHash:         bootstrapKeyHash(w),
+			Vkey:            w.PublicKey,
+			Signature:       w.Signature,
+			Bootstrap:       true,
+		})
+	}
+	return vkeyWitnesses
+}
+
+// bootstrapKeyHash returns the Byron address root a bootstrap witness signs for
+func bootstrapKeyHash(w lcommon.BootstrapWitness) []byte {
+	var data []byte
+	data = append(data, bootstrapKeyHashPrefix...)
+	data = append(data, w.PublicKey...)
+	data = append(data, w.ChainCode...)
+	data = append(data, w.Attributes...)
+	spendingDataHash := sha3.Sum256(data)
+	return lcommon.Blake2b224Hash(spendingDataHash[:]).Bytes()
+}
+
+// convertNativeScripts extracts the native scripts of a transaction from its CBOR.
+// The scripts are taken as originally encoded so their hashes match the on-chain ones.
+func convertNativeScripts(logger *slog.Logger, txCbor []byte, txHash []byte) []models.NativeScript {
+	var txArray []cbor.RawMessage
+	if _, err := cbor.Decode(txCbor, &txArray); err != nil || len(txArray) < 2 {
+		return nil
+	}
+	var witnessMap map[uint64]cbor.RawMessage
+	if _, err := cbor.Decode(txArray[1], &witnessMap); err != nil {
+		logger.Warn("Failed to decode witness set.", "txHash", txHash, "error", err)
+		return nil
+	}
+	rawScripts, ok := witnessMap[witnessSetNativeScriptsKey]
+	if !ok {
+		return nil
+	}
+	var scripts []cbor.RawMessage
+	if _, err := cbor.Decode(bytes.TrimPrefix(rawScripts, cborSetTag), &scripts); err != nil {
+		logger.Warn("Failed to decode native scripts.", "txHash", txHash, "error", err)
+		return nil
+	}
+
+	var nativeScripts []models.NativeScript
+	for _, script := range scripts {
+		nativeScripts = append(nativeScripts, models.NativeScript{
+			TransactionHash: txHash,
+			ScriptHash:      scriptHash(models.ScriptLanguageNative, script),
+			Cbor:            script,
+		})
+	}
+	return nativeScripts
+}
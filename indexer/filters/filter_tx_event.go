@@ -6,8 +6,10 @@ import (
 
 	"github.com/Andamio-Platform/andamio-indexer/indexer/cache"                       // Import the cache package
 	eventHandlers "github.com/Andamio-Platform/andamio-indexer/indexer/eventHandlers" // Import the eventHandlers package
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
 	"github.com/blinklabs-io/adder/event"
 	input_chainsync "github.com/blinklabs-io/adder/input/chainsync"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
 
 	"github.com/Andamio-Platform/andamio-indexer/database" // Import the database package
 )
@@ -24,7 +26,8 @@ func FilterTxEvent(db *database.Database, evt event.Event) error {
 		relevantDataCache := cache.GetRelevantDataCache()
 		relevantAddresses := relevantDataCache.GetAddresses()
 		relevantPolicies := relevantDataCache.GetPolicies()
-		slog.Debug("Retrieved relevant data from cache.", "addressesCount", len(relevantAddresses), "policiesCount", len(relevantPolicies))
+		relevantFingerprints := relevantDataCache.GetFingerprints()
+		slog.Debug("Retrieved relevant data from cache.", "addressesCount", len(relevantAddresses), "policiesCount", len(relevantPolicies), "fingerprintsCount", len(relevantFingerprints))
 
 		shouldProcess := false
 
@@ -34,12 +37,18 @@ func FilterTxEvent(db *database.Database, evt event.Event) error {
 			// Check address
 			inputAddress := input.Address().String()
 			slog.Debug("Checking input address", "address", inputAddress)
-			for _, addr := range relevantAddresses {
-				if inputAddress == addr {
-					slog.Debug("Input address is relevant.", "address", inputAddress)
-					shouldProcess = true
-					break
-				}
+			if relevantDataCache.IsWatchedAddress(inputAddress, eventCtx.SlotNumber) {
+				slog.Debug("Input address is relevant.", "address", inputAddress)
+				shouldProcess = true
+			}
+			paymentCredential, stakeCredential := credential.FromAddress(input.Address())
+			if relevantDataCache.IsWatchedPaymentCredential(paymentCredential, eventCtx.SlotNumber) {
+				slog.Debug("Input payment credential is relevant.", "address", inputAddress)
+				shouldProcess = true
+			}
+			if relevantDataCache.IsWatchedStakeCredential(stakeCredential, eventCtx.SlotNumber) {
+				slog.Debug("Input stake credential is relevant.", "address", inputAddress)
+				shouldProcess = true
 			}
 			if shouldProcess {
 				slog.Debug("Transaction marked for processing based on input address.")
@@ -51,12 +60,12 @@ func FilterTxEvent(db *database.Database, evt event.Event) error {
 				slog.Debug("Checking input assets policy IDs.")
 				for _, policyId := range input.Assets().Policies() { // Iterate over policy IDs
 					slog.Debug("Checking input asset policy ID", "policyId", policyId.String())
-					for _, relevantPolicy := range relevantPolicies {
-						if policyId.String() == relevantPolicy {
-							slog.Debug("Input asset policy ID is relevant.", "policyId", policyId.String())
-							shouldProcess = true
-							break
-						}
+					if relevantDataCache.IsWatchedPolicy(policyId.String()) {
+						slog.Debug("Input asset policy ID is relevant.", "policyId", policyId.String())
+						shouldProcess = true
+					} else if hasWatchedFingerprint(relevantDataCache, policyId, input.Assets().Assets(policyId)) {
+						slog.Debug("Input asset fingerprint is relevant.", "policyId", policyId.String())
+						shouldProcess = true
 					}
 					if shouldProcess {
 						slog.Debug("Transaction marked for processing based on input asset policy ID.")
@@ -74,12 +83,18 @@ func FilterTxEvent(db *database.Database, evt event.Event) error {
 				// Check address
 				outputAddress := output.Address().String()
 				slog.Debug("Checking output address", "address", outputAddress)
-				for _, addr := range relevantAddresses {
-					if outputAddress == addr {
-						slog.Debug("Output address is relevant.", "address", outputAddress)
-						shouldProcess = true
-						break
-					}
+				if relevantDataCache.IsWatchedAddress(outputAddress, eventCtx.SlotNumber) {
+					slog.Debug("Output address is relevant.", "address", outputAddress)
+					shouldProcess = true
+				}
+				paymentCredential, stakeCredential := credential.FromAddress(output.Address())
+				if relevantDataCache.IsWatchedPaymentCredential(paymentCredential, eventCtx.SlotNumber) {
+					slog.Debug("Output payment credential is relevant.", "address", outputAddress)
+					shouldProcess = true
+				}
+				if relevantDataCache.IsWatchedStakeCredential(stakeCredential, eventCtx.SlotNumber) {
+					slog.Debug("Output stake credential is relevant.", "address", outputAddress)
+					shouldProcess = true
 				}
 				if shouldProcess {
 					slog.Debug("Transaction marked for processing based on output address.")
@@ -91,12 +106,12 @@ func FilterTxEvent(db *database.Database, evt event.Event) error {
 					slog.Debug("Checking output assets policy IDs.")
 					for _, policyId := range output.Assets().Policies() { // Iterate over policy IDs
 						slog.Debug("Checking output asset policy ID", "policyId", policyId.String())
-						for _, relevantPolicy := range relevantPolicies {
-							if policyId.String() == relevantPolicy {
-								slog.Debug("Output asset policy ID is relevant.", "policyId", policyId.String())
-								shouldProcess = true
-								break
-							}
+						if relevantDataCache.IsWatchedPolicy(policyId.String()) {
+							slog.Debug("Output asset policy ID is relevant.", "policyId", policyId.String())
+							shouldProcess = true
+						} else if hasWatchedFingerprint(relevantDataCache, policyId, output.Assets().Assets(policyId)) {
+							slog.Debug("Output asset fingerprint is relevant.", "policyId", policyId.String())
+							shouldProcess = true
 						}
 						if shouldProcess {
 							slog.Debug("Transaction marked for processing based on output asset policy ID.")
@@ -108,6 +123,36 @@ func FilterTxEvent(db *database.Database, evt event.Event) error {
 			slog.Debug("Finished checking transaction outputs.", "shouldProcess", shouldProcess)
 		}
 
+		if !shouldProcess {
+			// Check reward withdrawals from watched stake addresses
+			for stakeAddress := range eventTx.Withdrawals {
+				if relevantDataCache.IsWatchedAddress(stakeAddress, eventCtx.SlotNumber) {
+					slog.Debug("Withdrawal stake address is relevant.", "stakeAddress", stakeAddress)
+					shouldProcess = true
+					break
+				}
+			}
+		}
+
+		if !shouldProcess {
+			// Check certificates registering, deregistering or delegating watched stake credentials
+			for _, cert := range eventTx.Certificates {
+				if relevantDataCache.IsWatchedStakeCredential(credential.FromCertificate(cert), eventCtx.SlotNumber) {
+					slog.Debug("Certificate stake credential is relevant.")
+					shouldProcess = true
+					break
+				}
+			}
+		}
+
+		if !shouldProcess {
+			// Check governance voters and proposal return accounts against watched credentials
+			if hasWatchedGovernanceCredential(relevantDataCache, eventTx.Transaction, eventCtx.SlotNumber) {
+				slog.Debug("Governance credential is relevant.")
+				shouldProcess = true
+			}
+		}
+
 		// If the transaction meets filtering criteria and has a certificate, add to batch
 		if shouldProcess {
 			slog.Info("Transaction meets filtering criteria, adding to batch.", "txHash", fmt.Sprintf("%x", eventTx.Transaction.Hash().Bytes()))
@@ -115,8 +160,50 @@ func FilterTxEvent(db *database.Database, evt event.Event) error {
 		} else {
 			slog.Debug("Transaction does not meet filtering criteria, skipping.", "txHash", fmt.Sprintf("%x", eventTx.Transaction.Hash().Bytes()))
 		}
+	} else if evt.Type == "chainsync.block" {
+		slog.Debug("Processing chainsync.block event")
+
+		eventBlock := evt.Payload.(input_chainsync.BlockEvent)
+		eventCtx := evt.Context.(input_chainsync.BlockContext)
+
+		// Every block is saved, whether or not any of its transactions is relevant
+		txn := db.MetadataTxn(true)
+		return txn.Do(func(txn *database.Txn) error {
+			return eventHandlers.BlockEvent(db.Logger(), eventBlock, eventCtx, txn)
+		})
 	} else {
-		slog.Debug("Event is not a chainsync.transaction, skipping.", "eventType", evt.Type)
+		slog.Debug("Event is not a chainsync.transaction or chainsync.block, skipping.", "eventType", evt.Type)
 	}
 	return nil // Return nil if the event is not a transaction or if filtering passes without error
 }
+
+// hasWatchedFingerprint reports whether any asset of the policy is watched by its fingerprint
+func hasWatchedFingerprint(relevantDataCache *cache.RelevantDataCache, policyId lcommon.Blake2b224, assetNames [][]byte) bool {
+	if !relevantDataCache.HasWatchedFingerprints() {
+		return false
+	}
+	for _, assetName := range assetNames {
+		fingerprint := lcommon.NewAssetFingerprint(policyId.Bytes(), assetName).String()
+		if relevantDataCache.IsWatchedFingerprint(fingerprint) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasWatchedGovernanceCredential reports whether a voter of the transaction or the return
+// account of one of its proposals is a watched credential
+func hasWatchedGovernanceCredential(relevantDataCache *cache.RelevantDataCache, tx lcommon.Transaction, slot uint64) bool {
+	for voter := range tx.VotingProcedures() {
+		if relevantDataCache.IsWatchedCredential(voter.Hash[:], slot) {
+			return true
+		}
+	}
+	for _, proposal := range tx.ProposalProcedures() {
+		_, rewardCredential := credential.FromAddress(proposal.RewardAccount)
+		if relevantDataCache.IsWatchedCredential(rewardCredential, slot) {
+			return true
+		}
+	}
+	return false
+}
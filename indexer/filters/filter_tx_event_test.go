@@ -0,0 +1,52 @@
+package filters
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/Andamio-Platform/andamio-indexer/config"
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/indexer/cache"
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+func TestHasWatchedFingerprint(t *testing.T) {
+	db, err := database.New(nil, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() {
+		db.Close() //nolint:errcheck
+	})
+	if config.GlobalConfig == nil {
+		config.GlobalConfig = &config.Config{}
+	}
+	database.SetGlobalDB(db)
+	relevantDataCache := cache.GetRelevantDataCache()
+
+	// Test vectors from CIP-14
+	policyIdBytes, _ := hex.DecodeString("7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc373")
+	policyId := lcommon.NewBlake2b224(policyIdBytes)
+	watchedName, _ := hex.DecodeString("504154415445")
+	otherName, _ := hex.DecodeString("7eae28af2208be856f7a119668ae52a49b73725e326dc16579dcc373")
+
+	relevantDataCache.LoadCache()
+	if hasWatchedFingerprint(relevantDataCache, policyId, [][]byte{watchedName}) {
+		t.Fatalf("expected no watched fingerprint before one is added")
+	}
+
+	if err := db.Metadata().AddFingerprint(nil, &models.Fingerprint{Fingerprint: "asset13n25uv0yaf5kus35fm2k86cqy60z58d9xmde92"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	relevantDataCache.LoadCache()
+	if !hasWatchedFingerprint(relevantDataCache, policyId, [][]byte{otherName, watchedName}) {
+		t.Fatalf("expected the watched asset of the policy to match")
+	}
+	if hasWatchedFingerprint(relevantDataCache, policyId, [][]byte{otherName, {}}) {
+		t.Fatalf("expected other assets of the policy not to match")
+	}
+	if hasWatchedFingerprint(relevantDataCache, policyId, nil) {
+		t.Fatalf("expected a policy without assets not to match")
+	}
+}
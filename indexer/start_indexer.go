@@ -116,7 +116,7 @@ func StartIndexer(db *database.Database, logger plugin.Logger) error {
 
 	// Define type in event filter
 	filterEvent := filter_event.New(
-		filter_event.WithTypes([]string{"chainsync.transaction"}),
+		filter_event.WithTypes([]string{"chainsync.transaction", "chainsync.block"}),
 	)
 	// Add event filter to pipeline
 	p.AddFilter(filterEvent)
@@ -0,0 +1,31 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	// keyPrefix marks a string as an Andamio indexer API key
+	keyPrefix = "andamio_"
+	// displayPrefixLength is the number of characters kept in clear text to identify a key
+	displayPrefixLength = len(keyPrefix) + 8
+)
+
+// Generate creates a new random API key. It returns the raw key, which is only shown once,
+// and a short prefix that can be stored alongside the hash to identify the key later.
+func Generate() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	key := keyPrefix + hex.EncodeToString(buf)
+	return key, key[:displayPrefixLength], nil
+}
+
+// Hash returns the SHA-256 hash of a raw API key as stored in the metadata store.
+func Hash(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
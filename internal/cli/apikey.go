@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/internal/apikey"
+)
+
+const apiKeyUsage = `usage: andamio-indexer -config <file> apikey <command> [flags]
+
+commands:
+  create -name <name> -scope read|admin   mint a new API key and print it once
+         [-daily-quota <rows>]           override the configured daily row quota
+  revoke -id <id>                         revoke an API key
+  list                                    list all API keys`
+
+// RunAPIKeyCommand runs the "apikey" subcommand against the metadata store.
+func RunAPIKeyCommand(store metadata.MetadataStore, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(apiKeyUsage)
+	}
+	switch args[0] {
+	case "create":
+		return createAPIKey(store, args[1:], out)
+	case "revoke":
+		return revokeAPIKey(store, args[1:], out)
+	case "list":
+		return listAPIKeys(store, out)
+	default:
+		return fmt.Errorf("unknown apikey command %q\n%s", args[0], apiKeyUsage)
+	}
+}
+
+func createAPIKey(store metadata.MetadataStore, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+	name := fs.String("name", "", "name identifying the key holder")
+	scope := fs.String("scope", models.APIKeyScopeRead, "key scope: read or admin")
+	dailyQuota := fs.Int64("daily-quota", 0, "daily row quota for this key (0 uses the configured quota)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return errors.New("-name is required")
+	}
+	if *dailyQuota < 0 {
+		return errors.New("-daily-quota cannot be negative")
+	}
+
+	rawKey, prefix, err := apikey.Generate()
+	if err != nil {
+		return fmt.Errorf("failed to generate API key: %w", err)
+	}
+	newKey := models.APIKey{
+		Name:          *name,
+		Prefix:        prefix,
+		KeyHash:       apikey.Hash(rawKey),
+		Scope:         *scope,
+		DailyRowQuota: *dailyQuota,
+	}
+	if err := store.AddAPIKey(nil, &newKey); err != nil {
+		return fmt.Errorf("failed to store API key: %w", err)
+	}
+
+	fmt.Fprintf(out, "Created %s API key %d for %q.\n", newKey.Scope, newKey.ID, newKey.Name)
+	fmt.Fprintf(out, "Key (shown only once): %s\n", rawKey)
+	return nil
+}
+
+func revokeAPIKey(store metadata.MetadataStore, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("apikey revoke", flag.ContinueOnError)
+	id := fs.Uint("id", 0, "ID of the key to revoke")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == 0 {
+		return errors.New("-id is required")
+	}
+	if err := store.RevokeAPIKey(nil, *id); err != nil {
+		return fmt.Errorf("failed to revoke API key %d: %w", *id, err)
+	}
+	fmt.Fprintf(out, "Revoked API key %d.\n", *id)
+	return nil
+}
+
+func listAPIKeys(store metadata.MetadataStore, out io.Writer) error {
+	apiKeys, err := store.GetAllAPIKeys(nil)
+	if err != nil {
+		return fmt.Errorf("failed to list API keys: %w", err)
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPREFIX\tSCOPE\tDAILY QUOTA\tCREATED\tREVOKED")
+	for _, k := range apiKeys {
+		revoked := "-"
+		if k.RevokedAt != nil {
+			revoked = k.RevokedAt.Format(time.RFC3339)
+		}
+		quota := "default"
+		if k.DailyRowQuota > 0 {
+			quota = strconv.FormatInt(k.DailyRowQuota, 10)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", k.ID, k.Name, k.Prefix, k.Scope, quota, k.CreatedAt.Format(time.RFC3339), revoked)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,141 @@
+package credential
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// CredentialHashSize is the size of a payment or stake credential hash (Blake2b-224)
+const CredentialHashSize = lcommon.AddressHashSize
+
+// FromAddress returns the payment and stake credential hashes of an address. Parts the
+// address does not have (Byron addresses, enterprise and pointer stake parts, reward
+// address payment parts) are returned as empty, non-nil slices so that they are stored
+// as empty values rather than NULL.
+func FromAddress(addr lcommon.Address) ([]byte, []byte) {
+	payment, stake := []byte{}, []byte{}
+	switch addr.Type() {
+	case lcommon.AddressTypeKeyKey, lcommon.AddressTypeScriptKey,
+		lcommon.AddressTypeKeyScript, lcommon.AddressTypeScriptScript:
+		payment = addr.PaymentKeyHash().Bytes()
+		stake = addr.StakeKeyHash().Bytes()
+	case lcommon.AddressTypeKeyPointer, lcommon.AddressTypeScriptPointer,
+		lcommon.AddressTypeKeyNone, lcommon.AddressTypeScriptNone:
+		payment = addr.PaymentKeyHash().Bytes()
+	case lcommon.AddressTypeNoneKey, lcommon.AddressTypeNoneScript:
+		stake = addr.StakeKeyHash().Bytes()
+	}
+	return payment, stake
+}
+
+// FromAddressString parses a bech32 or base58 address and returns its payment and stake
+// credential hashes, like FromAddress.
+func FromAddressString(addr string) ([]byte, []byte, error) {
+	parsed, err := lcommon.NewAddress(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	payment, stake := FromAddress(parsed)
+	return payment, stake, nil
+}
+
+// IsStakeAddress reports whether the string looks like a bech32 stake (reward) address.
+func IsStakeAddress(addr string) bool {
+	return strings.HasPrefix(addr, "stake1") || strings.HasPrefix(addr, "stake_test1")
+}
+
+// ParseStakeCredential returns the stake credential hash for a bech32 stake address or a
+// hex encoded stake credential hash.
+func ParseStakeCredential(s string) ([]byte, error) {
+	if IsStakeAddress(s) {
+		_, stake, err := FromAddressString(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(stake) != CredentialHashSize {
+			return nil, errors.New("not a stake address")
+		}
+		return stake, nil
+	}
+	cred, err := hex.DecodeString(s)
+	if err != nil || len(cred) != CredentialHashSize {
+		return nil, errors.New("must be a bech32 stake address or a hex encoded 28 byte credential hash")
+	}
+	return cred, nil
+}
+
+// IsCredentialHash reports whether the string is a hex encoded 28 byte credential hash,
+// i.e. a payment key hash or a script hash.
+func IsCredentialHash(s string) bool {
+	cred, err := hex.DecodeString(s)
+	return err == nil && len(cred) == CredentialHashSize
+}
+
+// ParsePaymentCredential returns the payment credential hash for a hex encoded payment
+// key hash or script hash, or for any address carrying a payment part.
+func ParsePaymentCredential(s string) ([]byte, error) {
+	if IsCredentialHash(s) {
+		return hex.DecodeString(s)
+	}
+	payment, _, err := FromAddressString(s)
+	if err != nil || len(payment) != CredentialHashSize {
+		return nil, errors.New("must be a hex encoded 28 byte key or script hash, or an address with a payment part")
+	}
+	return payment, nil
+}
+
+// PaymentScriptHash returns the payment part of an address when it is a script hash,
+// or nil when the address is locked by a key or has no payment part.
+func PaymentScriptHash(addr lcommon.Address) []byte {
+	switch addr.Type() {
+	case lcommon.AddressTypeScriptKey, lcommon.AddressTypeScriptScript,
+		lcommon.AddressTypeScriptPointer, lcommon.AddressTypeScriptNone:
+		return addr.PaymentKeyHash().Bytes()
+	}
+	return nil
+}
+
+// StakeScriptHash returns the stake part of an address when it is a script hash,
+// or nil when it is a key hash or the address has no stake credential.
+func StakeScriptHash(addr lcommon.Address) []byte {
+	switch addr.Type() {
+	case lcommon.AddressTypeKeyScript, lcommon.AddressTypeScriptScript, lcommon.AddressTypeNoneScript:
+		return addr.StakeKeyHash().Bytes()
+	}
+	return nil
+}
+
+// FromCertificate returns the stake credential hash a certificate registers, deregisters
+// or delegates, or nil when the certificate has none.
+func FromCertificate(cert lcommon.Certificate) []byte {
+	var cred *lcommon.Credential
+	switch c := cert.(type) {
+	case *lcommon.StakeRegistrationCertificate:
+		cred = &c.StakeRegistration
+	case *lcommon.StakeDeregistrationCertificate:
+		cred = &c.StakeDeregistration
+	case *lcommon.StakeDelegationCertificate:
+		cred = c.StakeCredential
+	case *lcommon.RegistrationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.DeregistrationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.VoteDelegationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.StakeVoteDelegationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.StakeRegistrationDelegationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.VoteRegistrationDelegationCertificate:
+		cred = &c.StakeCredential
+	case *lcommon.StakeVoteRegistrationDelegationCertificate:
+		cred = &c.StakeCredential
+	}
+	if cred == nil {
+		return nil
+	}
+	return cred.Credential.Bytes()
+}
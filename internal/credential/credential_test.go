@@ -0,0 +1,143 @@
+package credential
+
+import (
+	"encoding/hex"
+	"testing"
+
+	lcommon "github.com/blinklabs-io/gouroboros/ledger/common"
+)
+
+// Test vectors from CIP-19
+const (
+	testStakeKeyHash   = "337b62cfff6403a06a3acbc34f8c46003c69fe79a3628cefa9c47251"
+	testPaymentKeyHash = "9493315cd92eb5d8c4304e67b7e16ae36d61d34502694657811a2c8e"
+	testScriptHash     = "c37b1b5dc0669f1d3c61a6fddb2e8fde96be87b881c60bce8e8d542f"
+)
+
+func TestFromAddressString(t *testing.T) {
+	testDefs := []struct {
+		address string
+		payment string
+		stake   string
+	}{
+		{
+			address: "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x",
+			payment: testPaymentKeyHash,
+			stake:   testStakeKeyHash,
+		},
+		{
+			address: "addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8",
+			payment: testPaymentKeyHash,
+			stake:   "",
+		},
+		{
+			address: "stake1uyehkck0lajq8gr28t9uxnuvgcqrc6070x3k9r8048z8y5gh6ffgw",
+			payment: "",
+			stake:   testStakeKeyHash,
+		},
+	}
+	for _, testDef := range testDefs {
+		payment, stake, err := FromAddressString(testDef.address)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %s", testDef.address, err)
+		}
+		if hex.EncodeToString(payment) != testDef.payment {
+			t.Errorf("unexpected payment credential for %s: got %x, wanted %s", testDef.address, payment, testDef.payment)
+		}
+		if hex.EncodeToString(stake) != testDef.stake {
+			t.Errorf("unexpected stake credential for %s: got %x, wanted %s", testDef.address, stake, testDef.stake)
+		}
+	}
+}
+
+func TestParseStakeCredential(t *testing.T) {
+	for _, input := range []string{"stake1uyehkck0lajq8gr28t9uxnuvgcqrc6070x3k9r8048z8y5gh6ffgw", testStakeKeyHash} {
+		cred, err := ParseStakeCredential(input)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %s", input, err)
+		}
+		if hex.EncodeToString(cred) != testStakeKeyHash {
+			t.Errorf("unexpected stake credential for %s: got %x", input, cred)
+		}
+	}
+	if _, err := ParseStakeCredential("addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8"); err == nil {
+		t.Errorf("expected error for payment address")
+	}
+}
+
+func TestParsePaymentCredential(t *testing.T) {
+	for _, input := range []string{
+		testPaymentKeyHash,
+		"addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x",
+		"addr1vx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzers66hrl8",
+	} {
+		cred, err := ParsePaymentCredential(input)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %s", input, err)
+		}
+		if hex.EncodeToString(cred) != testPaymentKeyHash {
+			t.Errorf("unexpected payment credential for %s: got %x", input, cred)
+		}
+	}
+	if _, err := ParsePaymentCredential("stake1uyehkck0lajq8gr28t9uxnuvgcqrc6070x3k9r8048z8y5gh6ffgw"); err == nil {
+		t.Errorf("expected error for stake address")
+	}
+}
+
+func TestScriptHashes(t *testing.T) {
+	testDefs := []struct {
+		address string
+		payment string
+		stake   string
+	}{
+		{
+			address: "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x",
+			payment: "",
+			stake:   "",
+		},
+		{
+			address: "addr1z8phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gten0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgs9yc0hh",
+			payment: testScriptHash,
+			stake:   "",
+		},
+		{
+			address: "stake178phkx6acpnf78fuvxn0mkew3l0fd058hzquvz7w36x4gtcccycj5",
+			payment: "",
+			stake:   testScriptHash,
+		},
+	}
+	for _, testDef := range testDefs {
+		addr, err := lcommon.NewAddress(testDef.address)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %s", testDef.address, err)
+		}
+		if payment := PaymentScriptHash(addr); hex.EncodeToString(payment) != testDef.payment {
+			t.Errorf("unexpected payment script hash for %s: got %x, wanted %s", testDef.address, payment, testDef.payment)
+		}
+		if stake := StakeScriptHash(addr); hex.EncodeToString(stake) != testDef.stake {
+			t.Errorf("unexpected stake script hash for %s: got %x, wanted %s", testDef.address, stake, testDef.stake)
+		}
+	}
+}
+
+func TestFromCertificate(t *testing.T) {
+	scriptHash, _ := hex.DecodeString(testScriptHash)
+	cred := lcommon.Credential{
+		CredType:   lcommon.CredentialTypeScriptHash,
+		Credential: lcommon.NewBlake2b224(scriptHash),
+	}
+	testDefs := []struct {
+		cert     lcommon.Certificate
+		expected string
+	}{
+		{cert: &lcommon.StakeRegistrationCertificate{StakeRegistration: cred}, expected: testScriptHash},
+		{cert: &lcommon.StakeDelegationCertificate{StakeCredential: &cred}, expected: testScriptHash},
+		{cert: &lcommon.VoteDelegationCertificate{StakeCredential: cred}, expected: testScriptHash},
+		{cert: &lcommon.PoolRetirementCertificate{Epoch: 500}, expected: ""},
+	}
+	for _, testDef := range testDefs {
+		if stake := FromCertificate(testDef.cert); hex.EncodeToString(stake) != testDef.expected {
+			t.Errorf("unexpected stake credential for %T: got %x, wanted %s", testDef.cert, stake, testDef.expected)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package kupo looks up UTxOs in Kupo, the chain index the chain sync input resolves the
+// inputs a transaction spends with.
+package kupo
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds each request made to Kupo
+const requestTimeout = 30 * time.Second
+
+// Client looks up UTxOs in a Kupo instance
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// New returns a client for the Kupo instance at url
+func New(url string) *Client {
+	return &Client{
+		url:        strings.TrimRight(url, "/"),
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Asset is an amount of a native asset held by a UTxO
+type Asset struct {
+	PolicyId []byte
+	Name     []byte
+	Amount   uint64
+}
+
+// UTxO is a transaction output as indexed by Kupo. Kupo does not serve the CBOR of the
+// output; the datum is only filled in for inline datums.
+type UTxO struct {
+	Address     string
+	Amount      uint64
+	Assets      []Asset
+	DatumHash   []byte
+	Datum       []byte
+	InlineDatum bool
+	ScriptHash  []byte
+}
+
+// match is a UTxO in the JSON served by Kupo's /matches endpoint
+type match struct {
+	Address string `json:"address"`
+	Value   struct {
+		Coins  uint64            `json:"coins"`
+		Assets map[string]uint64 `json:"assets"`
+	} `json:"value"`
+	DatumHash  *string `json:"datum_hash"`
+	DatumType  *string `json:"datum_type"`
+	ScriptHash *string `json:"script_hash"`
+}
+
+// GetUTxO returns the output at index of the transaction with the given hash, spent or
+// not, or nil if Kupo has not indexed it.
+func (c *Client) GetUTxO(ctx context.Context, txHash []byte, index uint32) (*UTxO, error) {
+	var matches []match
+	if err := c.get(ctx, fmt.Sprintf("/matches/%d@%x", index, txHash), &matches); err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	m := matches[0]
+	utxo := &UTxO{
+		Address: m.Address,
+		Amount:  m.Value.Coins,
+	}
+	units := make([]string, 0, len(m.Value.Assets))
+	for unit := range m.Value.Assets {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	for _, unit := range units {
+		asset, err := parseAsset(unit, m.Value.Assets[unit])
+		if err != nil {
+			return nil, err
+		}
+		utxo.Assets = append(utxo.Assets, asset)
+	}
+	if m.ScriptHash != nil {
+		scriptHash, err := hex.DecodeString(*m.ScriptHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid script hash %q: %w", *m.ScriptHash, err)
+		}
+		utxo.ScriptHash = scriptHash
+	}
+	if m.DatumHash == nil {
+		return utxo, nil
+	}
+	datumHash, err := hex.DecodeString(*m.DatumHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid datum hash %q: %w", *m.DatumHash, err)
+	}
+	utxo.DatumHash = datumHash
+	if m.DatumType == nil || *m.DatumType != "inline" {
+		return utxo, nil
+	}
+	var datum *struct {
+		Datum string `json:"datum"`
+	}
+	if err := c.get(ctx, "/datums/"+*m.DatumHash, &datum); err != nil {
+		return nil, err
+	}
+	if datum != nil {
+		utxo.Datum, err = hex.DecodeString(datum.Datum)
+		if err != nil {
+			return nil, fmt.Errorf("invalid datum %s: %w", *m.DatumHash, err)
+		}
+		utxo.InlineDatum = true
+	}
+	return utxo, nil
+}
+
+// parseAsset parses an asset of a Kupo value, keyed by its policy ID and name in hex,
+// separated by a dot. Assets with an empty name are keyed by their policy ID alone.
+func parseAsset(unit string, amount uint64) (Asset, error) {
+	policyHex, nameHex, _ := strings.Cut(unit, ".")
+	policyId, err := hex.DecodeString(policyHex)
+	if err != nil {
+		return Asset{}, fmt.Errorf("invalid asset %q: %w", unit, err)
+	}
+	name, err := hex.DecodeString(nameHex)
+	if err != nil {
+		return Asset{}, fmt.Errorf("invalid asset %q: %w", unit, err)
+	}
+	return Asset{PolicyId: policyId, Name: name, Amount: amount}, nil
+}
+
+// get fetches a Kupo endpoint and decodes its JSON response into result
+func (c *Client) get(ctx context.Context, path string, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query Kupo: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to query Kupo: %s returned %s", path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode Kupo response for %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package kupo
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	testTxHash     = "0bd0cd3f2c5dd4ab3d8d7f2ee47fbc08cc0d4fb4e3e0d1fd3d35c4a1d6dd0bca"
+	testPolicyId   = "c37b1b5dc0669f1d3c61a6fddb2e8fde96be87b881c60bce8e8d542f"
+	testDatumHash  = "923918e403bf43c34b4ef6b48eb2ee04babed17320d8d1b9ff9ad086e86f44ec"
+	testScriptHash = "9493315cd92eb5d8c4304e67b7e16ae36d61d34502694657811a2c8e"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/matches/1@"+testTxHash, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{
+			"transaction_id": "` + testTxHash + `",
+			"output_index": 1,
+			"address": "addr_test1vz",
+			"value": {"coins": 2000000, "assets": {"` + testPolicyId + `.4d79546f6b656e": 5, "` + testPolicyId + `": 1}},
+			"datum_hash": "` + testDatumHash + `",
+			"datum_type": "inline",
+			"script_hash": "` + testScriptHash + `"
+		}]`)) //nolint:errcheck
+	})
+	mux.HandleFunc("/matches/0@"+testTxHash, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`)) //nolint:errcheck
+	})
+	mux.HandleFunc("/datums/"+testDatumHash, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"datum": "d87980"}`)) //nolint:errcheck
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetUTxO(t *testing.T) {
+	server := newTestServer(t)
+	client := New(server.URL + "/")
+	txHash, _ := hex.DecodeString(testTxHash)
+
+	utxo, err := client.GetUTxO(context.Background(), txHash, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if utxo == nil {
+		t.Fatalf("expected a UTxO")
+	}
+	if utxo.Address != "addr_test1vz" || utxo.Amount != 2000000 {
+		t.Fatalf("unexpected address or amount: %+v", utxo)
+	}
+	if len(utxo.Assets) != 2 {
+		t.Fatalf("expected 2 assets, got: %+v", utxo.Assets)
+	}
+	if len(utxo.Assets[0].Name) != 0 || utxo.Assets[0].Amount != 1 {
+		t.Fatalf("unexpected asset without name: %+v", utxo.Assets[0])
+	}
+	if string(utxo.Assets[1].Name) != "MyToken" || utxo.Assets[1].Amount != 5 || hex.EncodeToString(utxo.Assets[1].PolicyId) != testPolicyId {
+		t.Fatalf("unexpected asset: %+v", utxo.Assets[1])
+	}
+	if hex.EncodeToString(utxo.DatumHash) != testDatumHash || !utxo.InlineDatum || !bytes.Equal(utxo.Datum, []byte{0xd8, 0x79, 0x80}) {
+		t.Fatalf("unexpected datum: %+v", utxo)
+	}
+	if hex.EncodeToString(utxo.ScriptHash) != testScriptHash {
+		t.Fatalf("unexpected script hash: %x", utxo.ScriptHash)
+	}
+
+	missing, err := client.GetUTxO(context.Background(), txHash, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if missing != nil {
+		t.Fatalf("expected no UTxO, got: %+v", missing)
+	}
+	if _, err := client.GetUTxO(context.Background(), txHash, 2); err == nil {
+		t.Fatalf("expected an error for a failed request")
+	}
+}
+
+func TestParseAsset(t *testing.T) {
+	testDefs := []struct {
+		unit      string
+		name      string
+		expectErr bool
+	}{
+		{unit: testPolicyId + ".4d79546f6b656e", name: "MyToken"},
+		{unit: testPolicyId, name: ""},
+		{unit: "zz.00", expectErr: true},
+		{unit: testPolicyId + ".zz", expectErr: true},
+	}
+	for _, testDef := range testDefs {
+		asset, err := parseAsset(testDef.unit, 1)
+		if testDef.expectErr {
+			if err == nil {
+				t.Fatalf("expected an error for %q", testDef.unit)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", testDef.unit, err)
+		}
+		if string(asset.Name) != testDef.name || hex.EncodeToString(asset.PolicyId) != testPolicyId {
+			t.Fatalf("unexpected asset for %q: %+v", testDef.unit, asset)
+		}
+	}
+}
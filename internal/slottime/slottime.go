@@ -0,0 +1,129 @@
+package slottime
+
+import (
+	"time"
+)
+
+// Network magics of the public networks
+const (
+	MainnetMagic = 764824073
+	PreprodMagic = 1
+	PreviewMagic = 2
+)
+
+// Params are the chain time parameters of a network: the Byron era start, slot length and
+// epoch length, and the slot and epoch from which Shelley slot and epoch lengths apply
+type Params struct {
+	SystemStart       time.Time
+	ByronSlotLength   time.Duration
+	ByronEpochLength  uint64
+	ShelleyStartSlot  uint64
+	ShelleyStartEpoch uint64
+	SlotLength        time.Duration
+	EpochLength       uint64
+}
+
+var networks = map[uint32]Params{
+	MainnetMagic: {
+		SystemStart:       time.Date(2017, time.September, 23, 21, 44, 51, 0, time.UTC),
+		ByronSlotLength:   20 * time.Second,
+		ByronEpochLength:  21600,
+		ShelleyStartSlot:  4492800,
+		ShelleyStartEpoch: 208,
+		SlotLength:        time.Second,
+		EpochLength:       432000,
+	},
+	PreprodMagic: {
+		SystemStart:       time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC),
+		ByronSlotLength:   20 * time.Second,
+		ByronEpochLength:  21600,
+		ShelleyStartSlot:  86400,
+		ShelleyStartEpoch: 4,
+		SlotLength:        time.Second,
+		EpochLength:       432000,
+	},
+	PreviewMagic: {
+		SystemStart:       time.Date(2022, time.October, 25, 0, 0, 0, 0, time.UTC),
+		ByronSlotLength:   20 * time.Second,
+		ByronEpochLength:  4320,
+		ShelleyStartSlot:  0,
+		ShelleyStartEpoch: 0,
+		SlotLength:        time.Second,
+		EpochLength:       86400,
+	},
+}
+
+// ForNetwork returns the chain time parameters of a public network, or false when the
+// network magic is not one of them
+func ForNetwork(magic uint32) (Params, bool) {
+	params, ok := networks[magic]
+	return params, ok
+}
+
+// shelleyStart is the time of the first Shelley slot
+func (p Params) shelleyStart() time.Time {
+	return p.SystemStart.Add(time.Duration(p.ShelleyStartSlot) * p.ByronSlotLength)
+}
+
+// SlotToTime returns the start time of a slot
+func (p Params) SlotToTime(slot uint64) time.Time {
+	if slot < p.ShelleyStartSlot {
+		return p.SystemStart.Add(time.Duration(slot) * p.ByronSlotLength)
+	}
+	return p.shelleyStart().Add(time.Duration(slot-p.ShelleyStartSlot) * p.SlotLength)
+}
+
+// TimeToSlot returns the slot in progress at a time, or the first slot for times before
+// the system start
+func (p Params) TimeToSlot(t time.Time) uint64 {
+	if !t.After(p.SystemStart) {
+		return 0
+	}
+	shelleyStart := p.shelleyStart()
+	if t.Before(shelleyStart) {
+		return uint64(t.Sub(p.SystemStart) / p.ByronSlotLength)
+	}
+	return p.ShelleyStartSlot + uint64(t.Sub(shelleyStart)/p.SlotLength)
+}
+
+// SlotToEpoch returns the epoch a slot belongs to
+func (p Params) SlotToEpoch(slot uint64) uint64 {
+	if slot < p.ShelleyStartSlot {
+		return slot / p.ByronEpochLength
+	}
+	return p.ShelleyStartEpoch + (slot-p.ShelleyStartSlot)/p.EpochLength
+}
+
+// EpochStartSlot returns the first slot of an epoch
+func (p Params) EpochStartSlot(epoch uint64) uint64 {
+	if epoch < p.ShelleyStartEpoch {
+		return epoch * p.ByronEpochLength
+	}
+	return p.ShelleyStartSlot + (epoch-p.ShelleyStartEpoch)*p.EpochLength
+}
+
+// Valid reports whether the parameters are complete: a system start, Shelley slot and
+// epoch lengths, and Byron slot and epoch lengths when the network started in Byron
+func (p Params) Valid() bool {
+	if p.SystemStart.IsZero() || p.SlotLength <= 0 || p.EpochLength == 0 {
+		return false
+	}
+	if p.ShelleyStartSlot > 0 && (p.ByronSlotLength <= 0 || p.ByronEpochLength == 0) {
+		return false
+	}
+	return true
+}
+
+// SlotRange returns the first and last slots starting between two times, inclusive, or
+// false when no slot does
+func (p Params) SlotRange(from, to time.Time) (uint64, uint64, bool) {
+	first := p.TimeToSlot(from)
+	if p.SlotToTime(first).Before(from) {
+		first++
+	}
+	last := p.TimeToSlot(to)
+	if p.SlotToTime(last).After(to) || last < first {
+		return 0, 0, false
+	}
+	return first, last, true
+}
@@ -0,0 +1,73 @@
+package slottime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlotConversion(t *testing.T) {
+	testDefs := []struct {
+		magic uint32
+		slot  uint64
+		time  time.Time
+		epoch uint64
+	}{
+		// Shelley hard fork
+		{MainnetMagic, 4492800, time.Date(2020, time.July, 29, 21, 44, 51, 0, time.UTC), 208},
+		{MainnetMagic, 4492799, time.Date(2020, time.July, 29, 21, 44, 31, 0, time.UTC), 207},
+		{MainnetMagic, 4924800, time.Date(2020, time.August, 3, 21, 44, 51, 0, time.UTC), 209},
+		{PreprodMagic, 86400, time.Date(2022, time.June, 21, 0, 0, 0, 0, time.UTC), 4},
+		{PreviewMagic, 86400, time.Date(2022, time.October, 26, 0, 0, 0, 0, time.UTC), 1},
+	}
+	for _, testDef := range testDefs {
+		params, ok := ForNetwork(testDef.magic)
+		if !ok {
+			t.Fatalf("no parameters for network %d", testDef.magic)
+		}
+		if got := params.SlotToTime(testDef.slot); !got.Equal(testDef.time) {
+			t.Fatalf("network %d slot %d: expected time %s, got %s", testDef.magic, testDef.slot, testDef.time, got)
+		}
+		if got := params.TimeToSlot(testDef.time); got != testDef.slot {
+			t.Fatalf("network %d time %s: expected slot %d, got %d", testDef.magic, testDef.time, testDef.slot, got)
+		}
+		if got := params.SlotToEpoch(testDef.slot); got != testDef.epoch {
+			t.Fatalf("network %d slot %d: expected epoch %d, got %d", testDef.magic, testDef.slot, testDef.epoch, got)
+		}
+	}
+	if _, ok := ForNetwork(42); ok {
+		t.Fatalf("expected no parameters for an unknown network")
+	}
+}
+
+func TestSlotRange(t *testing.T) {
+	params, _ := ForNetwork(PreprodMagic)
+	shelleyStart := time.Date(2022, time.June, 21, 0, 0, 0, 0, time.UTC)
+	testDefs := []struct {
+		from  time.Time
+		to    time.Time
+		first uint64
+		last  uint64
+		ok    bool
+	}{
+		{shelleyStart, shelleyStart.Add(time.Minute), 86400, 86460, true},
+		// Times within a slot start the range at the next slot and end it at that slot
+		{shelleyStart.Add(500 * time.Millisecond), shelleyStart.Add(1500 * time.Millisecond), 86401, 86401, true},
+		{shelleyStart.Add(100 * time.Millisecond), shelleyStart.Add(900 * time.Millisecond), 0, 0, false},
+		// Byron slots last 20 seconds
+		{shelleyStart.Add(-time.Minute), shelleyStart.Add(-time.Second), 86397, 86399, true},
+		{shelleyStart, shelleyStart.Add(-time.Second), 0, 0, false},
+		{time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC), 0, 0, false},
+	}
+	for _, testDef := range testDefs {
+		first, last, ok := params.SlotRange(testDef.from, testDef.to)
+		if ok != testDef.ok || first != testDef.first || last != testDef.last {
+			t.Fatalf("range %s to %s: expected %d-%d %t, got %d-%d %t", testDef.from, testDef.to, testDef.first, testDef.last, testDef.ok, first, last, ok)
+		}
+	}
+	if !params.Valid() {
+		t.Fatalf("expected preprod parameters to be valid")
+	}
+	if (Params{SystemStart: params.SystemStart, ShelleyStartSlot: 86400, SlotLength: time.Second, EpochLength: 432000}).Valid() {
+		t.Fatalf("expected parameters without Byron lengths to be invalid")
+	}
+}
@@ -0,0 +1,317 @@
+package txmetadata
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+// CIP25Label is the metadata label of CIP-25 NFT metadata
+const CIP25Label = 721
+
+// CIP-67 asset name labels of CIP-68 tokens
+const (
+	CIP68ReferenceTokenLabel = 100
+	CIP68NFTLabel            = 222
+	CIP68FTLabel             = 333
+	CIP68RFTLabel            = 444
+)
+
+const (
+	cip25VersionKey = "version"
+	cip25Version1   = 1
+	// cip67PrefixSize is the size of the CIP-67 label prefix of an asset name
+	cip67PrefixSize = 4
+	// Plutus data constructor tags, see CIP-68 and the Plutus data CDDL
+	constrTagBase         = 121
+	constrTagMax          = 127
+	constrTagExtendedBase = 1280
+	constrTagExtendedMax  = 1400
+	constrTagGeneral      = 102
+)
+
+// TokenMetadata is the CIP-25 metadata of one asset
+type TokenMetadata struct {
+	PolicyId  []byte
+	AssetName []byte
+	Version   int64
+	Json      json.RawMessage
+	Cbor      []byte
+}
+
+// CIP67Label parses the CIP-67 label prefix of an asset name: a zero nibble, the 16
+// bit label, a CRC-8 checksum of the label and a zero nibble. It returns the label and
+// the rest of the name, or false when the name does not start with a valid label.
+func CIP67Label(assetName []byte) (uint16, []byte, bool) {
+	if len(assetName) < cip67PrefixSize || assetName[0]&0xf0 != 0 || assetName[3]&0x0f != 0 {
+		return 0, nil, false
+	}
+	prefix := uint32(assetName[0])<<24 | uint32(assetName[1])<<16 | uint32(assetName[2])<<8 | uint32(assetName[3])
+	label := uint16(prefix >> 12)
+	checksum := byte(prefix >> 4)
+	if crc8([]byte{byte(label >> 8), byte(label)}) != checksum {
+		return 0, nil, false
+	}
+	return label, assetName[cip67PrefixSize:], true
+}
+
+// CIP67Prefix returns the asset name prefix of a CIP-67 label
+func CIP67Prefix(label uint16) []byte {
+	checksum := crc8([]byte{byte(label >> 8), byte(label)})
+	prefix := uint32(label)<<12 | uint32(checksum)<<4
+	return []byte{byte(prefix >> 24), byte(prefix >> 16), byte(prefix >> 8), byte(prefix)}
+}
+
+// crc8 is the CRC-8 checksum CIP-67 uses (polynomial 0x07, no reflection, zero init)
+func crc8(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// CIP25 extracts the metadata of each asset from the metadatum of the CIP-25 label,
+// {policy_id: {asset_name: {...}}, "version": n}. Version 1 encodes policy IDs as hex
+// text and asset names as UTF-8 text; version 2 encodes both as byte strings.
+func CIP25(metadatum []byte) ([]TokenMetadata, error) {
+	pairs, err := decodeMap(metadatum)
+	if err != nil {
+		return nil, err
+	}
+	version := int64(cip25Version1)
+	for _, pair := range pairs {
+		var key string
+		if _, err := cbor.Decode(pair[0], &key); err == nil && key == cip25VersionKey {
+			if n, err := decodeInt(pair[1]); err == nil && n.IsInt64() {
+				version = n.Int64()
+			}
+		}
+	}
+
+	var tokens []TokenMetadata
+	for _, pair := range pairs {
+		policyId, err := cip25Key(pair[0], true)
+		if err != nil {
+			// The version entry, or a malformed policy ID
+			continue
+		}
+		assets, err := decodeMap(pair[1])
+		if err != nil {
+			continue
+		}
+		for _, asset := range assets {
+			assetName, err := cip25Key(asset[0], false)
+			if err != nil {
+				continue
+			}
+			value, err := noSchema(asset[1])
+			if err != nil {
+				return nil, fmt.Errorf("asset %x: %w", assetName, err)
+			}
+			assetJson, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, TokenMetadata{
+				PolicyId:  policyId,
+				AssetName: assetName,
+				Version:   version,
+				Json:      assetJson,
+				Cbor:      asset[1],
+			})
+		}
+	}
+	return tokens, nil
+}
+
+// cip25Key decodes a CIP-25 policy ID or asset name key, given as a byte string or text
+func cip25Key(data []byte, hexText bool) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty key")
+	}
+	switch data[0] >> 5 {
+	case cborTypeBytes:
+		var b []byte
+		_, err := cbor.Decode(data, &b)
+		return b, err
+	case cborTypeText:
+		var s string
+		if _, err := cbor.Decode(data, &s); err != nil {
+			return nil, err
+		}
+		if hexText {
+			return hex.DecodeString(s)
+		}
+		return []byte(s), nil
+	}
+	return nil, errors.New("key is not a byte string or text")
+}
+
+// CIP68 decodes the datum of a CIP-68 reference token, Constr 0 [metadata, version,
+// extra]. It returns the metadata as JSON and the datum version.
+func CIP68(datum []byte) (json.RawMessage, int64, error) {
+	constructor, fields, err := decodeConstr(datum)
+	if err != nil {
+		return nil, 0, err
+	}
+	if constructor != 0 || len(fields) < 2 {
+		return nil, 0, errors.New("datum is not a CIP-68 metadata constructor")
+	}
+	if len(fields[0]) == 0 || fields[0][0]>>5 != cborTypeMap {
+		return nil, 0, errors.New("CIP-68 metadata is not a map")
+	}
+	metadata, err := plutusDataJSON(fields[0])
+	if err != nil {
+		return nil, 0, err
+	}
+	metadataJson, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, 0, err
+	}
+	version, err := decodeInt(fields[1])
+	if err != nil || !version.IsInt64() {
+		return nil, 0, errors.New("invalid CIP-68 version")
+	}
+	return metadataJson, version.Int64(), nil
+}
+
+// decodeConstr decodes a Plutus data constructor into its index and fields
+func decodeConstr(data []byte) (uint64, []cbor.RawMessage, error) {
+	if len(data) == 0 || data[0]>>5 != cborTypeTag {
+		return 0, nil, errors.New("not a Plutus data constructor")
+	}
+	var tag cbor.RawTag
+	if _, err := cbor.Decode(data, &tag); err != nil {
+		return 0, nil, err
+	}
+	switch {
+	case tag.Number >= constrTagBase && tag.Number <= constrTagMax:
+		fields, err := decodeArray(tag.Content)
+		return tag.Number - constrTagBase, fields, err
+	case tag.Number >= constrTagExtendedBase && tag.Number <= constrTagExtendedMax:
+		fields, err := decodeArray(tag.Content)
+		return tag.Number - constrTagExtendedBase + constrTagMax - constrTagBase + 1, fields, err
+	case tag.Number == constrTagGeneral:
+		items, err := decodeArray(tag.Content)
+		if err != nil {
+			return 0, nil, err
+		}
+		if len(items) != 2 {
+			return 0, nil, errors.New("invalid general constructor")
+		}
+		var constructor uint64
+		if _, err := cbor.Decode(items[0], &constructor); err != nil {
+			return 0, nil, err
+		}
+		fields, err := decodeArray(items[1])
+		return constructor, fields, err
+	}
+	return 0, nil, fmt.Errorf("unexpected tag %d", tag.Number)
+}
+
+// plutusDataJSON converts Plutus data to JSON the way CIP-68 metadata is usually
+// presented: byte strings holding UTF-8 text become strings and other byte strings
+// "0x" prefixed hex, maps become objects and constructors {"constructor", "fields"}.
+func plutusDataJSON(data []byte) (any, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty Plutus data")
+	}
+	switch data[0] >> 5 {
+	case cborTypeUint, cborTypeNegInt:
+		n, err := decodeInt(data)
+		if err != nil {
+			return nil, err
+		}
+		return json.Number(n.String()), nil
+	case cborTypeBytes:
+		var b []byte
+		if _, err := cbor.Decode(data, &b); err != nil {
+			return nil, err
+		}
+		if utf8.Valid(b) {
+			return string(b), nil
+		}
+		return "0x" + hex.EncodeToString(b), nil
+	case cborTypeArray:
+		items, err := decodeArray(data)
+		if err != nil {
+			return nil, err
+		}
+		list := []any{}
+		for _, item := range items {
+			value, err := plutusDataJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case cborTypeMap:
+		pairs, err := decodeMap(data)
+		if err != nil {
+			return nil, err
+		}
+		fields := object{}
+		for _, pair := range pairs {
+			key, err := plutusDataJSON(pair[0])
+			if err != nil {
+				return nil, err
+			}
+			value, err := plutusDataJSON(pair[1])
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field{Key: jsonKey(key), Value: value})
+		}
+		return fields, nil
+	case cborTypeTag:
+		if constructor, items, err := decodeConstr(data); err == nil {
+			fields := []any{}
+			for _, item := range items {
+				value, err := plutusDataJSON(item)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, value)
+			}
+			return object{{Key: "constructor", Value: constructor}, {Key: "fields", Value: fields}}, nil
+		}
+		// Bignums
+		n, err := decodeInt(data)
+		if err != nil {
+			return nil, err
+		}
+		return json.Number(n.String()), nil
+	}
+	return nil, fmt.Errorf("unsupported Plutus data type %d", data[0]>>5)
+}
+
+// jsonKey converts a decoded value to a JSON object key
+func jsonKey(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	}
+	key, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(key)
+}
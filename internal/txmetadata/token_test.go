@@ -0,0 +1,94 @@
+package txmetadata
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestCIP67(t *testing.T) {
+	testDefs := []struct {
+		label  uint16
+		prefix string
+	}{
+		{label: CIP68ReferenceTokenLabel, prefix: "000643b0"},
+		{label: CIP68NFTLabel, prefix: "000de140"},
+		{label: CIP68FTLabel, prefix: "0014df10"},
+		{label: CIP68RFTLabel, prefix: "001bc280"},
+	}
+	for _, testDef := range testDefs {
+		if prefix := hex.EncodeToString(CIP67Prefix(testDef.label)); prefix != testDef.prefix {
+			t.Errorf("label %d: expected prefix %s, got %s", testDef.label, testDef.prefix, prefix)
+		}
+		assetName, _ := hex.DecodeString(testDef.prefix + "4e6674")
+		label, rest, ok := CIP67Label(assetName)
+		if !ok || label != testDef.label || string(rest) != "Nft" {
+			t.Errorf("label %d: unexpected parse result %d, %q, %v", testDef.label, label, rest, ok)
+		}
+	}
+
+	for _, invalid := range []string{"000de141", "000de150", "100de140", "000de1"} {
+		assetName, _ := hex.DecodeString(invalid)
+		if _, _, ok := CIP67Label(assetName); ok {
+			t.Errorf("expected no label for asset name %s", invalid)
+		}
+	}
+}
+
+func TestCIP25(t *testing.T) {
+	policyId := strings.Repeat("ab", 28)
+	testDefs := []struct {
+		name      string
+		metadatum string
+		version   int64
+	}{
+		{
+			// {"abab...": {"Nft1": {"name": "Nft1"}}}
+			name:      "v1",
+			metadatum: "a17838" + hex.EncodeToString([]byte(policyId)) + "a1644e667431a1646e616d65644e667431",
+			version:   1,
+		},
+		{
+			// {h'abab...': {h'4e667431': {"name": "Nft1"}}, "version": 2}
+			name:      "v2",
+			metadatum: "a2581c" + policyId + "a1444e667431a1646e616d65644e667431" + "6776657273696f6e02",
+			version:   2,
+		},
+	}
+	for _, testDef := range testDefs {
+		metadatum, _ := hex.DecodeString(testDef.metadatum)
+		tokens, err := CIP25(metadatum)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if len(tokens) != 1 {
+			t.Fatalf("%s: expected 1 token, got %d", testDef.name, len(tokens))
+		}
+		token := tokens[0]
+		if hex.EncodeToString(token.PolicyId) != policyId || string(token.AssetName) != "Nft1" || token.Version != testDef.version {
+			t.Errorf("%s: unexpected token %+v", testDef.name, token)
+		}
+		if string(token.Json) != `{"name":"Nft1"}` {
+			t.Errorf("%s: unexpected metadata %s", testDef.name, token.Json)
+		}
+	}
+}
+
+func TestCIP68(t *testing.T) {
+	// Constr 0 [{h'6e616d65': h'4e6674', h'696d616765': h'ff00'}, 1, Constr 0 []]
+	datum, _ := hex.DecodeString("d87983a2446e616d65434e667445696d61676542ff0001d87980")
+	metadata, version, err := CIP68(datum)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !bytes.Equal(metadata, []byte(`{"name":"Nft","image":"0xff00"}`)) || version != 1 {
+		t.Errorf("unexpected metadata %s, version %d", metadata, version)
+	}
+
+	// Constr 1 [{}, 1]
+	datum, _ = hex.DecodeString("d87a82a001")
+	if _, _, err := CIP68(datum); err == nil {
+		t.Errorf("expected an error for a datum with constructor 1")
+	}
+}
@@ -0,0 +1,381 @@
+// Package txmetadata decodes transaction metadata into the JSON forms used by
+// cardano-cli: the detailed schema, which keeps every metadatum type explicit, and
+// the no-schema form, which maps metadata onto plain JSON values.
+package txmetadata
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+)
+
+const (
+	cborTypeUint     = 0
+	cborTypeNegInt   = 1
+	cborTypeBytes    = 2
+	cborTypeText     = 3
+	cborTypeArray    = 4
+	cborTypeMap      = 5
+	cborTypeTag      = 6
+	cborBreak        = 0xff
+	cborIndefinite   = 31
+	tagAlonzoAuxData = 259
+	// alonzoMetadataKey is the auxiliary data map key of the metadata
+	alonzoMetadataKey = 0
+)
+
+// Entry is the metadatum of one metadata label
+type Entry struct {
+	Label uint64
+	Cbor  []byte
+}
+
+// Entries returns the labelled metadata of auxiliary data, in encoded order. The
+// Shelley (plain map), Allegra (array of metadata and scripts) and Alonzo (tag 259
+// map) encodings are supported.
+func Entries(auxData []byte) ([]Entry, error) {
+	if len(auxData) == 0 {
+		return nil, nil
+	}
+	metadata := auxData
+	switch auxData[0] >> 5 {
+	case cborTypeArray:
+		items, err := decodeArray(auxData)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return nil, errors.New("empty auxiliary data array")
+		}
+		metadata = items[0]
+	case cborTypeTag:
+		var tag cbor.RawTag
+		if _, err := cbor.Decode(auxData, &tag); err != nil {
+			return nil, err
+		}
+		if tag.Number != tagAlonzoAuxData {
+			return nil, fmt.Errorf("unexpected auxiliary data tag %d", tag.Number)
+		}
+		pairs, err := decodeMap(tag.Content)
+		if err != nil {
+			return nil, err
+		}
+		metadata = nil
+		for _, pair := range pairs {
+			var key uint64
+			if _, err := cbor.Decode(pair[0], &key); err == nil && key == alonzoMetadataKey {
+				metadata = pair[1]
+			}
+		}
+		if metadata == nil {
+			return nil, nil
+		}
+	}
+
+	pairs, err := decodeMap(metadata)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(pairs))
+	for _, pair := range pairs {
+		var label uint64
+		if _, err := cbor.Decode(pair[0], &label); err != nil {
+			return nil, fmt.Errorf("invalid metadata label: %w", err)
+		}
+		entries = append(entries, Entry{Label: label, Cbor: bytes.Clone(pair[1])})
+	}
+	return entries, nil
+}
+
+// DetailedSchema converts auxiliary data to a JSON object of labels to metadatums in
+// the detailed schema, e.g. {"674": {"map": [{"k": {"string": "msg"}, "v": ...}]}}
+func DetailedSchema(auxData []byte) (json.RawMessage, error) {
+	return convert(auxData, detailedSchema)
+}
+
+// NoSchema converts auxiliary data to a JSON object of labels to metadatums in the
+// no-schema form, e.g. {"674": {"msg": ["hello"]}}. Byte strings are "0x" prefixed hex.
+func NoSchema(auxData []byte) (json.RawMessage, error) {
+	return convert(auxData, noSchema)
+}
+
+func convert(auxData []byte, convertMetadatum func([]byte) (any, error)) (json.RawMessage, error) {
+	entries, err := Entries(auxData)
+	if err != nil {
+		return nil, err
+	}
+	labels := object{}
+	for _, entry := range entries {
+		value, err := convertMetadatum(entry.Cbor)
+		if err != nil {
+			return nil, fmt.Errorf("label %d: %w", entry.Label, err)
+		}
+		labels = append(labels, field{Key: strconv.FormatUint(entry.Label, 10), Value: value})
+	}
+	return json.Marshal(labels)
+}
+
+// field is a JSON object member
+type field struct {
+	Key   string
+	Value any
+}
+
+// object is a JSON object that keeps its members in order, as metadata maps are
+type object []field
+
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func detailedSchema(data []byte) (any, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty metadatum")
+	}
+	switch data[0] >> 5 {
+	case cborTypeUint, cborTypeNegInt, cborTypeTag:
+		n, err := decodeInt(data)
+		if err != nil {
+			return nil, err
+		}
+		return object{{Key: "int", Value: json.Number(n.String())}}, nil
+	case cborTypeBytes:
+		var b []byte
+		if _, err := cbor.Decode(data, &b); err != nil {
+			return nil, err
+		}
+		return object{{Key: "bytes", Value: hex.EncodeToString(b)}}, nil
+	case cborTypeText:
+		var s string
+		if _, err := cbor.Decode(data, &s); err != nil {
+			return nil, err
+		}
+		return object{{Key: "string", Value: s}}, nil
+	case cborTypeArray:
+		items, err := decodeArray(data)
+		if err != nil {
+			return nil, err
+		}
+		list := []any{}
+		for _, item := range items {
+			value, err := detailedSchema(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return object{{Key: "list", Value: list}}, nil
+	case cborTypeMap:
+		pairs, err := decodeMap(data)
+		if err != nil {
+			return nil, err
+		}
+		entries := []any{}
+		for _, pair := range pairs {
+			key, err := detailedSchema(pair[0])
+			if err != nil {
+				return nil, err
+			}
+			value, err := detailedSchema(pair[1])
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, object{{Key: "k", Value: key}, {Key: "v", Value: value}})
+		}
+		return object{{Key: "map", Value: entries}}, nil
+	}
+	return nil, fmt.Errorf("unsupported metadatum type %d", data[0]>>5)
+}
+
+func noSchema(data []byte) (any, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty metadatum")
+	}
+	switch data[0] >> 5 {
+	case cborTypeUint, cborTypeNegInt, cborTypeTag:
+		n, err := decodeInt(data)
+		if err != nil {
+			return nil, err
+		}
+		return json.Number(n.String()), nil
+	case cborTypeBytes:
+		var b []byte
+		if _, err := cbor.Decode(data, &b); err != nil {
+			return nil, err
+		}
+		return "0x" + hex.EncodeToString(b), nil
+	case cborTypeText:
+		var s string
+		if _, err := cbor.Decode(data, &s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case cborTypeArray:
+		items, err := decodeArray(data)
+		if err != nil {
+			return nil, err
+		}
+		list := []any{}
+		for _, item := range items {
+			value, err := noSchema(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case cborTypeMap:
+		pairs, err := decodeMap(data)
+		if err != nil {
+			return nil, err
+		}
+		fields := object{}
+		for _, pair := range pairs {
+			key, err := noSchemaKey(pair[0])
+			if err != nil {
+				return nil, err
+			}
+			value, err := noSchema(pair[1])
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field{Key: key, Value: value})
+		}
+		return fields, nil
+	}
+	return nil, fmt.Errorf("unsupported metadatum type %d", data[0]>>5)
+}
+
+// noSchemaKey converts a map key to a JSON object key. Strings, numbers and byte
+// strings use their no-schema form; lists and maps are JSON encoded.
+func noSchemaKey(data []byte) (string, error) {
+	value, err := noSchema(data)
+	if err != nil {
+		return "", err
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	}
+	key, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
+// decodeInt decodes a CBOR integer or bignum
+func decodeInt(data []byte) (*big.Int, error) {
+	n := new(big.Int)
+	if _, err := cbor.Decode(data, n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// decodeHeader returns the item count of an array or map header and the header
+// length. Indefinite-length items return a count of -1.
+func decodeHeader(data []byte) (int, int, error) {
+	if len(data) == 0 {
+		return 0, 0, errors.New("unexpected end of data")
+	}
+	info := data[0] & 0x1f
+	switch {
+	case info < 24:
+		return int(info), 1, nil
+	case info == cborIndefinite:
+		return -1, 1, nil
+	case info > 27:
+		return 0, 0, fmt.Errorf("invalid CBOR header 0x%02x", data[0])
+	}
+	size := 1 << (info - 24)
+	if len(data) < 1+size {
+		return 0, 0, errors.New("unexpected end of data")
+	}
+	var count uint64
+	for _, b := range data[1 : 1+size] {
+		count = count<<8 | uint64(b)
+	}
+	if count > uint64(len(data)) {
+		return 0, 0, errors.New("item count exceeds data length")
+	}
+	return int(count), 1 + size, nil
+}
+
+// decodeItems splits the items following an array or map header
+func decodeItems(data []byte, itemsPerEntry int) ([]cbor.RawMessage, error) {
+	count, offset, err := decodeHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	var items []cbor.RawMessage
+	for i := 0; count < 0 || i < count*itemsPerEntry; i++ {
+		if offset >= len(data) {
+			return nil, errors.New("unexpected end of data")
+		}
+		if count < 0 && data[offset] == cborBreak {
+			break
+		}
+		var item cbor.RawMessage
+		n, err := cbor.Decode(data[offset:], &item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, cbor.RawMessage(data[offset:offset+n]))
+		offset += n
+	}
+	if len(items)%itemsPerEntry != 0 {
+		return nil, errors.New("odd number of map items")
+	}
+	return items, nil
+}
+
+func decodeArray(data []byte) ([]cbor.RawMessage, error) {
+	if len(data) == 0 || data[0]>>5 != cborTypeArray {
+		return nil, errors.New("not a CBOR array")
+	}
+	return decodeItems(data, 1)
+}
+
+// decodeMap returns the key and value of each map entry, in encoded order
+func decodeMap(data []byte) ([][2]cbor.RawMessage, error) {
+	if len(data) == 0 || data[0]>>5 != cborTypeMap {
+		return nil, errors.New("not a CBOR map")
+	}
+	items, err := decodeItems(data, 2)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([][2]cbor.RawMessage, 0, len(items)/2)
+	for i := 0; i < len(items); i += 2 {
+		pairs = append(pairs, [2]cbor.RawMessage{items[i], items[i+1]})
+	}
+	return pairs, nil
+}
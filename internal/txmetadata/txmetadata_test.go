@@ -0,0 +1,73 @@
+package txmetadata
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// {674: {"msg": ["hi"]}}
+const testMessageMetadata = "a11902a2a1636d736781626869"
+
+func TestEntries(t *testing.T) {
+	testDefs := []struct {
+		name    string
+		auxData string
+	}{
+		{name: "shelley", auxData: testMessageMetadata},
+		{name: "allegra", auxData: "82" + testMessageMetadata + "80"},
+		{name: "alonzo", auxData: "d90103a100" + testMessageMetadata},
+	}
+	for _, testDef := range testDefs {
+		auxData, _ := hex.DecodeString(testDef.auxData)
+		entries, err := Entries(auxData)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", testDef.name, err)
+		}
+		if len(entries) != 1 || entries[0].Label != 674 || hex.EncodeToString(entries[0].Cbor) != "a1636d736781626869" {
+			t.Errorf("%s: unexpected entries: %+v", testDef.name, entries)
+		}
+	}
+
+	auxData, _ := hex.DecodeString("d90103a10180")
+	entries, err := Entries(auxData)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("expected no entries for auxiliary data without metadata, got %+v, %v", entries, err)
+	}
+}
+
+func TestSchemas(t *testing.T) {
+	testDefs := []struct {
+		auxData  string
+		detailed string
+		noSchema string
+	}{
+		{
+			auxData:  testMessageMetadata,
+			detailed: `{"674":{"map":[{"k":{"string":"msg"},"v":{"list":[{"string":"hi"}]}}]}}`,
+			noSchema: `{"674":{"msg":["hi"]}}`,
+		},
+		{
+			// {1: [h'ab', -5], 0: {2: 18446744073709551615}}
+			auxData:  "a2018241ab2400a1021bffffffffffffffff",
+			detailed: `{"1":{"list":[{"bytes":"ab"},{"int":-5}]},"0":{"map":[{"k":{"int":2},"v":{"int":18446744073709551615}}]}}`,
+			noSchema: `{"1":["0xab",-5],"0":{"2":18446744073709551615}}`,
+		},
+	}
+	for _, testDef := range testDefs {
+		auxData, _ := hex.DecodeString(testDef.auxData)
+		detailed, err := DetailedSchema(auxData)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if string(detailed) != testDef.detailed {
+			t.Errorf("unexpected detailed schema: got %s, wanted %s", detailed, testDef.detailed)
+		}
+		noSchema, err := NoSchema(auxData)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if string(noSchema) != testDef.noSchema {
+			t.Errorf("unexpected no-schema JSON: got %s, wanted %s", noSchema, testDef.noSchema)
+		}
+	}
+}
@@ -13,7 +13,9 @@ import (
 
 	"github.com/Andamio-Platform/andamio-indexer/config"
 	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata"
 	"github.com/Andamio-Platform/andamio-indexer/indexer"
+	"github.com/Andamio-Platform/andamio-indexer/internal/cli"
 	"github.com/Andamio-Platform/andamio-indexer/router"
 )
 
@@ -37,6 +39,11 @@ var cmdlineFlags struct {
 
 //	@securityDefinitions.basic	BasicAuth
 
+//	@securityDefinitions.apikey	ApiKeyAuth
+//	@in							header
+//	@name						X-API-Key
+//	@description				API key issued with the "apikey create" command. Read endpoints need a read or admin key, changes need an admin key.
+
 // @externalDocs.description	OpenAPI
 // @externalDocs.url			https://swagger.io/resources/open-api/
 func main() {
@@ -72,6 +79,23 @@ func main() {
 	fiberLogger.SetLevel(fiberLogger.LevelDebug)
 	// fiberLogger.SetLevel(fiberLogger.LevelInfo)
 
+	// Run the "apikey" subcommand against the metadata store only, so that keys can be
+	// minted and revoked while the indexer holds the blob store open
+	if flag.Arg(0) == "apikey" {
+		metadataStore, err := metadata.New("sqlite", config.GlobalConfig.Database.DatabaseDIR, logger)
+		if err != nil {
+			slog.Error("Failed to open metadata store", "error", err)
+			os.Exit(1)
+		}
+		err = cli.RunAPIKeyCommand(metadataStore, flag.Args()[1:], os.Stdout)
+		metadataStore.Close()
+		if err != nil {
+			slog.Error("apikey command failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var db *database.Database
 	if !fiber.IsChild() {
 		var err error
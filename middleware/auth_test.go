@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAuditStatusCode(t *testing.T) {
+	testDefs := []struct {
+		responseStatus int
+		handlerErr     error
+		expected       int
+	}{
+		{fiber.StatusOK, nil, fiber.StatusOK},
+		{fiber.StatusNotFound, nil, fiber.StatusNotFound},
+		{fiber.StatusOK, fiber.ErrNotFound, fiber.StatusNotFound},
+		{fiber.StatusOK, fmt.Errorf("wrapped: %w", fiber.ErrBadRequest), fiber.StatusBadRequest},
+		{fiber.StatusOK, errors.New("handler failed"), fiber.StatusInternalServerError},
+	}
+	for _, testDef := range testDefs {
+		if statusCode := auditStatusCode(testDef.responseStatus, testDef.handlerErr); statusCode != testDef.expected {
+			t.Fatalf("auditStatusCode(%d, %v) = %d, expected %d", testDef.responseStatus, testDef.handlerErr, statusCode, testDef.expected)
+		}
+	}
+}
+
+func TestAuditRequestBody(t *testing.T) {
+	body := []byte(`{"address":"addr_test1qz"}`)
+	if recorded := auditRequestBody(body); !bytes.Equal(recorded, body) {
+		t.Fatalf("expected small body to be recorded as is, got: %s", recorded)
+	}
+	large := bytes.Repeat([]byte("a"), maxAuditBodySize+1)
+	recorded := auditRequestBody(large)
+	if len(recorded) != maxAuditBodySize {
+		t.Fatalf("expected body to be truncated to %d bytes, got %d", maxAuditBodySize, len(recorded))
+	}
+	recorded[0] = 'b'
+	if large[0] != 'a' {
+		t.Fatalf("expected recorded body to be a copy")
+	}
+}
@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	quotaDayLayout = "2006-01-02"
+	// quotaRowsLocal is the fiber.Ctx locals key holding the row count reported by a handler
+	quotaRowsLocal = "quota_rows"
+)
+
+// SetRowCount reports the number of result rows a handler served. Handlers answering with
+// an object that holds lists of results, rather than a top-level array, report their rows
+// so that they are counted against the quota.
+func SetRowCount(c *fiber.Ctx, rows int) {
+	c.Locals(quotaRowsLocal, int64(rows))
+}
+
+// quotaTracker caches the rows served per client for the current UTC day. Usage is
+// persisted so that quotas survive restarts; the cache is seeded from the database the
+// first time a client is seen each day.
+type quotaTracker struct {
+	db   *database.Database
+	mu   sync.Mutex
+	day  string
+	used map[string]int64
+}
+
+func (t *quotaTracker) usage(client, day string) (int64, error) {
+	t.mu.Lock()
+	if t.day != day {
+		t.day = day
+		t.used = make(map[string]int64)
+	}
+	rows, ok := t.used[client]
+	t.mu.Unlock()
+	if ok {
+		return rows, nil
+	}
+
+	rows, err := t.db.Metadata().GetQuotaUsage(nil, client, day)
+	if err != nil {
+		return 0, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cached, ok := t.used[client]; ok && t.day == day {
+		return cached, nil
+	}
+	if t.day == day {
+		t.used[client] = rows
+	}
+	return rows, nil
+}
+
+func (t *quotaTracker) add(client, day string, rows int64) (int64, error) {
+	if err := t.db.Metadata().AddQuotaUsage(nil, client, day, rows); err != nil {
+		return 0, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.day != day {
+		return rows, nil
+	}
+	t.used[client] += rows
+	return t.used[client], nil
+}
+
+// countRows returns the number of result rows in a JSON response body: the length of a
+// top-level array, or 1 for any other document. It is used unless the handler reported its
+// row count with SetRowCount.
+func countRows(body []byte) int64 {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return 0
+	}
+	if body[0] != '[' {
+		return 1
+	}
+	var rows []json.RawMessage
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return 1
+	}
+	return int64(len(rows))
+}
+
+// Quota returns a middleware enforcing a daily quota on the number of result rows served to
+// each client. API keys with their own quota override defaultQuota; a quota of 0 disables it.
+// The requested page size is capped to the rows left in the quota. It must be installed
+// after RequireScope so API keys are known.
+func Quota(db *database.Database, logger *slog.Logger, defaultQuota int64) fiber.Handler {
+	tracker := &quotaTracker{db: db}
+
+	return func(c *fiber.Ctx) error {
+		quota := defaultQuota
+		if apiKey := APIKeyFromCtx(c); apiKey != nil && apiKey.DailyRowQuota > 0 {
+			quota = apiKey.DailyRowQuota
+		}
+		if quota <= 0 {
+			return c.Next()
+		}
+
+		now := time.Now().UTC()
+		day := now.Format(quotaDayLayout)
+		client := clientID(c)
+		used, err := tracker.usage(client, day)
+		if err != nil {
+			logger.Error("failed to read quota usage", "client", client, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+		}
+
+		nextDay := now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+		setQuotaHeaders := func(used int64) {
+			c.Set("X-RateLimit-Quota-Limit", strconv.FormatInt(quota, 10))
+			c.Set("X-RateLimit-Quota-Remaining", strconv.FormatInt(max(quota-used, 0), 10))
+			c.Set("X-RateLimit-Quota-Reset", strconv.Itoa(int(nextDay.Sub(now).Seconds())))
+		}
+		if used >= quota {
+			setQuotaHeaders(used)
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "daily row quota exceeded"})
+		}
+
+		remaining := quota - used
+		if limit := c.QueryInt("limit", defaultPageLimit); limit <= 0 || int64(limit) > remaining {
+			c.Request().URI().QueryArgs().Set("limit", strconv.FormatInt(remaining, 10))
+		}
+
+		handlerErr := c.Next()
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusOK && status < fiber.StatusMultipleChoices {
+			rows, ok := c.Locals(quotaRowsLocal).(int64)
+			if !ok {
+				rows = countRows(c.Response().Body())
+			}
+			if rows > 0 {
+				total, err := tracker.add(client, day, rows)
+				if err != nil {
+					logger.Error("failed to record quota usage", "client", client, "error", err)
+				} else {
+					used = total
+				}
+			}
+		}
+		setQuotaHeaders(used)
+
+		return handlerErr
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestQuota(t *testing.T) {
+	db, err := database.New(nil, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() {
+		db.Close() //nolint:errcheck
+	})
+	app := fiber.New()
+	app.Use(Quota(db, slog.Default(), 5))
+	app.Get("/rows", func(c *fiber.Ctx) error {
+		return c.JSON(make([]int, c.QueryInt("limit", defaultPageLimit)))
+	})
+	app.Get("/utxos", func(c *fiber.Ctx) error {
+		SetRowCount(c, 3)
+		return c.JSON(fiber.Map{"inputs": []int{1, 2}, "outputs": []int{3}})
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/utxos", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if remaining := resp.Header.Get("X-RateLimit-Quota-Remaining"); remaining != "2" {
+		t.Fatalf("expected the reported rows to be counted, got remaining quota %s", remaining)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/rows?limit=50", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var rows []int
+	if err := json.NewDecoder(resp.Body).Decode(&rows); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected the page size to be capped to the remaining quota, got %d rows", len(rows))
+	}
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/rows", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.StatusCode != fiber.StatusTooManyRequests {
+		t.Fatalf("expected quota to be exceeded, got status %d", resp.StatusCode)
+	}
+}
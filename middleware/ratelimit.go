@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Andamio-Platform/andamio-indexer/config"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// defaultPageLimit is the page size handlers use when no limit is given
+	defaultPageLimit = 100
+	// bucketIdleTimeout is how long an untouched bucket is kept before being dropped
+	bucketIdleTimeout = 10 * time.Minute
+)
+
+// clientID identifies the caller for rate limiting and quotas: the API key when one was
+// presented, the remote IP for anonymous requests.
+func clientID(c *fiber.Ctx) string {
+	if apiKey := APIKeyFromCtx(c); apiKey != nil {
+		return "key:" + strconv.FormatUint(uint64(apiKey.ID), 10)
+	}
+	return "ip:" + c.IP()
+}
+
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// rateLimiter keeps one token bucket per client.
+type rateLimiter struct {
+	rate      float64
+	burst     float64
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = int(math.Max(1, math.Ceil(rate)))
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// take removes a token from the client's bucket. It returns whether the request is allowed,
+// the tokens left and how long until the bucket is full again (or, when the request is
+// refused, until the next token is available).
+func (l *rateLimiter) take(client string, now time.Time) (bool, int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > bucketIdleTimeout {
+		for id, b := range l.buckets {
+			if now.Sub(b.lastSeen) > bucketIdleTimeout {
+				delete(l.buckets, id)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, lastSeen: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.lastSeen).Seconds()*l.rate)
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		return false, 0, l.wait(1 - b.tokens)
+	}
+	b.tokens--
+	return true, int(b.tokens), l.wait(l.burst - b.tokens)
+}
+
+func (l *rateLimiter) wait(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// RateLimit returns a middleware enforcing the rule with a token bucket per client and capping
+// the requested page size. It must be installed after RequireScope so API keys are known.
+func RateLimit(rule config.RateLimitRule) fiber.Handler {
+	var limiter *rateLimiter
+	if rule.RequestsPerSecond > 0 {
+		limiter = newRateLimiter(rule.RequestsPerSecond, rule.Burst)
+	}
+
+	return func(c *fiber.Ctx) error {
+		if limiter != nil {
+			allowed, remaining, reset := limiter.take(clientID(c), time.Now())
+			resetSeconds := strconv.Itoa(int(math.Ceil(reset.Seconds())))
+			c.Set("X-RateLimit-Limit", strconv.Itoa(int(limiter.burst)))
+			c.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+			c.Set("X-RateLimit-Reset", resetSeconds)
+			if !allowed {
+				c.Set(fiber.HeaderRetryAfter, resetSeconds)
+				return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "rate limit exceeded"})
+			}
+		}
+
+		if rule.MaxLimit > 0 {
+			limit := c.QueryInt("limit", defaultPageLimit)
+			if limit <= 0 || limit > rule.MaxLimit {
+				c.Request().URI().QueryArgs().Set("limit", strconv.Itoa(rule.MaxLimit))
+			}
+		}
+
+		return c.Next()
+	}
+}
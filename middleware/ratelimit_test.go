@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterTake(t *testing.T) {
+	limiter := newRateLimiter(2, 3)
+	now := time.Unix(1700000000, 0)
+
+	for i := 2; i >= 0; i-- {
+		allowed, remaining, _ := limiter.take("key:1", now)
+		if !allowed || remaining != i {
+			t.Fatalf("expected request to be allowed with %d remaining, got allowed=%v remaining=%d", i, allowed, remaining)
+		}
+	}
+	allowed, _, reset := limiter.take("key:1", now)
+	if allowed {
+		t.Fatalf("expected request to be refused once the bucket is empty")
+	}
+	if reset != 500*time.Millisecond {
+		t.Fatalf("expected next token in 500ms, got %s", reset)
+	}
+	if allowed, _, _ := limiter.take("ip:127.0.0.1", now); !allowed {
+		t.Fatalf("expected other clients to have their own bucket")
+	}
+
+	allowed, remaining, _ := limiter.take("key:1", now.Add(time.Second))
+	if !allowed || remaining != 1 {
+		t.Fatalf("expected bucket to refill two tokens per second, got allowed=%v remaining=%d", allowed, remaining)
+	}
+}
+
+func TestCountRows(t *testing.T) {
+	testDefs := []struct {
+		body     string
+		expected int64
+	}{
+		{``, 0},
+		{`[]`, 0},
+		{`[{"a":1},{"a":2}]`, 2},
+		{`{"count":5}`, 1},
+	}
+	for _, testDef := range testDefs {
+		if rows := countRows([]byte(testDef.body)); rows != testDef.expected {
+			t.Fatalf("countRows(%q) = %d, expected %d", testDef.body, rows, testDef.expected)
+		}
+	}
+}
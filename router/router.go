@@ -5,12 +5,27 @@ import (
 
 	"github.com/Andamio-Platform/andamio-indexer/config"
 	"github.com/Andamio-Platform/andamio-indexer/database"
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	account_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/account_handlers"
 	address_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/address_handlers"
+	admin_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/admin_handlers"
 	asset_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/asset_handlers"
+	block_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/block_handlers"
+	certificate_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/certificate_handlers"
+	credential_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/credential_handlers"
+	datum_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/datum_handlers"
+	fingerprint_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/fingerprint_handlers"
+	governance_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/governance_handlers"
+	metadata_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/metadata_handlers"
 	metrics_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/metrics_handlers"
+	policy_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/policy_handlers"
 	redeemer_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/redeemer_handlers"
+	script_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/script_handlers"
+	signer_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/signer_handlers"
 	transaction_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/transaction_handlers"
+	utxo_handlers "github.com/Andamio-Platform/andamio-indexer/handlers/v1/utxo_handlers"
 	"github.com/Andamio-Platform/andamio-indexer/internal/logutils" // Add this import
+	"github.com/Andamio-Platform/andamio-indexer/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -64,33 +79,128 @@ func RouterInit(router *fiber.App, db *database.Database, logger *slog.Logger) {
 	logger.Info("Setting up Swagger handler", "path", "/docs/*")
 	indexer.Get("/docs/*", swagger.New(swagger.Config{URL: config.GetGlobalConfig().Indexer.SwaggerURL}))
 
+	// API key authentication. Everything below the docs requires a read key (unless anonymous
+	// reads are enabled), and every change requires an admin key and is written to the audit log.
+	allowAnonymousRead := config.GetGlobalConfig().Auth.AllowAnonymousRead
+	readAuth := middleware.RequireScope(globalDB, logger, models.APIKeyScopeRead, allowAnonymousRead)
+	adminAuth := middleware.RequireScope(globalDB, logger, models.APIKeyScopeAdmin, false)
+	auditLog := middleware.AuditLog(globalDB, logger)
+	indexer.Use(readAuth)
+
+	// Rate limiting and quotas, keyed by API key (or IP for anonymous reads). The daily row
+	// quota is shared by all routes, the token bucket and maximum page size are per group.
+	rateLimit := config.GetGlobalConfig().RateLimit
+	indexer.Use(middleware.Quota(globalDB, logger, rateLimit.DailyRowQuota))
+
+	// Admin handlers
+	admin := indexer.Group("/admin", adminAuth, middleware.RateLimit(rateLimit.Rule("admin")))
+	admin.Get("/audit-log", admin_handlers.GetAuditLogsHandler(globalDB, logger))
+
 	// Addresses handlers
-	addresses := indexer.Group("/addresses")
-	addresses.Post("/", address_handlers.AddAddressHandler(globalDB, logger))
-	addresses.Delete("/remove-address", address_handlers.RemoveAddressHandler(globalDB, logger))
+	addresses := indexer.Group("/addresses", middleware.RateLimit(rateLimit.Rule("addresses")))
+	addresses.Get("/", address_handlers.GetWatchedAddressesHandler(globalDB, logger))
+	addresses.Post("/", adminAuth, auditLog, address_handlers.AddAddressHandler(globalDB, logger))
+	addresses.Delete("/remove-address", adminAuth, auditLog, address_handlers.RemoveAddressHandler(globalDB, logger))
+	addresses.Post("/bulk", adminAuth, auditLog, address_handlers.BulkAddAddressesHandler(globalDB, logger))
+	addresses.Delete("/bulk", adminAuth, auditLog, address_handlers.BulkRemoveAddressesHandler(globalDB, logger))
+	addresses.Get("/groups", address_handlers.GetAddressGroupsHandler(globalDB, logger))
+	addresses.Get("/groups/:tag/transactions", address_handlers.GetTransactionsByAddressGroupHandler(globalDB, logger))
 	addresses.Get("/:address/transactions", address_handlers.GetTransactionsByAddressHandler(globalDB))
 	addresses.Get("/:address/assets", address_handlers.GetAssetsByAddressHandler(globalDB, logger))
+	addresses.Get("/:address/balance", address_handlers.GetBalanceByAddressHandler(globalDB, logger))
+	addresses.Get("/:address/balance-history", address_handlers.GetBalanceHistoryByAddressHandler(globalDB, logger))
+	addresses.Get("/:address/stats", address_handlers.GetStatsByAddressHandler(globalDB, logger))
+
+	// Policy watchlist handlers
+	policies := indexer.Group("/policies", middleware.RateLimit(rateLimit.Rule("policies")))
+	policies.Get("/", policy_handlers.GetPoliciesHandler(globalDB, logger))
+	policies.Post("/", adminAuth, auditLog, policy_handlers.AddPolicyHandler(globalDB, logger))
+	policies.Delete("/:policy_id", adminAuth, auditLog, policy_handlers.RemovePolicyHandler(globalDB, logger))
+
+	// Asset fingerprint watchlist handlers
+	fingerprints := indexer.Group("/fingerprints", middleware.RateLimit(rateLimit.Rule("fingerprints")))
+	fingerprints.Get("/", fingerprint_handlers.GetFingerprintsHandler(globalDB, logger))
+	fingerprints.Post("/", adminAuth, auditLog, fingerprint_handlers.AddFingerprintHandler(globalDB, logger))
+	fingerprints.Delete("/:fingerprint", adminAuth, auditLog, fingerprint_handlers.RemoveFingerprintHandler(globalDB, logger))
 
 	// Transaction handlers
-	transactions := indexer.Group("/transactions")
+	transactions := indexer.Group("/transactions", middleware.RateLimit(rateLimit.Rule("transactions")))
 	transactions.Get("/by-slot-range", transaction_handlers.GetTransactionsBySlotRangeHandler(globalDB, logger))
+	transactions.Get("/by-date-range", transaction_handlers.GetTransactionsByDateRangeHandler(globalDB, logger))
 	transactions.Get("/by-block-number/:block_number", transaction_handlers.GetTransactionsByBlockNumberHandler(globalDB))
 	transactions.Get("/:tx_hash", transaction_handlers.GetTransactionByTxHashHandler(globalDB))
 	transactions.Get("/:tx_hash/utxos", transaction_handlers.GetUTxOsByTransactionHandler(globalDB))
 	transactions.Get("/:tx_hash/utxos/inputs", transaction_handlers.GetUTxOsInputsByTransactionHandler(globalDB))
 	transactions.Get("/:tx_hash/utxos/outputs", transaction_handlers.GetUTxOsOutputsByTransactionHandler(globalDB))
+	transactions.Get("/:tx_hash/value-flow", transaction_handlers.GetValueFlowByTransactionHandler(globalDB, logger))
+
+	// Block handlers
+	blocks := indexer.Group("/blocks", middleware.RateLimit(rateLimit.Rule("blocks")))
+	blocks.Get("/latest", block_handlers.GetLatestBlockHandler(globalDB, logger))
+	blocks.Get("/:number_or_hash", block_handlers.GetBlockHandler(globalDB, logger))
+	blocks.Get("/:number/transactions", block_handlers.GetTransactionsByBlockHandler(globalDB, logger))
 
 	// Asset handlers
-	asset := indexer.Group("/assets")
+	asset := indexer.Group("/assets", middleware.RateLimit(rateLimit.Rule("assets")))
 	asset.Get("/policy/:policyId/transactions", asset_handlers.GetTransactionsByPolicyIdHandler(globalDB))
+	asset.Get("/policy/:policyId/mints", asset_handlers.GetMintsByPolicyIdHandler(globalDB, logger))
+	asset.Get("/policy/:policyId/supply", asset_handlers.GetSupplyByPolicyIdHandler(globalDB, logger))
+	asset.Get("/policy/:policyId/assets", asset_handlers.GetAssetsByPolicyIdHandler(globalDB, logger))
 	asset.Get("/token/:tokenname/transactions", asset_handlers.GetTransactionsByTokenNameHandler(globalDB))
 	asset.Get("/fingerprint/:asset_fingerprint/transactions", asset_handlers.GetTransactionsByAssetFingerprintHandler(globalDB))
 	asset.Get("/policy/:policyId/token/:tokenname/transactions", asset_handlers.GetTransactionsByPolicyIdAndTokenNameHandler(globalDB))
 	asset.Get("/fingerprint/:asset_fingerprint/addresses", asset_handlers.GetAddressesByAssetFingerprintHandler(globalDB, logger))
 	asset.Get("/fingerprint/:asset_fingerprint/utxos", asset_handlers.GetUTxOsByAssetFingerprintHandler(globalDB, logger))
+	asset.Get("/fingerprint/:asset_fingerprint/metadata", asset_handlers.GetTokenMetadataByAssetFingerprintHandler(globalDB, logger))
+	asset.Get("/fingerprint/:asset_fingerprint/history", asset_handlers.GetAssetHistoryByAssetFingerprintHandler(globalDB, logger))
+	asset.Get("/fingerprint/:asset_fingerprint/holders", asset_handlers.GetHoldersByAssetFingerprintHandler(globalDB, logger))
+
+	// Account (stake credential) handlers
+	accounts := indexer.Group("/accounts", middleware.RateLimit(rateLimit.Rule("accounts")))
+	accounts.Get("/:stake_address/transactions", account_handlers.GetTransactionsByStakeAddressHandler(globalDB, logger))
+	accounts.Get("/:stake_address/utxos", account_handlers.GetUTxOsByStakeAddressHandler(globalDB, logger))
+	accounts.Get("/:stake_address/withdrawals", account_handlers.GetWithdrawalsByStakeAddressHandler(globalDB, logger))
+
+	// Payment credential handlers
+	credentials := indexer.Group("/credentials", middleware.RateLimit(rateLimit.Rule("credentials")))
+	credentials.Get("/:credential/transactions", credential_handlers.GetTransactionsByCredentialHandler(globalDB, logger))
+	credentials.Get("/:credential/utxos", credential_handlers.GetUTxOsByCredentialHandler(globalDB, logger))
+
+	// Signer (vkey witness) handlers
+	signers := indexer.Group("/signers", middleware.RateLimit(rateLimit.Rule("signers")))
+	signers.Get("/:key_hash/transactions", signer_handlers.GetTransactionsBySignerHandler(globalDB, logger))
+
+	// Script registry handlers
+	scripts := indexer.Group("/scripts", middleware.RateLimit(rateLimit.Rule("scripts")))
+	scripts.Get("/:script_hash", script_handlers.GetScriptByHashHandler(globalDB, logger))
+	scripts.Get("/:script_hash/transactions", script_handlers.GetTransactionsByScriptHashHandler(globalDB, logger))
+
+	// UTxO handlers
+	utxos := indexer.Group("/utxos", middleware.RateLimit(rateLimit.Rule("utxos")))
+	utxos.Get("/:tx_hash/:index", utxo_handlers.GetUTxOHandler(globalDB, logger))
+	utxos.Get("/:tx_hash/:index/lineage", utxo_handlers.GetUTxOLineageHandler(globalDB, logger))
+	utxos.Get("/:tx_hash/:index/referenced-by", utxo_handlers.GetTransactionsReferencingUTxOHandler(globalDB, logger))
+
+	// Datum handlers
+	datums := indexer.Group("/datums", middleware.RateLimit(rateLimit.Rule("datums")))
+	datums.Get("/:datum_hash", datum_handlers.GetDatumByHashHandler(globalDB, logger))
+
+	// Certificate handlers
+	certificates := indexer.Group("/certificates", middleware.RateLimit(rateLimit.Rule("certificates")))
+	certificates.Get("/", certificate_handlers.GetCertificatesHandler(globalDB, logger))
+
+	// Metadata handlers
+	metadata := indexer.Group("/metadata", middleware.RateLimit(rateLimit.Rule("metadata")))
+	metadata.Get("/labels/:label/transactions", metadata_handlers.GetTransactionsByMetadataLabelHandler(globalDB, logger))
+
+	// Governance handlers
+	governance := indexer.Group("/governance", middleware.RateLimit(rateLimit.Rule("governance")))
+	governance.Get("/proposals", governance_handlers.GetGovernanceProposalsHandler(globalDB, logger))
+	governance.Get("/proposals/:tx_hash/:index/votes", governance_handlers.GetVotesByProposalHandler(globalDB, logger))
+	governance.Get("/votes", governance_handlers.GetVotesByVoterHandler(globalDB, logger))
 
 	// Metrics handlers
-	metrics := indexer.Group("/metrics")
+	metrics := indexer.Group("/metrics", middleware.RateLimit(rateLimit.Rule("metrics")))
 	metrics.Get("/addresses/count", metrics_handlers.GetAddressesCountHandler(globalDB, logger))
 	metrics.Get("/assets/count", metrics_handlers.GetAssetsCountHandler(globalDB, logger))
 	metrics.Get("/latest-block", metrics_handlers.GetLatestBlockHandler(globalDB, logger))
@@ -98,6 +208,7 @@ func RouterInit(router *fiber.App, db *database.Database, logger *slog.Logger) {
 	metrics.Get("/total_transaction_fees", metrics_handlers.GetTotalTransactionFeesHandler(globalDB))
 
 	// Redeemer handlers
-	redeemers := indexer.Group("/redeemers")
+	redeemers := indexer.Group("/redeemers", middleware.RateLimit(rateLimit.Rule("redeemers")))
+	redeemers.Get("/", redeemer_handlers.GetRedeemersHandler(globalDB, logger))
 	redeemers.Get("/:tx_hash", redeemer_handlers.GetRedeemersByTxHashHandler(globalDB, logger))
 }
@@ -1,21 +1,106 @@
 package viewmodel
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Andamio-Platform/andamio-indexer/internal/credential"
 )
 
 type AddressRequest struct {
-	Address string `json:"address"`
+	Address   string          `json:"address"`
+	Label     string          `json:"label,omitempty"`
+	Tags      []string        `json:"tags,omitempty"`
+	Source    string          `json:"source,omitempty"`
+	StartSlot uint64          `json:"start_slot,omitempty"`
+	Metadata  json.RawMessage `json:"metadata,omitempty" swaggertype:"object"`
 }
 
 func (ar *AddressRequest) IsValid() error {
-	// Regular expression for a valid address (example: Cardano address)
-	addressRegex := regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+	// Regular expression for a valid address (example: Cardano address, including
+	// testnet addr_test and stake_test prefixes)
+	addressRegex := regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
 
 	if !addressRegex.MatchString(ar.Address) {
 		return fmt.Errorf("invalid address format")
 	}
+	// Payment key hashes and script hashes are watched by their lower case hex
+	if credential.IsCredentialHash(ar.Address) {
+		ar.Address = strings.ToLower(ar.Address)
+	}
 
 	return nil
 }
+
+// BulkRemoveAddressesRequest lists the addresses to remove from the watchlist.
+type BulkRemoveAddressesRequest struct {
+	Addresses []string `json:"addresses"`
+}
+
+// ParseAddressRequestsCSV reads watchlist entries from CSV. The first row is a header naming
+// the columns: address (required), label, tags (separated by ";"), source, start_slot and
+// metadata (a JSON object).
+func ParseAddressRequestsCSV(r io.Reader) ([]AddressRequest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv is empty")
+		}
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["address"]; !ok {
+		return nil, errors.New("csv header must contain an address column")
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var requests []AddressRequest
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		request := AddressRequest{
+			Address: field(record, "address"),
+			Label:   field(record, "label"),
+			Source:  field(record, "source"),
+		}
+		if tags := field(record, "tags"); tags != "" {
+			request.Tags = strings.Split(tags, ";")
+		}
+		if startSlot := field(record, "start_slot"); startSlot != "" {
+			request.StartSlot, err = strconv.ParseUint(startSlot, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid start_slot %q", line, startSlot)
+			}
+		}
+		if metadata := field(record, "metadata"); metadata != "" {
+			request.Metadata = json.RawMessage(metadata)
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
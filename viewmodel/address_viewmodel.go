@@ -1,6 +1,10 @@
 package viewmodel
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
 
 // Address represents the view model for an Address API response.
 type Address struct {
@@ -14,4 +18,36 @@ func (v *Address) IsValid() error {
 	}
 	// Add more specific address validation if needed
 	return nil
-}
\ No newline at end of file
+}
+
+// WatchedAddress represents the view model for an address watchlist entry.
+type WatchedAddress struct {
+	Address   string          `json:"address"`
+	Label     string          `json:"label"`
+	Tags      []string        `json:"tags"`
+	Source    string          `json:"source"`
+	StartSlot uint64          `json:"start_slot"`
+	Metadata  json.RawMessage `json:"metadata,omitempty" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// BulkAddAddressesResult reports the outcome of a bulk watchlist addition.
+type BulkAddAddressesResult struct {
+	Added   int      `json:"added"`
+	Skipped []string `json:"skipped"` // addresses that were already watched
+}
+
+// AddressStats represents the view model for the activity of an address.
+type AddressStats struct {
+	Address        string `json:"address"`
+	FirstSeenSlot  uint64 `json:"first_seen_slot"`
+	FirstSeenTx    string `json:"first_seen_tx"`
+	LastSeenSlot   uint64 `json:"last_seen_slot"`
+	LastSeenTx     string `json:"last_seen_tx"`
+	TxCount        int64  `json:"tx_count"`
+	TotalReceived  uint64 `json:"total_received"`
+	TotalSent      uint64 `json:"total_sent"`
+	FeesPaid       uint64 `json:"fees_paid"`
+	DistinctAssets int64  `json:"distinct_assets"`
+	Counterparties int64  `json:"counterparties"`
+}
@@ -0,0 +1,23 @@
+package viewmodel
+
+// AssetHolding represents a quantity of an asset held at an address.
+type AssetHolding struct {
+	Address  string `json:"address"`
+	Quantity uint64 `json:"quantity"`
+}
+
+// AssetTransfer represents how a transaction moved an asset. Mint and Burn are set when the
+// transaction minted or burned the asset, by the quantity in Minted.
+type AssetTransfer struct {
+	TransactionHash string         `json:"transaction_hash"`
+	BlockNumber     uint64         `json:"block_number"`
+	SlotNumber      uint64         `json:"slot_number"`
+	BlockTime       string         `json:"block_time,omitempty"`
+	IsValid         bool           `json:"is_valid"`
+	From            []AssetHolding `json:"from"`
+	To              []AssetHolding `json:"to"`
+	Quantity        uint64         `json:"quantity"`
+	Mint            bool           `json:"mint"`
+	Burn            bool           `json:"burn"`
+	Minted          int64          `json:"minted"`
+}
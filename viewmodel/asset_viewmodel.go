@@ -25,4 +25,20 @@ func (v *Asset) IsValid() error {
 		return errors.New("fingerprint cannot be empty")
 	}
 	return nil
-}
\ No newline at end of file
+}
+// PolicyAsset represents the view model for an asset name under a policy, with the quantity
+// held in unspent outputs and the number of addresses holding it.
+type PolicyAsset struct {
+	PolicyId    string `json:"policy_id"`
+	Name        string `json:"name"`
+	NameHex     string `json:"name_hex"`
+	Fingerprint string `json:"fingerprint"`
+	Supply      uint64 `json:"supply"`
+	Holders     int64  `json:"holders"`
+}
+
+// AssetHolder represents the view model for an address holding an asset.
+type AssetHolder struct {
+	Address  string `json:"address"`
+	Quantity uint64 `json:"quantity"`
+}
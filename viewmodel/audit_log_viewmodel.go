@@ -0,0 +1,15 @@
+package viewmodel
+
+import "time"
+
+// AuditLog represents the view model for an AuditLog API response.
+type AuditLog struct {
+	ID          uint      `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	APIKeyID    uint      `json:"api_key_id"`
+	APIKeyName  string    `json:"api_key_name"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	RequestBody string    `json:"request_body"`
+	StatusCode  int       `json:"status_code"`
+}
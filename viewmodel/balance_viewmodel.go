@@ -0,0 +1,31 @@
+package viewmodel
+
+// AssetBalance represents the quantity of a native asset held at an address.
+type AssetBalance struct {
+	PolicyId    string `json:"policy_id"`
+	Name        string `json:"name"`
+	NameHex     string `json:"name_hex"`
+	Fingerprint string `json:"fingerprint"`
+	Quantity    uint64 `json:"quantity"`
+}
+
+// AddressBalance represents the view model for what an address held in UTxOs. AtSlot is
+// omitted for the current balance.
+type AddressBalance struct {
+	Address   string         `json:"address"`
+	AtSlot    *uint64        `json:"at_slot,omitempty"`
+	Lovelace  uint64         `json:"lovelace"`
+	UTxOCount int64          `json:"utxo_count"`
+	Assets    []AssetBalance `json:"assets"`
+}
+
+// BalancePoint represents a bucket of an address balance history: the lovelace received and
+// sent within the bucket and the balance at its end.
+type BalancePoint struct {
+	Bucket    string `json:"bucket"`
+	StartSlot uint64 `json:"start_slot"`
+	StartTime string `json:"start_time"`
+	Received  uint64 `json:"received"`
+	Sent      uint64 `json:"sent"`
+	Lovelace  uint64 `json:"lovelace"`
+}
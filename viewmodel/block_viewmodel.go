@@ -0,0 +1,15 @@
+package viewmodel
+
+// Block represents the view model for a block. BlockTime is omitted when the chain time
+// parameters of the network are not known.
+type Block struct {
+	Hash            string `json:"hash"`
+	Number          uint64 `json:"number"`
+	Slot            uint64 `json:"slot"`
+	BlockTime       string `json:"block_time,omitempty"`
+	Era             string `json:"era"`
+	Size            uint64 `json:"size"`
+	Issuer          string `json:"issuer"`
+	TxCount         uint64 `json:"tx_count"`
+	RelevantTxCount uint64 `json:"relevant_tx_count"`
+}
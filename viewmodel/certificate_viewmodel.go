@@ -0,0 +1,50 @@
+package viewmodel
+
+import "encoding/json"
+
+// Certificate represents the view model for a decoded certificate. Type tags the
+// certificate and only the fields of that type are set.
+type Certificate struct {
+	TransactionHash         string          `json:"transaction_hash,omitempty"`
+	SlotNumber              uint64          `json:"slot_number,omitempty"`
+	Index                   uint32          `json:"index"`
+	Type                    string          `json:"type"`
+	StakeCredential         string          `json:"stake_credential,omitempty"`
+	StakeCredentialType     string          `json:"stake_credential_type,omitempty"`
+	PoolKeyHash             string          `json:"pool_key_hash,omitempty"`
+	DrepType                string          `json:"drep_type,omitempty"`
+	DrepCredential          string          `json:"drep_credential,omitempty"`
+	CommitteeColdCredential string          `json:"committee_cold_credential,omitempty"`
+	CommitteeHotCredential  string          `json:"committee_hot_credential,omitempty"`
+	Amount                  int64           `json:"amount,omitempty"`
+	Epoch                   uint64          `json:"epoch,omitempty"`
+	Pool                    *PoolParameters `json:"pool,omitempty"`
+	AnchorUrl               string          `json:"anchor_url,omitempty"`
+	AnchorDataHash          string          `json:"anchor_data_hash,omitempty"`
+	Cbor                    string          `json:"cbor"`
+}
+
+// PoolParameters represents the parameters of a pool registration certificate.
+type PoolParameters struct {
+	VrfKeyHash    string          `json:"vrf_key_hash"`
+	Pledge        uint64          `json:"pledge"`
+	Cost          uint64          `json:"cost"`
+	Margin        string          `json:"margin"`
+	RewardAccount string          `json:"reward_account"`
+	Owners        []string        `json:"owners"`
+	Relays        json.RawMessage `json:"relays,omitempty"`
+	MetadataUrl   string          `json:"metadata_url,omitempty"`
+	MetadataHash  string          `json:"metadata_hash,omitempty"`
+}
+
+var credentialTypeNames = []string{"key_hash", "script_hash"}
+
+var drepTypeNames = []string{"key_hash", "script_hash", "abstain", "no_confidence"}
+
+// optionalNameOf returns the name at the index, or "" when the index is nil
+func optionalNameOf(names []string, index *uint8) string {
+	if index == nil {
+		return ""
+	}
+	return nameOf(names, *index)
+}
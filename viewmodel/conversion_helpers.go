@@ -1,9 +1,18 @@
 package viewmodel
 
 import (
+	"bytes"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
 
+	"github.com/Andamio-Platform/andamio-indexer/config"
+	"github.com/Andamio-Platform/andamio-indexer/database"
 	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+	"github.com/Andamio-Platform/andamio-indexer/database/types"
+	"github.com/Andamio-Platform/andamio-indexer/internal/txmetadata"
 )
 
 // Helper function to convert a slice of models.TransactionInput to a slice of viewmodel.TransactionInput
@@ -15,6 +24,8 @@ func ConvertTransactionInputsToViewModels(inputs []models.TransactionInput) []Tr
 			UTxOID:          hex.EncodeToString(input.UTxOID),
 			UTxOIDIndex:     input.UTxOIDIndex,
 			Address:         string(input.Address),
+			PaymentCredential: hex.EncodeToString(input.PaymentCredential),
+			StakeCredential:   hex.EncodeToString(input.StakeCredential),
 			Amount:          input.Amount,
 			Cbor:            hex.EncodeToString(input.Cbor),
 			Asset: ConvertAssetModelsToViewModels(input.Asset),
@@ -32,10 +43,14 @@ func ConvertTransactionOutputsToViewModels(outputs []models.TransactionOutput) [
 			UTxOID:      hex.EncodeToString(output.UTxOID),
 			UTxOIDIndex: output.UTxOIDIndex,
 			Address:     string(output.Address),
+			PaymentCredential: hex.EncodeToString(output.PaymentCredential),
+			StakeCredential:   hex.EncodeToString(output.StakeCredential),
 			Amount:      output.Amount,
 			Cbor:        hex.EncodeToString(output.Cbor),
 			Asset: ConvertAssetModelsToViewModels(output.Asset),
 			Datum: ConvertDatumModelToViewModel(output.Datum),
+			ReferenceScriptHash: hex.EncodeToString(output.ReferenceScriptHash),
+			BlockTime: convertSlotToBlockTime(output.SlotNumber),
 		})
 	}
 	return outputViewModels
@@ -58,13 +73,28 @@ func ConvertAssetModelsToViewModels(assets []models.Asset) []Asset {
 	return assetViewModels
 }
 
-// Helper function to convert a models.Datum to a viewmodel.Datum
-func ConvertDatumModelToViewModel(datum models.Datum) Datum {
-	return Datum{
-		UTxOID:      hex.EncodeToString(datum.UTxOID),
-		UTxOIDIndex: datum.UTxOIDIndex,
-		DatumHash:   hex.EncodeToString(datum.DatumHash),
-		DatumCbor:   hex.EncodeToString(datum.DatumCbor), // Assuming DatumCbor should be a string representation of CBOR
+// Helper function to convert a models.UTxODatum to a viewmodel.Datum
+func ConvertDatumModelToViewModel(utxoDatum *models.UTxODatum) Datum {
+	if utxoDatum == nil {
+		return Datum{}
+	}
+	datum := Datum{
+		UTxOID:      hex.EncodeToString(utxoDatum.UTxOID),
+		UTxOIDIndex: utxoDatum.UTxOIDIndex,
+		DatumHash:   hex.EncodeToString(utxoDatum.DatumHash),
+		Inline:      utxoDatum.Inline,
+	}
+	if utxoDatum.Datum != nil {
+		datum.DatumCbor = hex.EncodeToString(utxoDatum.Datum.DatumCbor)
+	}
+	return datum
+}
+
+// Helper function to convert a models.Datum to a viewmodel.DatumByHash
+func ConvertDatumByHashToViewModel(datum *models.Datum) DatumByHash {
+	return DatumByHash{
+		DatumHash: hex.EncodeToString(datum.DatumHash),
+		DatumCbor: hex.EncodeToString(datum.DatumCbor),
 	}
 }
 
@@ -85,12 +115,25 @@ func ConvertSimpleUTxOModelsToViewModels(utxos []models.SimpleUTxO) []SimpleUTxO
 func ConvertRedeemersToViewModels(redeemers []models.Redeemer) []Redeemer {
 	redeemerViewModels := []Redeemer{}
 	for _, redeemer := range redeemers {
-		redeemerViewModels = append(redeemerViewModels, Redeemer{
-			TransactionHash: hex.EncodeToString(redeemer.TransactionHash),
-			Index:           redeemer.Index,
-			Tag:             redeemer.Tag,
-			Cbor:            hex.EncodeToString(redeemer.Cbor), // CBOR string representation
-		})
+		redeemerViewModel := Redeemer{
+			TransactionHash:  hex.EncodeToString(redeemer.TransactionHash),
+			Index:            redeemer.Index,
+			Tag:              redeemer.Tag,
+			Cbor:             hex.EncodeToString(redeemer.Cbor), // CBOR string representation
+			ExUnitsMem:       redeemer.ExUnitsMem,
+			ExUnitsSteps:     redeemer.ExUnitsSteps,
+			Purpose:          redeemer.Purpose,
+			ScriptHash:       hex.EncodeToString(redeemer.ScriptHash),
+			UTxOID:           hex.EncodeToString(redeemer.UTxOID),
+			UTxOIDIndex:      redeemer.UTxOIDIndex,
+			CertificateIndex: redeemer.CertificateIndex,
+			RewardAccount:    redeemer.RewardAccount,
+		}
+		// A minting policy ID is the hash of the policy script
+		if redeemer.Purpose == models.RedeemerPurposeMint {
+			redeemerViewModel.PolicyId = redeemerViewModel.ScriptHash
+		}
+		redeemerViewModels = append(redeemerViewModels, redeemerViewModel)
 	}
 	return redeemerViewModels
 }
@@ -104,7 +147,35 @@ func ConvertWitnessModelToViewModel(witness models.Witness) Witness {
 		PlutusV2Scripts: ConvertByteSliceSliceToStringSlice(witness.PlutusV2Scripts),
 		PlutusV3Scripts: ConvertByteSliceSliceToStringSlice(witness.PlutusV3Scripts),
 		Redeemers:       ConvertRedeemersToViewModels(witness.Redeemers),
+		VkeyWitnesses:   ConvertVkeyWitnessesToViewModels(witness.VkeyWitnesses),
+		NativeScripts:   ConvertNativeScriptsToViewModels(witness.NativeScripts),
+	}
+}
+
+// Helper function to convert a slice of models.VkeyWitness to a slice of viewmodel.VkeyWitness
+func ConvertVkeyWitnessesToViewModels(vkeyWitnesses []models.VkeyWitness) []VkeyWitness {
+	viewModels := make([]VkeyWitness, len(vkeyWitnesses))
+	for i, w := range vkeyWitnesses {
+		viewModels[i] = VkeyWitness{
+			KeyHash:   hex.EncodeToString(w.KeyHash),
+			Vkey:      hex.EncodeToString(w.Vkey),
+			Signature: hex.EncodeToString(w.Signature),
+			Bootstrap: w.Bootstrap,
+		}
+	}
+	return viewModels
+}
+
+// Helper function to convert a slice of models.NativeScript to a slice of viewmodel.NativeScript
+func ConvertNativeScriptsToViewModels(nativeScripts []models.NativeScript) []NativeScript {
+	viewModels := make([]NativeScript, len(nativeScripts))
+	for i, script := range nativeScripts {
+		viewModels[i] = NativeScript{
+			ScriptHash: hex.EncodeToString(script.ScriptHash),
+			Cbor:       hex.EncodeToString(script.Cbor),
+		}
 	}
+	return viewModels
 }
 
 // Helper function to convert a slice of byte slices to a slice of strings
@@ -115,3 +186,684 @@ func ConvertByteSliceSliceToStringSlice(byteSlices [][]byte) []string {
 	}
 	return stringSlice
 }
+
+// Helper function to convert a slice of models.AuditLog to a slice of viewmodel.AuditLog
+func ConvertAuditLogModelsToViewModels(entries []models.AuditLog) []AuditLog {
+	auditLogViewModels := []AuditLog{}
+	for _, entry := range entries {
+		auditLogViewModels = append(auditLogViewModels, AuditLog{
+			ID:          entry.ID,
+			CreatedAt:   entry.CreatedAt,
+			APIKeyID:    entry.APIKeyID,
+			APIKeyName:  entry.APIKeyName,
+			Method:      entry.Method,
+			Path:        entry.Path,
+			RequestBody: string(entry.RequestBody),
+			StatusCode:  entry.StatusCode,
+		})
+	}
+	return auditLogViewModels
+}
+
+// Helper function to convert a database.Transaction to a viewmodel.Transaction
+func ConvertTransactionToViewModel(tx database.Transaction) Transaction {
+	metadataJSON, metadataDetailedSchema := ConvertMetadataToJSON(tx.Metadata)
+	blockTime := convertSlotToBlockTime(tx.SlotNumber)
+	transaction := Transaction{
+		TransactionHash: hex.EncodeToString(tx.TransactionHash),
+		BlockNumber:     tx.BlockNumber,
+		SlotNumber:      tx.SlotNumber,
+		BlockTime:       blockTime,
+		Inputs:          ConvertTransactionInputsToViewModels(tx.Inputs),
+		Outputs:         ConvertTransactionOutputsToViewModels(tx.Outputs),
+		Fee:             tx.Fee,
+		TTL:             tx.TTL,
+		BlockHash:       string(tx.BlockHash),
+		Metadata:        hex.EncodeToString(tx.Metadata),
+		ReferenceInputs: ConvertReferenceInputsToViewModels(tx.ReferenceInputs),
+		Withdrawals:     tx.Withdrawals,
+		Certificates:    ConvertTransactionCertificatesToViewModels(tx.DecodedCertificates, tx.Certificates),
+		Witness:         ConvertWitnessModelToViewModel(tx.Witness),
+		TransactionCBOR: hex.EncodeToString(tx.TransactionCBOR),
+
+		ValidityIntervalStart: tx.ValidityIntervalStart,
+		Collateral:            ConvertCollateralInputsToViewModels(tx.Collateral),
+		CollateralReturn:      ConvertCollateralReturnToViewModel(tx.CollateralReturn),
+		TotalCollateral:       tx.TotalCollateral,
+		RequiredSigners:       ConvertByteSliceSliceToStringSlice(tx.RequiredSigners),
+		NetworkId:             tx.NetworkId,
+		ScriptDataHash:        hex.EncodeToString(tx.ScriptDataHash),
+		Valid:                 tx.IsValid,
+		ExecutedScripts:       ConvertTransactionScriptsToStringSlice(tx.ExecutedScripts),
+		Proposals:             ConvertGovernanceProposalsToViewModels(tx.Proposals),
+		Votes:                 ConvertGovernanceVotesToViewModels(tx.Votes),
+		CurrentTreasuryValue:  tx.CurrentTreasuryValue,
+		TreasuryDonation:      tx.TreasuryDonation,
+
+		MetadataJSON:           metadataJSON,
+		MetadataDetailedSchema: metadataDetailedSchema,
+	}
+	// The reference inputs and collateral are read in the block of the transaction
+	for i := range transaction.ReferenceInputs {
+		transaction.ReferenceInputs[i].BlockTime = blockTime
+	}
+	for i := range transaction.Collateral {
+		transaction.Collateral[i].BlockTime = blockTime
+	}
+	return transaction
+}
+
+// Helper function to decode transaction metadata into its no-schema and detailed-schema JSON forms.
+// Metadata that cannot be decoded is only returned as CBOR.
+func ConvertMetadataToJSON(metadata []byte) (json.RawMessage, json.RawMessage) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	noSchema, err := txmetadata.NoSchema(metadata)
+	if err != nil {
+		return nil, nil
+	}
+	detailedSchema, err := txmetadata.DetailedSchema(metadata)
+	if err != nil {
+		return nil, nil
+	}
+	return noSchema, detailedSchema
+}
+
+// Helper function to convert a slice of models.TransactionScript to a slice of hex script hashes
+func ConvertTransactionScriptsToStringSlice(transactionScripts []models.TransactionScript) []string {
+	scriptHashes := []string{}
+	for _, transactionScript := range transactionScripts {
+		scriptHashes = append(scriptHashes, hex.EncodeToString(transactionScript.ScriptHash))
+	}
+	return scriptHashes
+}
+
+// Helper function to convert a models.Script and the UTxOs carrying it to a viewmodel.Script
+func ConvertScriptModelToViewModel(script models.Script, referenceUTxOs []models.TransactionOutput) Script {
+	return Script{
+		ScriptHash:      hex.EncodeToString(script.ScriptHash),
+		Language:        ScriptLanguageName(script.Language),
+		Size:            script.Size,
+		Cbor:            hex.EncodeToString(script.Cbor),
+		FirstSeenTxHash: hex.EncodeToString(script.FirstSeenTxHash),
+		FirstSeenSlot:   script.FirstSeenSlot,
+		ReferenceUTxOs:  ConvertTransactionOutputsToViewModels(referenceUTxOs),
+	}
+}
+
+// Helper function to convert a slice of models.CollateralInput to a slice of viewmodel.SimpleUTxO
+func ConvertCollateralInputsToViewModels(inputs []models.CollateralInput) []SimpleUTxO {
+	utxoViewModels := []SimpleUTxO{}
+	for _, input := range inputs {
+		utxoViewModels = append(utxoViewModels, SimpleUTxO{
+			TransactionHash: hex.EncodeToString(input.TransactionHash),
+			UTxOID:          hex.EncodeToString(input.UTxOID),
+			UTxOIDIndex:     input.UTxOIDIndex,
+		})
+	}
+	return utxoViewModels
+}
+
+// Helper function to convert a slice of reference input models.SimpleUTxO to a slice of viewmodel.ReferenceInput
+func ConvertReferenceInputsToViewModels(utxos []models.SimpleUTxO) []ReferenceInput {
+	referenceInputViewModels := []ReferenceInput{}
+	for _, utxo := range utxos {
+		referenceInput := ReferenceInput{
+			TransactionHash:     hex.EncodeToString(utxo.TransactionHash),
+			UTxOID:              hex.EncodeToString(utxo.UTxOID),
+			UTxOIDIndex:         utxo.UTxOIDIndex,
+			Resolved:            utxo.Resolved,
+			Address:             string(utxo.Address),
+			PaymentCredential:   hex.EncodeToString(utxo.PaymentCredential),
+			StakeCredential:     hex.EncodeToString(utxo.StakeCredential),
+			Amount:              utxo.Amount,
+			Asset:               ConvertAssetModelsToViewModels(utxo.Asset),
+			Cbor:                hex.EncodeToString(utxo.Cbor),
+			ReferenceScriptHash: hex.EncodeToString(utxo.ReferenceScriptHash),
+		}
+		if utxo.Datum != nil {
+			datum := ConvertDatumModelToViewModel(utxo.Datum)
+			referenceInput.Datum = &datum
+		}
+		referenceInputViewModels = append(referenceInputViewModels, referenceInput)
+	}
+	return referenceInputViewModels
+}
+
+// Helper function to convert an optional collateral return output to a viewmodel.TransactionOutput
+func ConvertCollateralReturnToViewModel(output *models.TransactionOutput) *TransactionOutput {
+	if output == nil {
+		return nil
+	}
+	return &ConvertTransactionOutputsToViewModels([]models.TransactionOutput{*output})[0]
+}
+
+// Helper function to convert a slice of database.Transaction to a slice of viewmodel.Transaction
+func ConvertTransactionsToViewModels(txs []database.Transaction) []Transaction {
+	transactionViewModels := []Transaction{}
+	for _, tx := range txs {
+		transactionViewModels = append(transactionViewModels, ConvertTransactionToViewModel(tx))
+	}
+	return transactionViewModels
+}
+
+// Helper function to convert a viewmodel.AddressRequest to a models.Address watchlist entry.
+// Tags are trimmed and deduplicated, and source defaults to defaultSource.
+func ConvertAddressRequestToModel(request AddressRequest, defaultSource string) (*models.Address, error) {
+	address := strings.TrimSpace(request.Address)
+	if address == "" {
+		return nil, errors.New("address is required")
+	}
+	entry := &models.Address{
+		Address:   address,
+		Label:     strings.TrimSpace(request.Label),
+		Source:    strings.TrimSpace(request.Source),
+		StartSlot: request.StartSlot,
+	}
+	if entry.Source == "" {
+		entry.Source = defaultSource
+	}
+	if metadata := bytes.TrimSpace(request.Metadata); len(metadata) > 0 && !bytes.Equal(metadata, []byte("null")) {
+		var object map[string]any
+		if err := json.Unmarshal(metadata, &object); err != nil {
+			return nil, errors.New("metadata must be a JSON object")
+		}
+		entry.Metadata = metadata
+	}
+	seen := make(map[string]bool, len(request.Tags))
+	for _, tag := range request.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		entry.Tags = append(entry.Tags, models.AddressTag{Tag: tag})
+	}
+	return entry, nil
+}
+
+// Helper function to convert a slice of models.Address to a slice of viewmodel.WatchedAddress
+func ConvertAddressModelsToWatchedAddresses(entries []models.Address) []WatchedAddress {
+	watchedAddressViewModels := []WatchedAddress{}
+	for _, entry := range entries {
+		tags := []string{}
+		for _, tag := range entry.Tags {
+			tags = append(tags, tag.Tag)
+		}
+		watchedAddress := WatchedAddress{
+			Address:   entry.Address,
+			Label:     entry.Label,
+			Tags:      tags,
+			Source:    entry.Source,
+			StartSlot: entry.StartSlot,
+			CreatedAt: entry.CreatedAt,
+		}
+		if len(entry.Metadata) > 0 {
+			watchedAddress.Metadata = json.RawMessage(entry.Metadata)
+		}
+		watchedAddressViewModels = append(watchedAddressViewModels, watchedAddress)
+	}
+	return watchedAddressViewModels
+}
+
+// Helper function to convert a slice of models.Policy to a slice of viewmodel.WatchedPolicy
+func ConvertPolicyModelsToViewModels(policies []models.Policy) []WatchedPolicy {
+	policyViewModels := []WatchedPolicy{}
+	for _, policy := range policies {
+		policyViewModels = append(policyViewModels, WatchedPolicy{
+			PolicyID:  policy.PolicyID,
+			Label:     policy.Label,
+			CreatedAt: policy.CreatedAt,
+		})
+	}
+	return policyViewModels
+}
+
+// Helper function to convert a slice of models.Fingerprint to a slice of viewmodel.WatchedFingerprint
+func ConvertFingerprintModelsToViewModels(fingerprints []models.Fingerprint) []WatchedFingerprint {
+	fingerprintViewModels := []WatchedFingerprint{}
+	for _, fingerprint := range fingerprints {
+		fingerprintViewModels = append(fingerprintViewModels, WatchedFingerprint{
+			Fingerprint: fingerprint.Fingerprint,
+			Label:       fingerprint.Label,
+			CreatedAt:   fingerprint.CreatedAt,
+		})
+	}
+	return fingerprintViewModels
+}
+
+// Helper function to convert a slice of models.Withdrawal to a slice of viewmodel.Withdrawal
+func ConvertWithdrawalModelsToViewModels(withdrawals []models.Withdrawal) []Withdrawal {
+	withdrawalViewModels := []Withdrawal{}
+	for _, withdrawal := range withdrawals {
+		withdrawalViewModels = append(withdrawalViewModels, Withdrawal{
+			TransactionHash: hex.EncodeToString(withdrawal.TransactionHash),
+			BlockNumber:     withdrawal.BlockNumber,
+			SlotNumber:      withdrawal.SlotNumber,
+			StakeAddress:    withdrawal.StakeAddress,
+			Amount:          withdrawal.Amount,
+		})
+	}
+	return withdrawalViewModels
+}
+
+// Helper function to convert a slice of models.Mint to a slice of viewmodel.Mint
+func ConvertMintModelsToViewModels(mints []models.Mint) []Mint {
+	mintViewModels := []Mint{}
+	for _, mint := range mints {
+		mintViewModel := Mint{
+			TransactionHash: hex.EncodeToString(mint.TransactionHash),
+			BlockNumber:     mint.BlockNumber,
+			SlotNumber:      mint.SlotNumber,
+			PolicyId:        string(mint.PolicyId),
+			Name:            string(mint.Name),
+			NameHex:         string(mint.NameHex),
+			Fingerprint:     string(mint.Fingerprint),
+			Quantity:        mint.Quantity,
+		}
+		if mint.Redeemer != nil {
+			redeemer := ConvertRedeemersToViewModels([]models.Redeemer{*mint.Redeemer})[0]
+			mintViewModel.Redeemer = &redeemer
+		}
+		mintViewModels = append(mintViewModels, mintViewModel)
+	}
+	return mintViewModels
+}
+
+// Helper function to convert a slice of models.AssetSupply to a slice of viewmodel.AssetSupply
+func ConvertAssetSupplyModelsToViewModels(policyId string, supplies []models.AssetSupply) []AssetSupply {
+	supplyViewModels := []AssetSupply{}
+	for _, supply := range supplies {
+		supplyViewModels = append(supplyViewModels, AssetSupply{
+			PolicyId:    policyId,
+			Name:        string(supply.Name),
+			NameHex:     string(supply.NameHex),
+			Fingerprint: string(supply.Fingerprint),
+			Supply:      supply.Supply,
+			Minted:      supply.Minted,
+			Burned:      supply.Burned,
+		})
+	}
+	return supplyViewModels
+}
+
+// Helper function to convert a slice of models.PolicyAsset to a slice of viewmodel.PolicyAsset
+func ConvertPolicyAssetModelsToViewModels(policyId string, policyAssets []models.PolicyAsset) []PolicyAsset {
+	policyAssetViewModels := []PolicyAsset{}
+	for _, policyAsset := range policyAssets {
+		policyAssetViewModels = append(policyAssetViewModels, PolicyAsset{
+			PolicyId:    policyId,
+			Name:        string(policyAsset.Name),
+			NameHex:     string(policyAsset.NameHex),
+			Fingerprint: string(policyAsset.Fingerprint),
+			Supply:      policyAsset.Supply,
+			Holders:     policyAsset.Holders,
+		})
+	}
+	return policyAssetViewModels
+}
+
+// Helper function to convert a slice of models.AssetHolder to a slice of viewmodel.AssetHolder
+func ConvertAssetHolderModelsToViewModels(holders []models.AssetHolder) []AssetHolder {
+	holderViewModels := []AssetHolder{}
+	for _, holder := range holders {
+		holderViewModels = append(holderViewModels, AssetHolder{
+			Address:  string(holder.Address),
+			Quantity: holder.Quantity,
+		})
+	}
+	return holderViewModels
+}
+
+// Helper function to convert a slice of models.GovernanceProposal to a slice of viewmodel.GovernanceProposal
+func ConvertGovernanceProposalsToViewModels(proposals []models.GovernanceProposal) []GovernanceProposal {
+	proposalViewModels := []GovernanceProposal{}
+	for _, proposal := range proposals {
+		proposalViewModels = append(proposalViewModels, GovernanceProposal{
+			TransactionHash:  hex.EncodeToString(proposal.TransactionHash),
+			ProposalIndex:    proposal.ProposalIndex,
+			BlockNumber:      proposal.BlockNumber,
+			SlotNumber:       proposal.SlotNumber,
+			Deposit:          proposal.Deposit,
+			RewardAccount:    proposal.RewardAccount,
+			ActionType:       nameOf(govActionTypeNames, proposal.ActionType),
+			PrevActionTxHash: hex.EncodeToString(proposal.PrevActionTxHash),
+			PrevActionIndex:  proposal.PrevActionIndex,
+			PolicyHash:       hex.EncodeToString(proposal.PolicyHash),
+			AnchorUrl:        proposal.AnchorUrl,
+			AnchorDataHash:   hex.EncodeToString(proposal.AnchorDataHash),
+			ActionCbor:       hex.EncodeToString(proposal.ActionCbor),
+		})
+	}
+	return proposalViewModels
+}
+
+// Helper function to convert a slice of models.GovernanceVote to a slice of viewmodel.GovernanceVote
+func ConvertGovernanceVotesToViewModels(votes []models.GovernanceVote) []GovernanceVote {
+	voteViewModels := []GovernanceVote{}
+	for _, vote := range votes {
+		voteViewModels = append(voteViewModels, GovernanceVote{
+			TransactionHash: hex.EncodeToString(vote.TransactionHash),
+			BlockNumber:     vote.BlockNumber,
+			SlotNumber:      vote.SlotNumber,
+			VoterType:       nameOf(voterTypeNames, vote.VoterType),
+			VoterHash:       hex.EncodeToString(vote.VoterHash),
+			GovActionTxHash: hex.EncodeToString(vote.GovActionTxHash),
+			GovActionIndex:  vote.GovActionIndex,
+			Vote:            nameOf(voteNames, vote.Vote),
+			AnchorUrl:       vote.AnchorUrl,
+			AnchorDataHash:  hex.EncodeToString(vote.AnchorDataHash),
+		})
+	}
+	return voteViewModels
+}
+
+// Helper function to convert a slice of models.Certificate to a slice of viewmodel.Certificate
+func ConvertCertificatesToViewModels(certificates []models.Certificate) []Certificate {
+	certificateViewModels := []Certificate{}
+	for _, certificate := range certificates {
+		certificateViewModel := Certificate{
+			TransactionHash:         hex.EncodeToString(certificate.TransactionHash),
+			SlotNumber:              certificate.SlotNumber,
+			Index:                   certificate.CertIndex,
+			Type:                    certificate.Type,
+			StakeCredential:         hex.EncodeToString(certificate.StakeCredential),
+			StakeCredentialType:     optionalNameOf(credentialTypeNames, certificate.StakeCredentialType),
+			PoolKeyHash:             hex.EncodeToString(certificate.PoolKeyHash),
+			DrepType:                optionalNameOf(drepTypeNames, certificate.DrepType),
+			DrepCredential:          hex.EncodeToString(certificate.DrepCredential),
+			CommitteeColdCredential: hex.EncodeToString(certificate.CommitteeColdCredential),
+			CommitteeHotCredential:  hex.EncodeToString(certificate.CommitteeHotCredential),
+			Amount:                  certificate.Amount,
+			Epoch:                   certificate.Epoch,
+			AnchorUrl:               certificate.AnchorUrl,
+			AnchorDataHash:          hex.EncodeToString(certificate.AnchorDataHash),
+			Cbor:                    hex.EncodeToString(certificate.Cbor),
+		}
+		if certificate.Type == models.CertificateTypePoolRegistration {
+			certificateViewModel.Pool = &PoolParameters{
+				VrfKeyHash:    hex.EncodeToString(certificate.VrfKeyHash),
+				Pledge:        certificate.Pledge,
+				Cost:          certificate.Cost,
+				Margin:        certificate.Margin,
+				RewardAccount: hex.EncodeToString(certificate.PoolRewardAccount),
+				Owners:        ConvertByteSliceSliceToStringSlice(certificate.PoolOwners),
+				MetadataUrl:   certificate.PoolMetadataUrl,
+				MetadataHash:  hex.EncodeToString(certificate.PoolMetadataHash),
+			}
+			if certificate.PoolRelays != "" {
+				certificateViewModel.Pool.Relays = json.RawMessage(certificate.PoolRelays)
+			}
+		}
+		certificateViewModels = append(certificateViewModels, certificateViewModel)
+	}
+	return certificateViewModels
+}
+
+// Helper function to convert the certificates of a transaction to a slice of viewmodel.Certificate.
+// Transactions indexed before certificates were decoded only have the raw CBOR, which is returned
+// with the type "unknown".
+func ConvertTransactionCertificatesToViewModels(decoded []models.Certificate, raw types.ByteSliceSlice) []Certificate {
+	if len(decoded) > 0 || len(raw) == 0 {
+		return ConvertCertificatesToViewModels(decoded)
+	}
+	certificateViewModels := []Certificate{}
+	for i, cbor := range raw {
+		certificateViewModels = append(certificateViewModels, Certificate{
+			Index: uint32(i),
+			Type:  "unknown",
+			Cbor:  hex.EncodeToString(cbor),
+		})
+	}
+	return certificateViewModels
+}
+
+// Helper function to convert a database.AssetTokenMetadata to a viewmodel.TokenMetadata
+func ConvertTokenMetadataToViewModel(fingerprint string, asset *database.AssetTokenMetadata) TokenMetadata {
+	tokenMetadata := TokenMetadata{
+		PolicyId:        string(asset.PolicyId),
+		NameHex:         string(asset.NameHex),
+		Fingerprint:     fingerprint,
+		Label:           asset.Label,
+		Standard:        asset.Metadata.Standard,
+		Version:         asset.Metadata.Version,
+		Metadata:        json.RawMessage(asset.Metadata.Metadata),
+		Cbor:            hex.EncodeToString(asset.Metadata.Cbor),
+		TransactionHash: hex.EncodeToString(asset.Metadata.TransactionHash),
+		SlotNumber:      asset.Metadata.SlotNumber,
+		OutputIndex:     asset.Metadata.OutputIndex,
+	}
+	if !bytes.Equal(asset.Metadata.NameHex, asset.NameHex) {
+		tokenMetadata.ReferenceNameHex = string(asset.Metadata.NameHex)
+	}
+	return tokenMetadata
+}
+
+// Helper function to convert a database.UTxO to a viewmodel.UTxO
+func ConvertUTxOToViewModel(utxo *database.UTxO) UTxO {
+	utxoViewModel := UTxO{
+		Output: ConvertTransactionOutputsToViewModels([]models.TransactionOutput{utxo.Output})[0],
+		Spent:  utxo.SpentBy != nil,
+	}
+	if utxo.CreatedBy != nil {
+		utxoViewModel.CreatedBy = ConvertTransactionToViewModel(*utxo.CreatedBy)
+		utxoViewModel.Output.BlockTime = utxoViewModel.CreatedBy.BlockTime
+	}
+	if utxo.SpentBy != nil {
+		spentBy := ConvertTransactionToViewModel(*utxo.SpentBy)
+		utxoViewModel.SpentBy = &spentBy
+	}
+	return utxoViewModel
+}
+
+// Helper function to convert a database.UTxOLineage to a viewmodel.UTxOLineage
+func ConvertUTxOLineageToViewModel(utxoID []byte, utxoIndex uint32, depth int, lineage *database.UTxOLineage) UTxOLineage {
+	return UTxOLineage{
+		UTxOID:      hex.EncodeToString(utxoID),
+		UTxOIDIndex: utxoIndex,
+		Depth:       depth,
+		Ancestors:   convertLineageSteps(lineage.Ancestors),
+		Descendants: convertLineageSteps(lineage.Descendants),
+		Truncated:   lineage.Truncated,
+	}
+}
+
+// Helper function to convert a slice of database.UTxOLineageStep to a slice of viewmodel.LineageStep
+func convertLineageSteps(steps []database.UTxOLineageStep) []LineageStep {
+	stepViewModels := []LineageStep{}
+	for _, step := range steps {
+		stepViewModels = append(stepViewModels, LineageStep{
+			Depth:           step.Depth,
+			TransactionHash: hex.EncodeToString(step.TransactionHash),
+			BlockNumber:     step.BlockNumber,
+			SlotNumber:      step.SlotNumber,
+			BlockTime:       convertSlotToBlockTime(step.SlotNumber),
+			IsValid:         step.IsValid,
+			Consumed:        convertUTxORefs(step.Consumed),
+			Produced:        convertUTxORefs(step.Produced),
+		})
+	}
+	return stepViewModels
+}
+
+// Helper function to convert a slice of database.UTxORef to a slice of viewmodel.UTxORef
+func convertUTxORefs(refs []database.UTxORef) []UTxORef {
+	refViewModels := []UTxORef{}
+	for _, ref := range refs {
+		refViewModels = append(refViewModels, UTxORef{
+			UTxOID:      hex.EncodeToString(ref.UTxOID),
+			UTxOIDIndex: ref.UTxOIDIndex,
+		})
+	}
+	return refViewModels
+}
+
+// Helper function to convert a database.ValueFlow to a viewmodel.ValueFlow
+func ConvertValueFlowToViewModel(flow *database.ValueFlow) ValueFlow {
+	addresses := []AddressValueFlow{}
+	for _, address := range flow.Addresses {
+		addresses = append(addresses, AddressValueFlow{
+			Address:  address.Address,
+			Script:   address.Script,
+			Consumed: address.Consumed,
+			Produced: address.Produced,
+			Lovelace: address.Lovelace,
+			Assets:   convertAssetDeltas(address.Assets),
+		})
+	}
+	return ValueFlow{
+		TransactionHash: hex.EncodeToString(flow.TransactionHash),
+		IsValid:         flow.IsValid,
+		Fee:             flow.Fee,
+		Withdrawals:     flow.Withdrawals,
+		ScriptsExecuted: flow.ScriptsExecuted,
+		Complete:        flow.Complete,
+		Addresses:       addresses,
+	}
+}
+
+// Helper function to convert a database.AddressTxSummary to a viewmodel.AddressTxSummary
+func ConvertAddressTxSummaryToViewModel(summary database.AddressTxSummary) *AddressTxSummary {
+	return &AddressTxSummary{
+		Direction: summary.Direction,
+		Lovelace:  summary.Lovelace,
+		Assets:    convertAssetDeltas(summary.Assets),
+	}
+}
+
+// Helper function to convert a slice of database.AssetDelta to a slice of viewmodel.AssetDelta
+func convertAssetDeltas(deltas []database.AssetDelta) []AssetDelta {
+	deltaViewModels := []AssetDelta{}
+	for _, delta := range deltas {
+		deltaViewModels = append(deltaViewModels, AssetDelta{
+			PolicyId: string(delta.PolicyId),
+			NameHex:  string(delta.NameHex),
+			Amount:   json.Number(delta.Amount.String()),
+		})
+	}
+	return deltaViewModels
+}
+
+// Helper function to convert a slice of database.AssetTransfer to a slice of viewmodel.AssetTransfer
+func ConvertAssetTransfersToViewModels(transfers []database.AssetTransfer) []AssetTransfer {
+	transferViewModels := []AssetTransfer{}
+	for _, transfer := range transfers {
+		transferViewModels = append(transferViewModels, AssetTransfer{
+			TransactionHash: hex.EncodeToString(transfer.TransactionHash),
+			BlockNumber:     transfer.BlockNumber,
+			SlotNumber:      transfer.SlotNumber,
+			BlockTime:       convertSlotToBlockTime(transfer.SlotNumber),
+			IsValid:         transfer.IsValid,
+			From:            convertAssetHoldings(transfer.From),
+			To:              convertAssetHoldings(transfer.To),
+			Quantity:        transfer.Quantity,
+			Mint:            transfer.Minted > 0,
+			Burn:            transfer.Minted < 0,
+			Minted:          transfer.Minted,
+		})
+	}
+	return transferViewModels
+}
+
+// Helper function to convert a slice of database.AssetHolding to a slice of viewmodel.AssetHolding
+func convertAssetHoldings(holdings []database.AssetHolding) []AssetHolding {
+	holdingViewModels := []AssetHolding{}
+	for _, holding := range holdings {
+		holdingViewModels = append(holdingViewModels, AssetHolding{
+			Address:  holding.Address,
+			Quantity: holding.Quantity,
+		})
+	}
+	return holdingViewModels
+}
+
+// Helper function to convert a database.AddressBalance to a viewmodel.AddressBalance
+func ConvertAddressBalanceToViewModel(balance *database.AddressBalance, atSlot *uint64) AddressBalance {
+	assets := []AssetBalance{}
+	for _, asset := range balance.Assets {
+		assets = append(assets, AssetBalance{
+			PolicyId:    string(asset.PolicyId),
+			Name:        string(asset.Name),
+			NameHex:     string(asset.NameHex),
+			Fingerprint: string(asset.Fingerprint),
+			Quantity:    asset.Quantity,
+		})
+	}
+	return AddressBalance{
+		Address:   balance.Address,
+		AtSlot:    atSlot,
+		Lovelace:  balance.Lovelace,
+		UTxOCount: balance.UTxOCount,
+		Assets:    assets,
+	}
+}
+
+// Helper function to convert a slice of database.BalancePoint to a slice of viewmodel.BalancePoint
+func ConvertBalancePointsToViewModels(points []database.BalancePoint) []BalancePoint {
+	pointViewModels := []BalancePoint{}
+	for _, point := range points {
+		pointViewModels = append(pointViewModels, BalancePoint{
+			Bucket:    point.Bucket,
+			StartSlot: point.StartSlot,
+			StartTime: point.StartTime.UTC().Format(time.RFC3339),
+			Received:  point.Received,
+			Sent:      point.Sent,
+			Lovelace:  point.Lovelace,
+		})
+	}
+	return pointViewModels
+}
+
+// Helper function to convert a models.AddressStats to a viewmodel.AddressStats
+func ConvertAddressStatsModelToViewModel(stats *models.AddressStats) AddressStats {
+	return AddressStats{
+		Address:        string(stats.Address),
+		FirstSeenSlot:  stats.FirstSlot,
+		FirstSeenTx:    hex.EncodeToString(stats.FirstTxHash),
+		LastSeenSlot:   stats.LastSlot,
+		LastSeenTx:     hex.EncodeToString(stats.LastTxHash),
+		TxCount:        stats.TxCount,
+		TotalReceived:  stats.TotalReceived,
+		TotalSent:      stats.TotalSent,
+		FeesPaid:       stats.FeesPaid,
+		DistinctAssets: stats.DistinctAssets,
+		Counterparties: stats.Counterparties,
+	}
+}
+
+// Helper function to convert a models.Block to a viewmodel.Block
+func ConvertBlockModelToViewModel(block *models.Block) Block {
+	blockViewModel := Block{
+		Hash:            string(block.BlockHash),
+		Number:          block.BlockNumber,
+		Slot:            block.SlotNumber,
+		Era:             block.Era,
+		Size:            block.Size,
+		Issuer:          block.Issuer,
+		TxCount:         block.TxCount,
+		RelevantTxCount: block.RelevantTxCount,
+	}
+	if !block.BlockTime.IsZero() {
+		blockViewModel.BlockTime = block.BlockTime.UTC().Format(time.RFC3339)
+	} else {
+		// Blocks seen before the chain time parameters of the network were configured
+		blockViewModel.BlockTime = convertSlotToBlockTime(block.SlotNumber)
+	}
+	return blockViewModel
+}
+
+// Helper function to format the start time of the slot of a block in RFC 3339. It returns an
+// empty string for slot 0, which stands for an unknown slot, and when the chain time
+// parameters of the network are not known.
+func convertSlotToBlockTime(slot uint64) string {
+	cfg := config.GetGlobalConfig()
+	if slot == 0 || cfg == nil {
+		return ""
+	}
+	params, ok := cfg.Network.ChainTimeParams()
+	if !ok {
+		return ""
+	}
+	return params.SlotToTime(slot).UTC().Format(time.RFC3339)
+}
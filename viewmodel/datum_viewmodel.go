@@ -2,12 +2,20 @@ package viewmodel
 
 import "errors"
 
-// Datum represents the view model for a Datum API response.
+// Datum represents the view model for the datum of a UTxO. Inline is set when the output carries
+// the datum itself rather than its hash; DatumCbor is empty until a hash-only datum is resolved.
 type Datum struct {
 	UTxOID              string `json:"utxo_id"`
 	UTxOIDIndex         uint32 `json:"utxo_index"`
 	DatumHash           string `json:"datum_hash"`
 	DatumCbor           string `json:"datum_cbor"`
+	Inline              bool   `json:"inline"`
+}
+
+// DatumByHash represents the view model for a datum looked up by its hash.
+type DatumByHash struct {
+	DatumHash string `json:"datum_hash"`
+	DatumCbor string `json:"datum_cbor"`
 }
 
 // IsValid performs validation on the Datum view model.
@@ -0,0 +1,36 @@
+package viewmodel
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// fingerprintRegex matches a CIP-14 asset fingerprint: 20 bytes bech32 encoded with the asset prefix.
+var fingerprintRegex = regexp.MustCompile(`^asset1[02-9ac-hj-np-z]{38}$`)
+
+// FingerprintRequest is the request body for adding an asset fingerprint to the watchlist.
+type FingerprintRequest struct {
+	Fingerprint string `json:"fingerprint"`
+	Label       string `json:"label,omitempty"`
+}
+
+// IsValid checks that the fingerprint is a CIP-14 asset fingerprint.
+func (r *FingerprintRequest) IsValid() error {
+	r.Fingerprint = strings.ToLower(strings.TrimSpace(r.Fingerprint))
+	if r.Fingerprint == "" {
+		return errors.New("fingerprint is required")
+	}
+	if !fingerprintRegex.MatchString(r.Fingerprint) {
+		return errors.New("fingerprint must be a CIP-14 asset fingerprint (asset1...)")
+	}
+	return nil
+}
+
+// WatchedFingerprint represents the view model for a watched asset fingerprint.
+type WatchedFingerprint struct {
+	Fingerprint string    `json:"fingerprint"`
+	Label       string    `json:"label"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,60 @@
+package viewmodel
+
+// GovernanceProposal represents the view model for a governance proposal.
+type GovernanceProposal struct {
+	TransactionHash  string  `json:"transaction_hash"`
+	ProposalIndex    uint32  `json:"proposal_index"`
+	BlockNumber      uint64  `json:"block_number"`
+	SlotNumber       uint64  `json:"slot_number"`
+	Deposit          uint64  `json:"deposit"`
+	RewardAccount    string  `json:"reward_account"`
+	ActionType       string  `json:"action_type"`
+	PrevActionTxHash string  `json:"prev_action_tx_hash,omitempty"`
+	PrevActionIndex  *uint32 `json:"prev_action_index,omitempty"`
+	PolicyHash       string  `json:"policy_hash,omitempty"`
+	AnchorUrl        string  `json:"anchor_url"`
+	AnchorDataHash   string  `json:"anchor_data_hash"`
+	ActionCbor       string  `json:"action_cbor"`
+}
+
+// GovernanceVote represents the view model for a governance vote.
+type GovernanceVote struct {
+	TransactionHash string `json:"transaction_hash"`
+	BlockNumber     uint64 `json:"block_number"`
+	SlotNumber      uint64 `json:"slot_number"`
+	VoterType       string `json:"voter_type"`
+	VoterHash       string `json:"voter_hash"`
+	GovActionTxHash string `json:"gov_action_tx_hash"`
+	GovActionIndex  uint32 `json:"gov_action_index"`
+	Vote            string `json:"vote"`
+	AnchorUrl       string `json:"anchor_url,omitempty"`
+	AnchorDataHash  string `json:"anchor_data_hash,omitempty"`
+}
+
+var govActionTypeNames = []string{
+	"parameter_change",
+	"hard_fork_initiation",
+	"treasury_withdrawals",
+	"no_confidence",
+	"update_committee",
+	"new_constitution",
+	"info",
+}
+
+var voterTypeNames = []string{
+	"committee_key_hash",
+	"committee_script_hash",
+	"drep_key_hash",
+	"drep_script_hash",
+	"stake_pool",
+}
+
+var voteNames = []string{"no", "yes", "abstain"}
+
+// nameOf returns the name at the index, or "unknown"
+func nameOf(names []string, index uint8) string {
+	if int(index) < len(names) {
+		return names[index]
+	}
+	return "unknown"
+}
@@ -0,0 +1,25 @@
+package viewmodel
+
+// Mint represents the view model for a mint or burn of one asset. Burns have a negative quantity.
+type Mint struct {
+	TransactionHash string    `json:"transaction_hash"`
+	BlockNumber     uint64    `json:"block_number"`
+	SlotNumber      uint64    `json:"slot_number"`
+	PolicyId        string    `json:"policy_id"`
+	Name            string    `json:"name"`
+	NameHex         string    `json:"name_hex"`
+	Fingerprint     string    `json:"fingerprint"`
+	Quantity        int64     `json:"quantity"`
+	Redeemer        *Redeemer `json:"redeemer,omitempty"`
+}
+
+// AssetSupply represents the view model for the supply of one asset name under a policy.
+type AssetSupply struct {
+	PolicyId    string `json:"policy_id"`
+	Name        string `json:"name"`
+	NameHex     string `json:"name_hex"`
+	Fingerprint string `json:"fingerprint"`
+	Supply      int64  `json:"supply"`
+	Minted      int64  `json:"minted"`
+	Burned      int64  `json:"burned"`
+}
@@ -0,0 +1,33 @@
+package viewmodel
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// PolicyRequest is the request body for adding a policy ID to the watchlist.
+type PolicyRequest struct {
+	PolicyID string `json:"policy_id"`
+	Label    string `json:"label,omitempty"`
+}
+
+// IsValid checks that the policy ID is a hex encoded 28 byte hash.
+func (r *PolicyRequest) IsValid() error {
+	r.PolicyID = strings.ToLower(strings.TrimSpace(r.PolicyID))
+	if r.PolicyID == "" {
+		return errors.New("policy_id is required")
+	}
+	if policyId, err := hex.DecodeString(r.PolicyID); err != nil || len(policyId) != 28 {
+		return errors.New("policy_id must be a hex encoded 28 byte hash")
+	}
+	return nil
+}
+
+// WatchedPolicy represents the view model for a watched policy ID.
+type WatchedPolicy struct {
+	PolicyID  string    `json:"policy_id"`
+	Label     string    `json:"label"`
+	CreatedAt time.Time `json:"created_at"`
+}
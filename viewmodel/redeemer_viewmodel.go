@@ -8,6 +8,16 @@ type Redeemer struct {
 	Index           uint   `json:"index"`
 	Tag             uint8  `json:"tag"`
 	Cbor            string `json:"cbor"` // CBOR string representation
+	ExUnitsMem      uint64 `json:"ex_units_mem"`
+	ExUnitsSteps    uint64 `json:"ex_units_steps"`
+	Purpose         string `json:"purpose"`
+	ScriptHash      string `json:"script_hash,omitempty"`
+	// Purpose targets, only the one matching the purpose is set
+	UTxOID           string  `json:"utxo_id,omitempty"`
+	UTxOIDIndex      *uint32 `json:"utxo_index,omitempty"`
+	PolicyId         string  `json:"policy_id,omitempty"`
+	CertificateIndex *uint   `json:"certificate_index,omitempty"`
+	RewardAccount    string  `json:"reward_account,omitempty"`
 }
 
 // IsValid performs validation on the Redeemer view model.
@@ -0,0 +1,21 @@
+package viewmodel
+
+// ReferenceInput represents the view model for a reference input. Resolved is set when the
+// referenced output could be resolved when the transaction was indexed, in which case the
+// address, value, datum and script reference of the UTxO the transaction read are filled in.
+type ReferenceInput struct {
+	TransactionHash     string  `json:"transaction_hash"`
+	UTxOID              string  `json:"utxo_id"`
+	UTxOIDIndex         uint32  `json:"utxo_index"`
+	Resolved            bool    `json:"resolved"`
+	Address             string  `json:"address,omitempty"`
+	PaymentCredential   string  `json:"payment_credential,omitempty"`
+	StakeCredential     string  `json:"stake_credential,omitempty"`
+	Amount              uint64  `json:"amount"`
+	Asset               []Asset `json:"asset"`
+	Datum               *Datum  `json:"datum,omitempty"`
+	Cbor                string  `json:"cbor,omitempty"`
+	ReferenceScriptHash string  `json:"reference_script_hash,omitempty"`
+	// BlockTime is the time of the block of the transaction that read the UTxO
+	BlockTime string `json:"block_time,omitempty"`
+}
@@ -0,0 +1,31 @@
+package viewmodel
+
+import (
+	"github.com/Andamio-Platform/andamio-indexer/database/plugin/metadata/sqlite/models"
+)
+
+// Script represents the view model for a script registry entry.
+type Script struct {
+	ScriptHash      string              `json:"script_hash"`
+	Language        string              `json:"language"`
+	Size            int                 `json:"size"`
+	Cbor            string              `json:"cbor"`
+	FirstSeenTxHash string              `json:"first_seen_tx_hash"`
+	FirstSeenSlot   uint64              `json:"first_seen_slot"`
+	ReferenceUTxOs  []TransactionOutput `json:"reference_utxos"`
+}
+
+// ScriptLanguageName returns the API name of a script language.
+func ScriptLanguageName(language uint8) string {
+	switch language {
+	case models.ScriptLanguageNative:
+		return "native"
+	case models.ScriptLanguagePlutusV1:
+		return "plutus_v1"
+	case models.ScriptLanguagePlutusV2:
+		return "plutus_v2"
+	case models.ScriptLanguagePlutusV3:
+		return "plutus_v3"
+	}
+	return "unknown"
+}
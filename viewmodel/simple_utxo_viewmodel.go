@@ -7,6 +7,8 @@ type SimpleUTxO struct {
 	TransactionHash string `json:"transaction_hash"`
 	UTxOID          string `json:"utxo_id"`
 	UTxOIDIndex     uint32 `json:"utxo_index"`
+	// BlockTime is the time of the block of the transaction the UTxO is listed in
+	BlockTime string `json:"block_time,omitempty"`
 }
 
 // IsValid performs validation on the SimpleUTxO view model.
@@ -0,0 +1,20 @@
+package viewmodel
+
+import "encoding/json"
+
+// TokenMetadata represents the view model for the CIP-25 or CIP-68 metadata resolved for an asset.
+// For CIP-68 user tokens, ReferenceNameHex is the reference token whose datum holds the metadata.
+type TokenMetadata struct {
+	PolicyId         string          `json:"policy_id"`
+	NameHex          string          `json:"name_hex"`
+	Fingerprint      string          `json:"fingerprint"`
+	Label            *uint16         `json:"label,omitempty"`
+	Standard         string          `json:"standard"`
+	Version          int64           `json:"version"`
+	Metadata         json.RawMessage `json:"metadata"`
+	Cbor             string          `json:"cbor"`
+	ReferenceNameHex string          `json:"reference_name_hex,omitempty"`
+	TransactionHash  string          `json:"transaction_hash"`
+	SlotNumber       uint64          `json:"slot_number"`
+	OutputIndex      *uint32         `json:"output_index,omitempty"`
+}
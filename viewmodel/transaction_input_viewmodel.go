@@ -8,6 +8,8 @@ type TransactionInput struct {
 	UTxOID          string `json:"utxo_id"`
 	UTxOIDIndex     uint32 `json:"utxo_index"`
 	Address         string `json:"address"`
+	PaymentCredential string `json:"payment_credential,omitempty"`
+	StakeCredential   string `json:"stake_credential,omitempty"`
 	Amount          uint64 `json:"amount"`
 	Asset           []Asset `json:"asset"`
 	Datum           Datum   `json:"datum"`
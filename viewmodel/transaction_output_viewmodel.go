@@ -7,10 +7,15 @@ type TransactionOutput struct {
 	UTxOID      string `json:"utxo_id"`
 	UTxOIDIndex uint32 `json:"utxo_index"`
 	Address     string `json:"address"`
+	PaymentCredential string `json:"payment_credential,omitempty"`
+	StakeCredential   string `json:"stake_credential,omitempty"`
 	Amount      uint64 `json:"amount"`
 	Asset       []Asset `json:"asset"`
 	Datum       Datum   `json:"datum"`
 	Cbor        string `json:"cbor"` // CBOR string representation
+	ReferenceScriptHash string `json:"reference_script_hash,omitempty"`
+	// BlockTime is the time of the block of the transaction that produced the output
+	BlockTime string `json:"block_time,omitempty"`
 }
 
 // IsValid performs validation on the TransactionOutput view model.
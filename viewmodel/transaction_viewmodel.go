@@ -1,23 +1,49 @@
 package viewmodel
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+)
 
 // Transaction represents the view model for a Transaction API response.
 type Transaction struct {
 	BlockHash       string              `json:"block_hash"`
 	BlockNumber     uint64              `json:"block_number"`
 	SlotNumber      uint64              `json:"slot_number"`
+	// BlockTime is the start time of the slot in RFC 3339, omitted when the chain time
+	// parameters of the network are not known
+	BlockTime       string              `json:"block_time,omitempty"`
 	TransactionHash string              `json:"transaction_hash"`
 	Inputs          []TransactionInput  `json:"inputs"`
 	Outputs         []TransactionOutput `json:"outputs"`
-	ReferenceInputs []SimpleUTxO        `json:"reference_inputs"`
+	ReferenceInputs []ReferenceInput    `json:"reference_inputs"`
 	Metadata        string              `json:"metadata"` // CBOR string representation
 	Fee             uint64              `json:"fee"`
 	TTL             uint64              `json:"ttl"`
 	Withdrawals     map[string]uint64   `json:"withdrawals"`
 	Witness         Witness             `json:"witness"`
-	Certificates    []string            `json:"certificates"` // Slice of CBOR string representations
+	Certificates    []Certificate       `json:"certificates"`
 	TransactionCBOR string              `json:"transaction_cbor"`
+
+	ValidityIntervalStart uint64             `json:"validity_interval_start"`
+	Collateral            []SimpleUTxO       `json:"collateral"`
+	CollateralReturn      *TransactionOutput `json:"collateral_return,omitempty"`
+	TotalCollateral       uint64             `json:"total_collateral"`
+	RequiredSigners       []string           `json:"required_signers"`
+	NetworkId             *uint8             `json:"network_id,omitempty"`
+	ScriptDataHash        string             `json:"script_data_hash,omitempty"`
+	// Valid is false when the transaction failed phase-2 validation and only its collateral was consumed
+	Valid bool `json:"is_valid"`
+	// ExecutedScripts are the hashes of the scripts run by the transaction
+	ExecutedScripts []string `json:"executed_scripts"`
+	// Conway governance procedures and treasury fields
+	Proposals            []GovernanceProposal `json:"proposals"`
+	Votes                []GovernanceVote     `json:"votes"`
+	CurrentTreasuryValue int64                `json:"current_treasury_value,omitempty"`
+	TreasuryDonation     uint64               `json:"treasury_donation,omitempty"`
+	// Metadata decoded into the no-schema and detailed-schema JSON forms, keyed by label
+	MetadataJSON           json.RawMessage `json:"metadata_json,omitempty"`
+	MetadataDetailedSchema json.RawMessage `json:"metadata_detailed_schema,omitempty"`
 }
 
 // IsValid performs validation on the Transaction view model.
@@ -0,0 +1,40 @@
+package viewmodel
+
+// UTxO represents the view model for an indexed UTxO with the transactions that created and,
+// once spent, spent it.
+type UTxO struct {
+	Output    TransactionOutput `json:"output"`
+	Spent     bool              `json:"spent"`
+	CreatedBy Transaction       `json:"created_by"`
+	SpentBy   *Transaction      `json:"spent_by,omitempty"`
+}
+
+// UTxORef identifies a UTxO by the hash of the transaction that created it and its index.
+type UTxORef struct {
+	UTxOID      string `json:"utxo_id"`
+	UTxOIDIndex uint32 `json:"utxo_index"`
+}
+
+// LineageStep represents a transaction of a spend chain, depth transactions away from the
+// UTxO, with the UTxOs it consumed and produced.
+type LineageStep struct {
+	Depth           int       `json:"depth"`
+	TransactionHash string    `json:"transaction_hash"`
+	BlockNumber     uint64    `json:"block_number"`
+	SlotNumber      uint64    `json:"slot_number"`
+	BlockTime       string    `json:"block_time,omitempty"`
+	IsValid         bool      `json:"is_valid"`
+	Consumed        []UTxORef `json:"consumed"`
+	Produced        []UTxORef `json:"produced"`
+}
+
+// UTxOLineage represents the view model for the spend chain around a UTxO.
+type UTxOLineage struct {
+	UTxOID      string        `json:"utxo_id"`
+	UTxOIDIndex uint32        `json:"utxo_index"`
+	Depth       int           `json:"depth"`
+	Ancestors   []LineageStep `json:"ancestors"`
+	Descendants []LineageStep `json:"descendants"`
+	// Truncated is set when the walk stopped at its limits before reaching the depth.
+	Truncated bool `json:"truncated"`
+}
@@ -0,0 +1,46 @@
+package viewmodel
+
+import "encoding/json"
+
+// AssetDelta represents the net change in the amount of an asset. Amount is negative when
+// the asset left the address.
+type AssetDelta struct {
+	PolicyId string      `json:"policy_id"`
+	NameHex  string      `json:"name_hex"`
+	Amount   json.Number `json:"amount"`
+}
+
+// AddressValueFlow represents the value a transaction consumed from and produced to an address.
+type AddressValueFlow struct {
+	Address  string       `json:"address"`
+	Script   bool         `json:"script"`
+	Consumed uint64       `json:"consumed"`
+	Produced uint64       `json:"produced"`
+	Lovelace int64        `json:"lovelace"`
+	Assets   []AssetDelta `json:"assets"`
+}
+
+// ValueFlow represents the view model for the value a transaction moved between addresses.
+type ValueFlow struct {
+	TransactionHash string             `json:"transaction_hash"`
+	IsValid         bool               `json:"is_valid"`
+	Fee             uint64             `json:"fee"`
+	Withdrawals     uint64             `json:"withdrawals"`
+	ScriptsExecuted bool               `json:"scripts_executed"`
+	Complete        bool               `json:"complete"`
+	Addresses       []AddressValueFlow `json:"addresses"`
+}
+
+// AddressTxSummary represents the direction of a transaction and its net value change for an address.
+type AddressTxSummary struct {
+	Direction string       `json:"direction"`
+	Lovelace  int64        `json:"lovelace"`
+	Assets    []AssetDelta `json:"assets"`
+}
+
+// AddressTransaction represents a transaction of an address, with its summary for the
+// address when requested.
+type AddressTransaction struct {
+	Transaction
+	Summary *AddressTxSummary `json:"summary,omitempty"`
+}
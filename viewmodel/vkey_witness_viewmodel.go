@@ -0,0 +1,15 @@
+package viewmodel
+
+// VkeyWitness represents the view model for a vkey or bootstrap witness.
+type VkeyWitness struct {
+	KeyHash   string `json:"key_hash"`
+	Vkey      string `json:"vkey"`
+	Signature string `json:"signature"`
+	Bootstrap bool   `json:"bootstrap"`
+}
+
+// NativeScript represents the view model for a native script witness.
+type NativeScript struct {
+	ScriptHash string `json:"script_hash"`
+	Cbor       string `json:"cbor"`
+}
@@ -0,0 +1,10 @@
+package viewmodel
+
+// Withdrawal represents the view model for a reward withdrawal.
+type Withdrawal struct {
+	TransactionHash string `json:"transaction_hash"`
+	BlockNumber     uint64 `json:"block_number"`
+	SlotNumber      uint64 `json:"slot_number"`
+	StakeAddress    string `json:"stake_address"`
+	Amount          uint64 `json:"amount"`
+}
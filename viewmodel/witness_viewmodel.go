@@ -10,6 +10,8 @@ type Witness struct {
 	PlutusV2Scripts []string `json:"plutus_v2_scripts"` // Slice of CBOR string representations
 	PlutusV3Scripts []string `json:"plutus_v3_scripts"` // Slice of CBOR string representations
 	Redeemers       []Redeemer `json:"redeemers"`
+	VkeyWitnesses   []VkeyWitness `json:"vkey_witnesses"`
+	NativeScripts   []NativeScript `json:"native_scripts"`
 }
 
 // IsValid performs validation on the Witness view model.